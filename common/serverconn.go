@@ -149,24 +149,40 @@ func (c *ServerConn[ClientMsg, _]) ReadMessage() (ClientMsg, error) {
 
 // SendMessage sends a message to the client. It is safe to call SendMessage
 // from multiple goroutines.
-func (c *ServerConn[_, ServerMsg]) SendMessage(_ context.Context, msg ServerMsg) error {
-	// TODO: need to handle context cancellation
+func (c *ServerConn[_, ServerMsg]) SendMessage(ctx context.Context, msg ServerMsg) error {
+	data, err := c.PackMessage(msg)
+	if err != nil {
+		return err
+	}
+	return c.SendPacket(ctx, data)
+}
 
+// PackMessage packs a message with its ID, ready to be sent with SendPacket.
+// Packing may write to the message, so a message that is sent to several
+// clients at once should be packed once and sent to each of them as a
+// packet.
+func (c *ServerConn[_, ServerMsg]) PackMessage(msg ServerMsg) ([]byte, error) {
 	data, err := restruct.Pack(binary.LittleEndian, msg)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	id, err := c.ServerMsg.ID(msg)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	msgid := [2]byte{}
 	binary.LittleEndian.PutUint16(msgid[:], id)
-	data = append(msgid[:], data...)
+	return append(msgid[:], data...), nil
+}
 
-	data, err = pangcrypt.ServerEncrypt(data, c.key, 0)
+// SendPacket sends a packed message to the client. It is safe to call
+// SendPacket from multiple goroutines, with the same data.
+func (c *ServerConn[_, _]) SendPacket(_ context.Context, data []byte) error {
+	// TODO: need to handle context cancellation
+
+	data, err := pangcrypt.ServerEncrypt(data, c.key, 0)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,128 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pangbox/server/gen/dbmodels"
+)
+
+// AchievementStage is one step of an achievement. It is completed once the
+// achievement's counter reaches Goal, at which point its reward is granted.
+type AchievementStage struct {
+	AchievementTypeID int64
+	StageTypeID       int64
+	Goal              int64
+	ItemTypeID        int64
+	Quantity          int64
+	Pang              int64
+}
+
+// AddAchievementCounter adds amount to one of the player's achievement
+// counters, then completes any of the given stages whose goal has been
+// reached. Rewards are only granted the first time a stage is completed. The
+// new counter value and the newly completed stages are returned.
+func (s *Service) AddAchievementCounter(ctx context.Context, playerID, counterTypeID, amount int64, stages []AchievementStage, now time.Time) (int64, []AchievementStage, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	value, err := queries.AddAchievementCounter(ctx, dbmodels.AddAchievementCounterParams{
+		PlayerID:      playerID,
+		CounterTypeID: counterTypeID,
+		Value:         amount,
+	})
+	if err != nil {
+		return 0, nil, fmt.Errorf("adding achievement counter: %w", err)
+	}
+
+	var completed []AchievementStage
+	for _, stage := range stages {
+		if value < stage.Goal {
+			continue
+		}
+		ok, err := s.completeAchievementStageWith(ctx, queries, playerID, stage, now)
+		if err != nil {
+			return 0, nil, err
+		}
+		if ok {
+			completed = append(completed, stage)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return value, completed, nil
+}
+
+// completeAchievementStageWith marks an achievement stage as completed and
+// grants its reward. It returns false if the stage was already completed.
+func (s *Service) completeAchievementStageWith(ctx context.Context, tx *dbmodels.Queries, playerID int64, stage AchievementStage, now time.Time) (bool, error) {
+	n, err := tx.CompleteAchievementStage(ctx, dbmodels.CompleteAchievementStageParams{
+		PlayerID:          playerID,
+		AchievementTypeID: stage.AchievementTypeID,
+		StageTypeID:       stage.StageTypeID,
+		CompletedAt:       now.Unix(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("completing achievement stage: %w", err)
+	}
+	if n == 0 {
+		return false, nil
+	}
+
+	if stage.ItemTypeID != 0 {
+		if err := s.grantItemWith(ctx, tx, playerID, stage.ItemTypeID, stage.Quantity); err != nil {
+			return false, fmt.Errorf("granting achievement item: %w", err)
+		}
+	}
+
+	if stage.Pang != 0 {
+		if _, err := s.changeCurrencyWith(ctx, tx, playerID, CurrencyChange{
+			Pang:   stage.Pang,
+			Reason: CurrencyReasonAchievement,
+			Note:   fmt.Sprintf("achievement stage %08x", stage.StageTypeID),
+		}); err != nil {
+			return false, fmt.Errorf("granting achievement pang: %w", err)
+		}
+	}
+
+	return true, nil
+}
+
+// GetAchievementCounters returns the values of all of the player's
+// achievement counters.
+func (s *Service) GetAchievementCounters(ctx context.Context, playerID int64) ([]dbmodels.AchievementCounter, error) {
+	return s.queries.GetAchievementCounters(ctx, playerID)
+}
+
+// GetAchievementCompletions returns all of the achievement stages the player
+// has completed.
+func (s *Service) GetAchievementCompletions(ctx context.Context, playerID int64) ([]dbmodels.AchievementCompletion, error) {
+	return s.queries.GetAchievementCompletions(ctx, playerID)
+}
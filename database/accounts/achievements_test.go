@@ -0,0 +1,72 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAchievementCounter(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+
+	stages := []AchievementStage{
+		{AchievementTypeID: 0x4C80002D, StageTypeID: 0x7480087C, Goal: 1, Pang: 500},
+		{AchievementTypeID: 0x4C80002D, StageTypeID: 0x7480087D, Goal: 3, ItemTypeID: 0x18000004, Quantity: 2},
+	}
+	now := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	value, completed, err := s.AddAchievementCounter(ctx, player.PlayerID, 0x6C000001, 1, stages, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), value)
+	assert.Equal(t, stages[:1], completed)
+
+	currency, err := s.queries.GetPlayerCurrency(ctx, player.PlayerID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(20500), currency.Pang)
+
+	// Already completed stages are not rewarded again.
+	value, completed, err = s.AddAchievementCounter(ctx, player.PlayerID, 0x6C000001, 1, stages, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), value)
+	assert.Empty(t, completed)
+
+	value, completed, err = s.AddAchievementCounter(ctx, player.PlayerID, 0x6C000001, 2, stages, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), value)
+	assert.Equal(t, stages[1:], completed)
+
+	currency, err = s.queries.GetPlayerCurrency(ctx, player.PlayerID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(20500), currency.Pang)
+
+	counters, err := s.GetAchievementCounters(ctx, player.PlayerID)
+	require.NoError(t, err)
+	require.Len(t, counters, 1)
+	assert.Equal(t, int64(4), counters[0].Value)
+
+	completions, err := s.GetAchievementCompletions(ctx, player.PlayerID)
+	require.NoError(t, err)
+	assert.Len(t, completions, 2)
+}
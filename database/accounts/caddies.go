@@ -0,0 +1,149 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pangbox/server/gen/dbmodels"
+	"github.com/pangbox/server/pangya"
+)
+
+var (
+	ErrCaddieNotOwned    = errors.New("caddie not owned")
+	ErrMascotNotOwned    = errors.New("mascot not owned")
+	ErrNoCaddie          = errors.New("no caddie equipped")
+	ErrMascotTextTooLong = errors.New("mascot text too long")
+)
+
+// MaxMascotTextLength is the longest message a mascot can display.
+const MaxMascotTextLength = 15
+
+// SetCaddie equips a caddie from the player's inventory. A caddie ID of zero
+// unequips the current caddie. Rented caddies can't be equipped once their
+// time limit has passed.
+func (s *Service) SetCaddie(ctx context.Context, playerID int64, caddieID int64, now time.Time) error {
+	caddie := sql.NullInt64{}
+	if caddieID != 0 {
+		_, ok, err := s.getOwnedItemWith(ctx, s.queries, playerID, caddieID, pangya.ItemGroupCaddie, now)
+		if err != nil {
+			return err
+		} else if !ok {
+			return ErrCaddieNotOwned
+		}
+		caddie = sql.NullInt64{Valid: true, Int64: caddieID}
+	}
+	_, err := s.queries.SetPlayerCaddie(ctx, dbmodels.SetPlayerCaddieParams{
+		PlayerID: playerID,
+		CaddieID: caddie,
+	})
+	return err
+}
+
+// SetMascot equips a mascot from the player's inventory. A mascot ID of zero
+// unequips the current mascot. Rented mascots can't be equipped once their
+// time limit has passed.
+func (s *Service) SetMascot(ctx context.Context, playerID int64, mascotID int64, now time.Time) error {
+	mascot := sql.NullInt64{}
+	if mascotID != 0 {
+		_, ok, err := s.getOwnedItemWith(ctx, s.queries, playerID, mascotID, pangya.ItemGroupMascot, now)
+		if err != nil {
+			return err
+		} else if !ok {
+			return ErrMascotNotOwned
+		}
+		mascot = sql.NullInt64{Valid: true, Int64: mascotID}
+	}
+	_, err := s.queries.SetPlayerMascot(ctx, dbmodels.SetPlayerMascotParams{
+		PlayerID: playerID,
+		MascotID: mascot,
+	})
+	return err
+}
+
+// SetMascotText sets the message shown by one of the player's mascots.
+func (s *Service) SetMascotText(ctx context.Context, playerID int64, mascotID int64, text string, now time.Time) error {
+	if len(text) > MaxMascotTextLength {
+		return ErrMascotTextTooLong
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	_, ok, err := s.getOwnedItemWith(ctx, queries, playerID, mascotID, pangya.ItemGroupMascot, now)
+	if err != nil {
+		return err
+	} else if !ok {
+		return ErrMascotNotOwned
+	}
+
+	if _, err := queries.SetMascotText(ctx, dbmodels.SetMascotTextParams{
+		MascotText: sql.NullString{Valid: true, String: text},
+		PlayerID:   playerID,
+		ItemID:     mascotID,
+	}); err != nil {
+		return fmt.Errorf("setting mascot text: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// AddCaddieExp adds EXP to the player's equipped caddie, returning the
+// caddie's new level and EXP.
+func (s *Service) AddCaddieExp(ctx context.Context, playerID int64, add int) (int, int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	caddie, err := queries.GetEquippedCaddie(ctx, playerID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, 0, ErrNoCaddie
+	} else if err != nil {
+		return 0, 0, fmt.Errorf("getting equipped caddie: %w", err)
+	}
+
+	level, exp := pangya.AddCaddieExperience(int(caddie.CaddieLevel), int(caddie.CaddieExp), add)
+	caddie, err = queries.SetCaddieExp(ctx, dbmodels.SetCaddieExpParams{
+		CaddieLevel: int64(level),
+		CaddieExp:   int64(exp),
+		PlayerID:    playerID,
+		ItemID:      caddie.ItemID,
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("setting caddie exp: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, err
+	}
+
+	return int(caddie.CaddieLevel), int(caddie.CaddieExp), nil
+}
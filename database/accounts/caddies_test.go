@@ -0,0 +1,107 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/pangbox/server/gen/dbmodels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetCaddie(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+	other, err := s.Register(ctx, "other", "other")
+	require.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+
+	caddie, err := s.queries.AddItemToInventory(ctx, dbmodels.AddItemToInventoryParams{
+		PlayerID:   player.PlayerID,
+		ItemTypeID: 0x1C000001,
+	})
+	require.NoError(t, err)
+	rental, err := s.queries.AddItemToInventory(ctx, dbmodels.AddItemToInventoryParams{
+		PlayerID:    player.PlayerID,
+		ItemTypeID:  0x1C000002,
+		PurchasedAt: sql.NullInt64{Valid: true, Int64: now.Add(-48 * time.Hour).Unix()},
+		ExpiresAt:   sql.NullInt64{Valid: true, Int64: now.Add(-24 * time.Hour).Unix()},
+	})
+	require.NoError(t, err)
+	club, err := s.AddClubSet(ctx, player.PlayerID, 0x10000061)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, s.SetCaddie(ctx, other.PlayerID, caddie.ItemID, now), ErrCaddieNotOwned)
+	assert.ErrorIs(t, s.SetCaddie(ctx, player.PlayerID, club.ItemID, now), ErrCaddieNotOwned)
+	assert.ErrorIs(t, s.SetCaddie(ctx, player.PlayerID, rental.ItemID, now), ErrCaddieNotOwned)
+
+	_, _, err = s.AddCaddieExp(ctx, player.PlayerID, 100)
+	assert.ErrorIs(t, err, ErrNoCaddie)
+
+	require.NoError(t, s.SetCaddie(ctx, player.PlayerID, caddie.ItemID, now))
+	level, exp, err := s.AddCaddieExp(ctx, player.PlayerID, 300)
+	require.NoError(t, err)
+	assert.Equal(t, 0, level)
+	assert.Equal(t, 300, exp)
+	level, exp, err = s.AddCaddieExp(ctx, player.PlayerID, 300)
+	require.NoError(t, err)
+	assert.Equal(t, 1, level)
+	assert.Equal(t, 100, exp)
+
+	require.NoError(t, s.SetCaddie(ctx, player.PlayerID, 0, now))
+	_, _, err = s.AddCaddieExp(ctx, player.PlayerID, 100)
+	assert.ErrorIs(t, err, ErrNoCaddie)
+}
+
+func TestSetMascot(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+	now := time.Unix(1700000000, 0)
+
+	mascot, err := s.queries.AddItemToInventory(ctx, dbmodels.AddItemToInventoryParams{
+		PlayerID:    player.PlayerID,
+		ItemTypeID:  0x40000001,
+		PurchasedAt: sql.NullInt64{Valid: true, Int64: now.Unix()},
+		ExpiresAt:   sql.NullInt64{Valid: true, Int64: now.Add(24 * time.Hour).Unix()},
+	})
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, s.SetMascot(ctx, player.PlayerID, mascot.ItemID+1, now), ErrMascotNotOwned)
+	assert.ErrorIs(t, s.SetMascot(ctx, player.PlayerID, mascot.ItemID, now.Add(48*time.Hour)), ErrMascotNotOwned)
+	require.NoError(t, s.SetMascot(ctx, player.PlayerID, mascot.ItemID, now))
+
+	assert.ErrorIs(t, s.SetMascotText(ctx, player.PlayerID, mascot.ItemID, "this is far too long", now), ErrMascotTextTooLong)
+	require.NoError(t, s.SetMascotText(ctx, player.PlayerID, mascot.ItemID, "hello!", now))
+	item, err := s.queries.GetItem(ctx, dbmodels.GetItemParams{PlayerID: player.PlayerID, ItemID: mascot.ItemID})
+	require.NoError(t, err)
+	assert.Equal(t, "hello!", item.MascotText.String)
+
+	// Expiring the mascot unequips it.
+	removed, err := s.RemoveExpiredItems(ctx, now.Add(48*time.Hour), 10, noPartDefaults)
+	require.NoError(t, err)
+	require.Len(t, removed, 1)
+	equipped, err := s.queries.GetPlayerByUsername(ctx, "test")
+	require.NoError(t, err)
+	assert.False(t, equipped.MascotID.Valid)
+}
@@ -0,0 +1,290 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/pangbox/server/gen/dbmodels"
+)
+
+var (
+	ErrNoCardPack      = errors.New("no card pack in inventory")
+	ErrCardNotOwned    = errors.New("card not in inventory")
+	ErrCardSlotInvalid = errors.New("invalid card slot")
+	ErrCardSlotEmpty   = errors.New("card slot is empty")
+)
+
+// CardSlotKind is the kind of slot a card can be equipped to.
+type CardSlotKind int64
+
+const (
+	CardSlotCharacter CardSlotKind = 0
+	CardSlotCaddie    CardSlotKind = 1
+	CardSlotNPC       CardSlotKind = 2
+)
+
+// NumSlots returns how many slots of this kind each character or caddie
+// has. There is only one set of NPC slots, which belongs to the player.
+func (k CardSlotKind) NumSlots() int64 {
+	switch k {
+	case CardSlotCharacter, CardSlotCaddie:
+		return 4
+	case CardSlotNPC:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// CardSlot identifies one of the card slots of the player's equipped
+// character or caddie, or one of the player's NPC slots.
+type CardSlot struct {
+	Kind CardSlotKind
+	Num  int64
+}
+
+func (slot CardSlot) valid() bool {
+	return slot.Num >= 0 && slot.Num < slot.Kind.NumSlots()
+}
+
+// cardSlotItemsWith returns the item IDs of the player's equipped character
+// and caddie, whose card slots are in use. Either is zero if there isn't
+// one equipped.
+func (s *Service) cardSlotItemsWith(ctx context.Context, tx *dbmodels.Queries, playerID int64) (characterItemID, caddieItemID int64, err error) {
+	owners, err := tx.GetCardSlotOwners(ctx, playerID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("getting equipped character and caddie: %w", err)
+	}
+	if owners.CharacterID.Valid {
+		character, err := tx.GetCharacter(ctx, owners.CharacterID.Int64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("getting character: %w", err)
+		}
+		characterItemID = character.ItemID
+	}
+	return characterItemID, owners.CaddieID.Int64, nil
+}
+
+// cardSlotItemWith returns the item that the cards in slot are equipped to:
+// the player's equipped character or caddie. NPC slots belong to the player,
+// so their item ID is zero. ErrCardSlotInvalid is returned if the slot
+// doesn't exist, or there is no character or caddie equipped for it.
+func (s *Service) cardSlotItemWith(ctx context.Context, tx *dbmodels.Queries, playerID int64, slot CardSlot) (int64, error) {
+	if !slot.valid() {
+		return 0, ErrCardSlotInvalid
+	}
+	if slot.Kind == CardSlotNPC {
+		return 0, nil
+	}
+	characterItemID, caddieItemID, err := s.cardSlotItemsWith(ctx, tx, playerID)
+	if err != nil {
+		return 0, err
+	}
+	itemID := characterItemID
+	if slot.Kind == CardSlotCaddie {
+		itemID = caddieItemID
+	}
+	if itemID == 0 {
+		return 0, ErrCardSlotInvalid
+	}
+	return itemID, nil
+}
+
+// OpenCardPack uses up one of the player's card packs of type packTypeID and
+// adds one of each card in cardTypeIDs to their inventory. The inventory item
+// ID of the pack is returned.
+func (s *Service) OpenCardPack(ctx context.Context, playerID, packTypeID int64, cardTypeIDs []int64) (int64, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	packs, err := queries.GetItemsByTypeID(ctx, dbmodels.GetItemsByTypeIDParams{
+		PlayerID:   playerID,
+		ItemTypeID: packTypeID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("getting card packs: %w", err)
+	}
+	if len(packs) == 0 {
+		return 0, ErrNoCardPack
+	}
+	packItemID, err := s.decrementConsumableQuantityWith(ctx, queries, playerID, packTypeID)
+	if err != nil {
+		return 0, fmt.Errorf("using card pack: %w", err)
+	}
+
+	for _, cardTypeID := range cardTypeIDs {
+		if err := s.grantItemWith(ctx, queries, playerID, cardTypeID, 1); err != nil {
+			return 0, fmt.Errorf("adding card %08x: %w", cardTypeID, err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return packItemID, nil
+}
+
+// GetCardSlots returns the cards equipped to the player's equipped character
+// and caddie, and to their NPC slots.
+func (s *Service) GetCardSlots(ctx context.Context, playerID int64) ([]dbmodels.CardSlot, error) {
+	characterItemID, caddieItemID, err := s.cardSlotItemsWith(ctx, s.queries, playerID)
+	if err != nil {
+		return nil, err
+	}
+	slots, err := s.queries.GetCardSlots(ctx, dbmodels.GetCardSlotsParams{
+		PlayerID:        playerID,
+		CharacterItemID: characterItemID,
+		CaddieItemID:    caddieItemID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting card slots: %w", err)
+	}
+	return slots, nil
+}
+
+// EquipCard takes a card out of the player's inventory and puts it in slot.
+// A card that was already in the slot goes back to the inventory.
+func (s *Service) EquipCard(ctx context.Context, playerID int64, slot CardSlot, cardTypeID int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	itemID, err := s.cardSlotItemWith(ctx, queries, playerID, slot)
+	if err != nil {
+		return err
+	}
+
+	cards, err := queries.GetItemsByTypeID(ctx, dbmodels.GetItemsByTypeIDParams{
+		PlayerID:   playerID,
+		ItemTypeID: cardTypeID,
+	})
+	if err != nil {
+		return fmt.Errorf("getting cards: %w", err)
+	}
+	if len(cards) == 0 {
+		return ErrCardNotOwned
+	}
+	if _, err := s.decrementConsumableQuantityWith(ctx, queries, playerID, cardTypeID); err != nil {
+		return fmt.Errorf("taking card from inventory: %w", err)
+	}
+
+	if err := s.returnCardWith(ctx, queries, playerID, itemID, slot); err != nil && !errors.Is(err, ErrCardSlotEmpty) {
+		return err
+	}
+
+	if err := queries.SetCardSlot(ctx, dbmodels.SetCardSlotParams{
+		PlayerID:   playerID,
+		ItemID:     itemID,
+		SlotKind:   int64(slot.Kind),
+		SlotNum:    slot.Num,
+		CardTypeID: cardTypeID,
+	}); err != nil {
+		return fmt.Errorf("setting card slot: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// UnequipCard takes the card out of slot and puts it back in the player's
+// inventory.
+func (s *Service) UnequipCard(ctx context.Context, playerID int64, slot CardSlot) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	itemID, err := s.cardSlotItemWith(ctx, queries, playerID, slot)
+	if err != nil {
+		return err
+	}
+
+	if err := s.returnCardWith(ctx, queries, playerID, itemID, slot); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// returnCardWith empties slot of the given character or caddie, putting its
+// card back in the inventory.
+func (s *Service) returnCardWith(ctx context.Context, tx *dbmodels.Queries, playerID, itemID int64, slot CardSlot) error {
+	equipped, err := tx.GetCardSlot(ctx, dbmodels.GetCardSlotParams{
+		PlayerID: playerID,
+		ItemID:   itemID,
+		SlotKind: int64(slot.Kind),
+		SlotNum:  slot.Num,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrCardSlotEmpty
+	} else if err != nil {
+		return fmt.Errorf("getting card slot: %w", err)
+	}
+
+	if err := tx.ClearCardSlot(ctx, dbmodels.ClearCardSlotParams{
+		PlayerID: playerID,
+		ItemID:   itemID,
+		SlotKind: int64(slot.Kind),
+		SlotNum:  slot.Num,
+	}); err != nil {
+		return fmt.Errorf("clearing card slot: %w", err)
+	}
+
+	if err := s.incrementConsumableQuantityWith(ctx, tx, playerID, equipped.CardTypeID, 1); err != nil {
+		return fmt.Errorf("returning card to inventory: %w", err)
+	}
+	return nil
+}
+
+// returnItemCardsWith puts every card equipped to a character or caddie
+// back in the player's inventory.
+func (s *Service) returnItemCardsWith(ctx context.Context, tx *dbmodels.Queries, playerID, itemID int64) error {
+	slots, err := tx.GetItemCardSlots(ctx, dbmodels.GetItemCardSlotsParams{
+		PlayerID: playerID,
+		ItemID:   itemID,
+	})
+	if err != nil {
+		return fmt.Errorf("getting card slots: %w", err)
+	}
+	for _, slot := range slots {
+		if err := s.returnCardWith(ctx, tx, playerID, itemID, CardSlot{
+			Kind: CardSlotKind(slot.SlotKind),
+			Num:  slot.SlotNum,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
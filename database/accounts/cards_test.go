@@ -0,0 +1,126 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/pangbox/server/gen/dbmodels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCards(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+	const (
+		packTypeID  = 0x1A000100
+		cardTypeID  = 0x7C000001
+		card2TypeID = 0x7C000002
+	)
+
+	_, err := s.OpenCardPack(ctx, player.PlayerID, packTypeID, []int64{cardTypeID})
+	assert.ErrorIs(t, err, ErrNoCardPack)
+
+	_, err = s.queries.AddItemToInventory(ctx, dbmodels.AddItemToInventoryParams{
+		PlayerID:   player.PlayerID,
+		ItemTypeID: packTypeID,
+		Quantity:   sql.NullInt64{Valid: true, Int64: 1},
+	})
+	require.NoError(t, err)
+	_, err = s.OpenCardPack(ctx, player.PlayerID, packTypeID, []int64{cardTypeID, cardTypeID, card2TypeID})
+	require.NoError(t, err)
+
+	packs, err := s.queries.GetItemsByTypeID(ctx, dbmodels.GetItemsByTypeIDParams{PlayerID: player.PlayerID, ItemTypeID: packTypeID})
+	require.NoError(t, err)
+	assert.Empty(t, packs)
+	cards, err := s.queries.GetItemsByTypeID(ctx, dbmodels.GetItemsByTypeIDParams{PlayerID: player.PlayerID, ItemTypeID: cardTypeID})
+	require.NoError(t, err)
+	require.Len(t, cards, 1)
+	assert.Equal(t, int64(2), cards[0].Quantity.Int64)
+
+	slot := CardSlot{Kind: CardSlotCaddie, Num: 0}
+	assert.ErrorIs(t, s.EquipCard(ctx, player.PlayerID, CardSlot{Kind: CardSlotNPC, Num: 1}, cardTypeID), ErrCardSlotInvalid)
+
+	// Caddie slots belong to the equipped caddie.
+	assert.ErrorIs(t, s.EquipCard(ctx, player.PlayerID, slot, cardTypeID), ErrCardSlotInvalid)
+	caddie, err := s.queries.AddItemToInventory(ctx, dbmodels.AddItemToInventoryParams{
+		PlayerID:   player.PlayerID,
+		ItemTypeID: 0x1C000001,
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.SetCaddie(ctx, player.PlayerID, caddie.ItemID, time.Now()))
+
+	assert.ErrorIs(t, s.EquipCard(ctx, player.PlayerID, slot, 0x7C000003), ErrCardNotOwned)
+	require.NoError(t, s.EquipCard(ctx, player.PlayerID, slot, cardTypeID))
+
+	// Equipping over a card puts the old one back in the inventory.
+	require.NoError(t, s.EquipCard(ctx, player.PlayerID, slot, card2TypeID))
+	cards, err = s.queries.GetItemsByTypeID(ctx, dbmodels.GetItemsByTypeIDParams{PlayerID: player.PlayerID, ItemTypeID: cardTypeID})
+	require.NoError(t, err)
+	require.Len(t, cards, 1)
+	assert.Equal(t, int64(2), cards[0].Quantity.Int64)
+
+	slots, err := s.GetCardSlots(ctx, player.PlayerID)
+	require.NoError(t, err)
+	require.Len(t, slots, 1)
+	assert.Equal(t, int64(card2TypeID), slots[0].CardTypeID)
+
+	require.NoError(t, s.UnequipCard(ctx, player.PlayerID, slot))
+	assert.ErrorIs(t, s.UnequipCard(ctx, player.PlayerID, slot), ErrCardSlotEmpty)
+	slots, err = s.GetCardSlots(ctx, player.PlayerID)
+	require.NoError(t, err)
+	assert.Empty(t, slots)
+	cards, err = s.queries.GetItemsByTypeID(ctx, dbmodels.GetItemsByTypeIDParams{PlayerID: player.PlayerID, ItemTypeID: card2TypeID})
+	require.NoError(t, err)
+	require.Len(t, cards, 1)
+	assert.Equal(t, int64(1), cards[0].Quantity.Int64)
+
+	// Each character has its own slots.
+	first, err := s.AddCharacter(ctx, player.PlayerID, NewCharacterParams{CharTypeID: 0x04000001})
+	require.NoError(t, err)
+	second, err := s.AddCharacter(ctx, player.PlayerID, NewCharacterParams{CharTypeID: 0x04000002})
+	require.NoError(t, err)
+	charSlot := CardSlot{Kind: CardSlotCharacter, Num: 1}
+	require.NoError(t, s.SetCharacter(ctx, player.PlayerID, first.CharacterID))
+	require.NoError(t, s.EquipCard(ctx, player.PlayerID, charSlot, cardTypeID))
+	require.NoError(t, s.SetCharacter(ctx, player.PlayerID, second.CharacterID))
+	slots, err = s.GetCardSlots(ctx, player.PlayerID)
+	require.NoError(t, err)
+	assert.Empty(t, slots)
+	assert.ErrorIs(t, s.UnequipCard(ctx, player.PlayerID, charSlot), ErrCardSlotEmpty)
+	require.NoError(t, s.SetCharacter(ctx, player.PlayerID, first.CharacterID))
+	slots, err = s.GetCardSlots(ctx, player.PlayerID)
+	require.NoError(t, err)
+	require.Len(t, slots, 1)
+	assert.Equal(t, first.ItemID, slots[0].ItemID)
+
+	// Cards come back when the caddie they are equipped to leaves the
+	// inventory.
+	require.NoError(t, s.EquipCard(ctx, player.PlayerID, slot, cardTypeID))
+	_, err = s.PutLockerItem(ctx, player.PlayerID, caddie.ItemID, noPartDefaults)
+	require.NoError(t, err)
+	cards, err = s.queries.GetItemsByTypeID(ctx, dbmodels.GetItemsByTypeIDParams{PlayerID: player.PlayerID, ItemTypeID: cardTypeID})
+	require.NoError(t, err)
+	require.Len(t, cards, 1)
+	assert.Equal(t, int64(1), cards[0].Quantity.Int64)
+}
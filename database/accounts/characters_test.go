@@ -0,0 +1,153 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/pangbox/server/gen/dbmodels"
+	"github.com/pangbox/server/pangya"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetCharacterParts(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+	other, err := s.Register(ctx, "other", "other")
+	require.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+
+	const (
+		defaultHead = 0x08040000 // character 1, slot 0
+		defaultBody = 0x08042000 // character 1, slot 1
+		ownedHead   = 0x08040001
+		otherHead   = 0x08000001 // character 0, slot 0
+		unknownHead = 0x08040002
+		ringTypeID  = 0x70000001
+		cutInTypeID = 0x38800001
+		titleTypeID = 0x39C00001
+	)
+	parts := map[uint32]PartInfo{
+		defaultHead: {Character: 1, Slot: 0},
+		defaultBody: {Character: 1, Slot: 1},
+		ownedHead:   {Character: 1, Slot: 0},
+		otherHead:   {Character: 0, Slot: 0},
+	}
+	lookupPart := func(typeID uint32) (PartInfo, bool) {
+		part, ok := parts[typeID]
+		return part, ok
+	}
+	defaults := [24]uint32{defaultHead, defaultBody}
+	character, err := s.AddCharacter(ctx, player.PlayerID, NewCharacterParams{
+		CharTypeID:         0x04000001,
+		DefaultPartTypeIDs: defaults,
+	})
+	require.NoError(t, err)
+
+	addItem := func(playerID int64, typeID int64) uint32 {
+		item, err := s.queries.AddItemToInventory(ctx, dbmodels.AddItemToInventoryParams{
+			PlayerID:   playerID,
+			ItemTypeID: typeID,
+		})
+		require.NoError(t, err)
+		return uint32(item.ItemID)
+	}
+	head := addItem(player.PlayerID, ownedHead)
+	wrongHead := addItem(player.PlayerID, otherHead)
+	unknown := addItem(player.PlayerID, unknownHead)
+	ring := addItem(player.PlayerID, ringTypeID)
+	otherRing := addItem(other.PlayerID, ringTypeID)
+	cutIn := addItem(player.PlayerID, cutInTypeID)
+	title := addItem(player.PlayerID, titleTypeID)
+	expired, err := s.queries.AddItemToInventory(ctx, dbmodels.AddItemToInventoryParams{
+		PlayerID:   player.PlayerID,
+		ItemTypeID: cutInTypeID,
+		ExpiresAt:  sql.NullInt64{Valid: true, Int64: now.Add(-time.Hour).Unix()},
+	})
+	require.NoError(t, err)
+
+	valid := pangya.PlayerCharacterData{
+		ID:          uint32(character.CharacterID),
+		PartTypeIDs: [24]uint32{ownedHead, defaultBody},
+		PartIDs:     [24]uint32{head},
+		AuxParts:    [5]uint32{ring},
+		CutInID:     cutIn,
+	}
+
+	invalid := func(modify func(*pangya.PlayerCharacterData)) pangya.PlayerCharacterData {
+		data := valid
+		modify(&data)
+		return data
+	}
+	for _, test := range []struct {
+		name string
+		data pangya.PlayerCharacterData
+		err  error
+	}{
+		{"another player's character", invalid(func(d *pangya.PlayerCharacterData) {}), ErrCharacterNotFound},
+		{"part without item", invalid(func(d *pangya.PlayerCharacterData) { d.PartIDs[0] = 0 }), ErrCharacterPartNotOwned},
+		{"item of another type", invalid(func(d *pangya.PlayerCharacterData) { d.PartTypeIDs[0] = defaultHead }), ErrCharacterPartNotOwned},
+		{"part for another character", invalid(func(d *pangya.PlayerCharacterData) {
+			d.PartTypeIDs[0], d.PartIDs[0] = otherHead, wrongHead
+		}), ErrCharacterPartInvalid},
+		{"part in the wrong slot", invalid(func(d *pangya.PlayerCharacterData) {
+			d.PartTypeIDs[0], d.PartIDs[0] = defaultHead, 0
+			d.PartTypeIDs[1], d.PartIDs[1] = ownedHead, head
+		}), ErrCharacterPartInvalid},
+		{"part not in the IFF", invalid(func(d *pangya.PlayerCharacterData) {
+			d.PartTypeIDs[0], d.PartIDs[0] = unknownHead, unknown
+		}), ErrCharacterPartInvalid},
+		{"part as aux part", invalid(func(d *pangya.PlayerCharacterData) { d.AuxParts[1] = head }), ErrCharacterAuxPartInvalid},
+		{"aux part equipped twice", invalid(func(d *pangya.PlayerCharacterData) { d.AuxParts[1] = ring }), ErrCharacterAuxPartInvalid},
+		{"aux part not owned", invalid(func(d *pangya.PlayerCharacterData) { d.AuxParts[0] = otherRing }), ErrCharacterAuxPartInvalid},
+		{"cut-in of another kind", invalid(func(d *pangya.PlayerCharacterData) { d.CutInID = title }), ErrCharacterCutInInvalid},
+		{"expired cut-in", invalid(func(d *pangya.PlayerCharacterData) { d.CutInID = uint32(expired.ItemID) }), ErrCharacterCutInInvalid},
+		{"part as cut-in", invalid(func(d *pangya.PlayerCharacterData) { d.CutInID = head }), ErrCharacterCutInInvalid},
+	} {
+		playerID := player.PlayerID
+		if test.err == ErrCharacterNotFound {
+			playerID = other.PlayerID
+		}
+		err := s.SetCharacterParts(ctx, playerID, test.data, defaults, lookupPart, now)
+		assert.ErrorIs(t, err, test.err, test.name)
+	}
+
+	// Nothing was saved by the rejected attempts.
+	row, err := s.queries.GetCharacter(ctx, character.CharacterID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(defaultHead), row.Part00ItemTypeID)
+	assert.False(t, row.Part00ItemID.Valid)
+
+	require.NoError(t, s.SetCharacterParts(ctx, player.PlayerID, valid, defaults, lookupPart, now))
+	row, err = s.queries.GetCharacter(ctx, character.CharacterID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(ownedHead), row.Part00ItemTypeID)
+	assert.Equal(t, int64(head), row.Part00ItemID.Int64)
+	assert.Equal(t, int64(defaultBody), row.Part01ItemTypeID)
+	assert.Equal(t, int64(ring), row.AuxPart0ID.Int64)
+	assert.Equal(t, int64(cutIn), row.CutInID.Int64)
+
+	// Default parts can be put back without an item.
+	valid.PartTypeIDs[0], valid.PartIDs[0] = defaultHead, 0
+	require.NoError(t, s.SetCharacterParts(ctx, player.PlayerID, valid, defaults, lookupPart, now))
+}
@@ -0,0 +1,181 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/pangbox/server/gen/dbmodels"
+)
+
+var (
+	ErrClubNotFound    = errors.New("club set not found")
+	ErrClubStatInvalid = errors.New("invalid club stat")
+	ErrClubStatMaxed   = errors.New("club stat can't be upgraded any further")
+	ErrClubStatMin     = errors.New("club stat has no upgrades to remove")
+)
+
+// ClubStat is one of the stats of a club set that can be upgraded.
+type ClubStat int
+
+const (
+	ClubStatPower    ClubStat = 0
+	ClubStatControl  ClubStat = 1
+	ClubStatAccuracy ClubStat = 2
+	ClubStatSpin     ClubStat = 3
+	ClubStatCurve    ClubStat = 4
+
+	NumClubStats = 5
+)
+
+// ClubStats are the upgrade levels of each stat of a club set.
+type ClubStats [NumClubStats]int64
+
+// Club is a club set in a player's inventory.
+type Club struct {
+	ItemID     int64
+	ItemTypeID int64
+	Levels     ClubStats
+}
+
+func clubFromItem(item dbmodels.Inventory) Club {
+	return Club{
+		ItemID:     item.ItemID,
+		ItemTypeID: item.ItemTypeID,
+		Levels: ClubStats{
+			item.ClubPower,
+			item.ClubControl,
+			item.ClubAccuracy,
+			item.ClubSpin,
+			item.ClubCurve,
+		},
+	}
+}
+
+// ClubUpgrade describes upgrading or downgrading one stat of a club set by
+// one level.
+type ClubUpgrade struct {
+	ItemID int64
+
+	// ItemTypeID must match the club's type, so the caller's limits apply
+	// to the club that is actually upgraded.
+	ItemTypeID int64
+
+	Stat      ClubStat
+	Downgrade bool
+
+	// MaxLevel is the highest level the stat can be upgraded to.
+	MaxLevel int64
+
+	// Costs are the Pang costs of moving between levels: Costs[n] is
+	// charged to go from level n to n+1, or from n+1 back down to n.
+	Costs []int64
+}
+
+// GetClub returns a club set from the player's inventory.
+func (s *Service) GetClub(ctx context.Context, playerID, itemID int64) (Club, error) {
+	return s.getClubWith(ctx, s.queries, playerID, itemID)
+}
+
+func (s *Service) getClubWith(ctx context.Context, tx *dbmodels.Queries, playerID, itemID int64) (Club, error) {
+	item, err := tx.GetItem(ctx, dbmodels.GetItemParams{
+		PlayerID: playerID,
+		ItemID:   itemID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return Club{}, ErrClubNotFound
+	} else if err != nil {
+		return Club{}, fmt.Errorf("getting club: %w", err)
+	}
+	return clubFromItem(item), nil
+}
+
+// UpgradeClub raises or lowers one stat of a club set by one level, charging
+// the cost for that level. The club and the player's new Pang balance are
+// returned.
+func (s *Service) UpgradeClub(ctx context.Context, playerID int64, upgrade ClubUpgrade) (Club, int64, error) {
+	if upgrade.Stat < 0 || upgrade.Stat >= NumClubStats {
+		return Club{}, 0, ErrClubStatInvalid
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Club{}, 0, err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	club, err := s.getClubWith(ctx, queries, playerID, upgrade.ItemID)
+	if err != nil {
+		return Club{}, 0, err
+	}
+	if club.ItemTypeID != upgrade.ItemTypeID {
+		return Club{}, 0, ErrClubNotFound
+	}
+
+	level := club.Levels[upgrade.Stat]
+	costLevel := level
+	if upgrade.Downgrade {
+		if level <= 0 {
+			return Club{}, 0, ErrClubStatMin
+		}
+		costLevel = level - 1
+		club.Levels[upgrade.Stat] = level - 1
+	} else {
+		if level >= upgrade.MaxLevel || level >= int64(len(upgrade.Costs)) {
+			return Club{}, 0, ErrClubStatMaxed
+		}
+		club.Levels[upgrade.Stat] = level + 1
+	}
+
+	cost := int64(0)
+	if costLevel < int64(len(upgrade.Costs)) {
+		cost = upgrade.Costs[costLevel]
+	}
+	currency, err := s.changeCurrencyWith(ctx, queries, playerID, CurrencyChange{
+		Pang:       -cost,
+		Reason:     CurrencyReasonClubUpgrade,
+		ItemTypeID: club.ItemTypeID,
+	})
+	if err != nil {
+		return Club{}, 0, err
+	}
+
+	if _, err := queries.SetClubStats(ctx, dbmodels.SetClubStatsParams{
+		ClubPower:    club.Levels[ClubStatPower],
+		ClubControl:  club.Levels[ClubStatControl],
+		ClubAccuracy: club.Levels[ClubStatAccuracy],
+		ClubSpin:     club.Levels[ClubStatSpin],
+		ClubCurve:    club.Levels[ClubStatCurve],
+		PlayerID:     playerID,
+		ItemID:       club.ItemID,
+	}); err != nil {
+		return Club{}, 0, fmt.Errorf("setting club stats: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return Club{}, 0, err
+	}
+
+	return club, currency.Pang, nil
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeClub(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+	item, err := s.AddClubSet(ctx, player.PlayerID, 0x10000061)
+	require.NoError(t, err)
+
+	upgrade := ClubUpgrade{
+		ItemID:     item.ItemID,
+		ItemTypeID: item.ItemTypeID,
+		Stat:       ClubStatSpin,
+		MaxLevel:   2,
+		Costs:      []int64{1000, 2000, 3000},
+	}
+
+	_, _, err = s.UpgradeClub(ctx, player.PlayerID, ClubUpgrade{ItemID: item.ItemID, ItemTypeID: 0x10000062, MaxLevel: 2, Costs: upgrade.Costs})
+	assert.ErrorIs(t, err, ErrClubNotFound)
+	_, _, err = s.UpgradeClub(ctx, player.PlayerID, ClubUpgrade{ItemID: item.ItemID, ItemTypeID: item.ItemTypeID, Stat: NumClubStats})
+	assert.ErrorIs(t, err, ErrClubStatInvalid)
+
+	club, pang, err := s.UpgradeClub(ctx, player.PlayerID, upgrade)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), club.Levels[ClubStatSpin])
+	assert.Equal(t, player.Pang-1000, pang)
+
+	club, pang, err = s.UpgradeClub(ctx, player.PlayerID, upgrade)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), club.Levels[ClubStatSpin])
+	assert.Equal(t, player.Pang-3000, pang)
+
+	// The club's max level is lower than the number of configured costs.
+	_, _, err = s.UpgradeClub(ctx, player.PlayerID, upgrade)
+	assert.ErrorIs(t, err, ErrClubStatMaxed)
+
+	// Downgrading charges the cost of the level being removed.
+	upgrade.Downgrade = true
+	upgrade.Costs = []int64{100, 200}
+	club, pang, err = s.UpgradeClub(ctx, player.PlayerID, upgrade)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), club.Levels[ClubStatSpin])
+	assert.Equal(t, player.Pang-3200, pang)
+
+	club, err = s.GetClub(ctx, player.PlayerID, item.ItemID)
+	require.NoError(t, err)
+	assert.Equal(t, ClubStats{0, 0, 0, 1, 0}, club.Levels)
+
+	// Upgrades that can't be paid for are not applied.
+	upgrade.Downgrade = false
+	upgrade.Costs = []int64{0, 1000000}
+	_, _, err = s.UpgradeClub(ctx, player.PlayerID, upgrade)
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+
+}
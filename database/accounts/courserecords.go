@@ -0,0 +1,102 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pangbox/server/gen/dbmodels"
+	"github.com/pangbox/server/pangya"
+)
+
+// CourseResult is the outcome of a single game on a course, as recorded in a
+// player's course records.
+type CourseResult struct {
+	Season     uint8
+	CourseID   uint8
+	CharTypeID uint32
+	Score      int32
+	Pang       uint64
+	Strokes    uint32
+	Putts      uint32
+	Holes      uint32
+}
+
+// AddCourseResult adds a game result to the player's records for the course,
+// season and character it was played with.
+func (s *Service) AddCourseResult(ctx context.Context, playerID int64, result CourseResult) (dbmodels.CourseRecord, error) {
+	record, err := s.queries.AddCourseRecord(ctx, dbmodels.AddCourseRecordParams{
+		PlayerID:     playerID,
+		Season:       int64(result.Season),
+		CourseID:     int64(result.CourseID),
+		CharTypeID:   int64(result.CharTypeID),
+		BestScore:    int64(result.Score),
+		BestPang:     int64(result.Pang),
+		TotalStrokes: int64(result.Strokes),
+		TotalPutts:   int64(result.Putts),
+		TotalHoles:   int64(result.Holes),
+		TotalScore:   int64(result.Score),
+	})
+	if err != nil {
+		return dbmodels.CourseRecord{}, fmt.Errorf("adding course record: %w", err)
+	}
+	return record, nil
+}
+
+// GetSeasonHistory returns the player's course records in the form the client
+// expects. Records for each character are combined per course; the character
+// type reported is the one the best score was set with. The client's history
+// has a fixed number of seasons and courses; records for any others are
+// logged and left out.
+func (s *Service) GetSeasonHistory(ctx context.Context, playerID int64) (pangya.PlayerSeasonHistory, error) {
+	history := pangya.PlayerSeasonHistory{}
+	for i := range history.Seasons {
+		for j := range history.Seasons[i].Courses {
+			history.Seasons[i].Courses[j].CourseID = uint8(j)
+		}
+	}
+	records, err := s.queries.GetCourseRecords(ctx, playerID)
+	if err != nil {
+		return pangya.PlayerSeasonHistory{}, fmt.Errorf("getting course records: %w", err)
+	}
+	for _, record := range records {
+		if record.Season < 0 || record.Season >= int64(len(history.Seasons)) {
+			s.log.Warn().Int64("season", record.Season).Msg("skipping course record for season that doesn't fit in history")
+			continue
+		}
+		courses := &history.Seasons[record.Season].Courses
+		if record.CourseID < 0 || record.CourseID >= int64(len(courses)) {
+			s.log.Warn().Int64("course", record.CourseID).Msg("skipping course record for course that doesn't fit in history")
+			continue
+		}
+		course := &courses[record.CourseID]
+		if course.NumHoles == 0 || int8(record.BestScore) < course.BestScore {
+			course.BestScore = int8(record.BestScore)
+			course.CharTypeID = uint32(record.CharTypeID)
+		}
+		if uint32(record.BestPang) > course.BestPang {
+			course.BestPang = uint32(record.BestPang)
+		}
+		course.TotalStrokes += uint32(record.TotalStrokes)
+		course.TotalPutts += uint32(record.TotalPutts)
+		course.NumHoles += uint32(record.TotalHoles)
+		course.TotalScore += uint32(record.TotalScore)
+	}
+	return history, nil
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeasonHistory(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+
+	results := []CourseResult{
+		{Season: 1, CourseID: 2, CharTypeID: 0x04000000, Score: -2, Pang: 800, Strokes: 34, Putts: 10, Holes: 9},
+		{Season: 1, CourseID: 2, CharTypeID: 0x04000001, Score: -5, Pang: 600, Strokes: 31, Putts: 9, Holes: 9},
+		{Season: 1, CourseID: 2, CharTypeID: 0x04000000, Score: 1, Pang: 1000, Strokes: 37, Putts: 12, Holes: 9},
+		{Season: 2, CourseID: 0, CharTypeID: 0x04000000, Score: 0, Pang: 100, Strokes: 36, Putts: 11, Holes: 9},
+		{Season: 1, CourseID: 21, CharTypeID: 0x04000000, Score: 0, Pang: 100, Strokes: 36, Putts: 11, Holes: 9},
+		{Season: 40, CourseID: 0, CharTypeID: 0x04000000, Score: 0, Pang: 100, Strokes: 36, Putts: 11, Holes: 9},
+	}
+	for _, result := range results {
+		_, err := s.AddCourseResult(ctx, player.PlayerID, result)
+		require.NoError(t, err)
+	}
+
+	record, err := s.AddCourseResult(ctx, player.PlayerID, CourseResult{
+		Season: 1, CourseID: 2, CharTypeID: 0x04000000, Score: 3, Pang: 50, Strokes: 39, Putts: 13, Holes: 9,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(-2), record.BestScore)
+	assert.Equal(t, int64(1000), record.BestPang)
+	assert.Equal(t, int64(27), record.TotalHoles)
+
+	history, err := s.GetSeasonHistory(ctx, player.PlayerID)
+	require.NoError(t, err)
+	course := history.Seasons[1].Courses[2]
+	assert.Equal(t, uint8(2), course.CourseID)
+	assert.Equal(t, int8(-5), course.BestScore)
+	assert.Equal(t, uint32(0x04000001), course.CharTypeID)
+	assert.Equal(t, uint32(1000), course.BestPang)
+	assert.Equal(t, uint32(141), course.TotalStrokes)
+	assert.Equal(t, uint32(44), course.TotalPutts)
+	assert.Equal(t, uint32(36), course.NumHoles)
+	assert.Equal(t, uint32(9), history.Seasons[2].Courses[0].NumHoles)
+	assert.Equal(t, uint32(0), history.Seasons[1].Courses[0].NumHoles)
+}
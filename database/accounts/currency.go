@@ -0,0 +1,215 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pangbox/server/gen/dbmodels"
+)
+
+// ErrInsufficientFunds is returned when a currency change would leave the
+// player with a negative Pang or Cookie Point balance.
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// CurrencyReason is the reason code stored with each currency ledger entry.
+// These values are persisted, so existing values must never be renumbered.
+type CurrencyReason int64
+
+const (
+	CurrencyReasonPurchase    CurrencyReason = 1
+	CurrencyReasonPapel       CurrencyReason = 2
+	CurrencyReasonGameReward  CurrencyReason = 3
+	CurrencyReasonAdminGrant  CurrencyReason = 4
+	CurrencyReasonReversal    CurrencyReason = 5
+	CurrencyReasonMail        CurrencyReason = 6
+	CurrencyReasonLoginBonus  CurrencyReason = 7
+	CurrencyReasonAchievement CurrencyReason = 8
+	CurrencyReasonQuest       CurrencyReason = 9
+	CurrencyReasonLocker      CurrencyReason = 10
+	CurrencyReasonClubUpgrade CurrencyReason = 12
+)
+
+func (r CurrencyReason) String() string {
+	switch r {
+	case CurrencyReasonPurchase:
+		return "purchase"
+	case CurrencyReasonPapel:
+		return "papel"
+	case CurrencyReasonGameReward:
+		return "game reward"
+	case CurrencyReasonAdminGrant:
+		return "admin grant"
+	case CurrencyReasonReversal:
+		return "reversal"
+	case CurrencyReasonMail:
+		return "mail"
+	case CurrencyReasonLoginBonus:
+		return "login bonus"
+	case CurrencyReasonAchievement:
+		return "achievement"
+	case CurrencyReasonQuest:
+		return "quest"
+	case CurrencyReasonLocker:
+		return "locker"
+	case CurrencyReasonClubUpgrade:
+		return "club upgrade"
+	default:
+		return fmt.Sprintf("unknown reason %d", int64(r))
+	}
+}
+
+// CurrencyChange describes a signed change to a player's Pang and Cookie
+// Point balances.
+type CurrencyChange struct {
+	Pang       int64
+	Points     int64
+	Reason     CurrencyReason
+	ItemTypeID int64
+	Note       string
+}
+
+// changeCurrencyWith applies a currency change and records it in the ledger.
+// It must be called inside of a transaction so that the balance update and the
+// ledger entry are committed together.
+func (s *Service) changeCurrencyWith(ctx context.Context, tx *dbmodels.Queries, playerID int64, change CurrencyChange) (dbmodels.SetPlayerCurrencyRow, error) {
+	currency, err := tx.GetPlayerCurrency(ctx, playerID)
+	if err != nil {
+		return dbmodels.SetPlayerCurrencyRow{}, fmt.Errorf("getting player currency: %w", err)
+	}
+
+	newPang := currency.Pang + change.Pang
+	newPoints := currency.Points + change.Points
+	if newPang < 0 || newPoints < 0 {
+		return dbmodels.SetPlayerCurrencyRow{}, ErrInsufficientFunds
+	}
+
+	newCurrency, err := tx.SetPlayerCurrency(ctx, dbmodels.SetPlayerCurrencyParams{
+		PlayerID: playerID,
+		Pang:     newPang,
+		Points:   newPoints,
+	})
+	if err != nil {
+		return dbmodels.SetPlayerCurrencyRow{}, fmt.Errorf("setting player currency: %w", err)
+	}
+
+	_, err = tx.AddCurrencyLedgerEntry(ctx, dbmodels.AddCurrencyLedgerEntryParams{
+		PlayerID:      playerID,
+		Reason:        int64(change.Reason),
+		PangDelta:     change.Pang,
+		PointsDelta:   change.Points,
+		PangBalance:   newCurrency.Pang,
+		PointsBalance: newCurrency.Points,
+		ItemTypeID:    change.ItemTypeID,
+		Note:          change.Note,
+		CreatedAt:     time.Now().Unix(),
+	})
+	if err != nil {
+		return dbmodels.SetPlayerCurrencyRow{}, fmt.Errorf("adding currency ledger entry: %w", err)
+	}
+
+	s.log.Debug().
+		Int64("player", playerID).
+		Stringer("reason", change.Reason).
+		Int64("pang delta", change.Pang).
+		Int64("points delta", change.Points).
+		Msg("currency change")
+
+	return newCurrency, nil
+}
+
+// ChangeCurrency applies a currency change to a player in its own transaction.
+func (s *Service) ChangeCurrency(ctx context.Context, playerID int64, change CurrencyChange) (dbmodels.SetPlayerCurrencyRow, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return dbmodels.SetPlayerCurrencyRow{}, err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	newCurrency, err := s.changeCurrencyWith(ctx, queries, playerID, change)
+	if err != nil {
+		return dbmodels.SetPlayerCurrencyRow{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return dbmodels.SetPlayerCurrencyRow{}, err
+	}
+
+	return newCurrency, nil
+}
+
+// GrantCurrency gives (or, with negative values, takes) currency from a player
+// on behalf of an administrator.
+func (s *Service) GrantCurrency(ctx context.Context, playerID, pang, points int64, note string) (dbmodels.SetPlayerCurrencyRow, error) {
+	return s.ChangeCurrency(ctx, playerID, CurrencyChange{
+		Pang:   pang,
+		Points: points,
+		Reason: CurrencyReasonAdminGrant,
+		Note:   note,
+	})
+}
+
+// GetCurrencyLedger returns the most recent currency ledger entries for a
+// player, newest first.
+func (s *Service) GetCurrencyLedger(ctx context.Context, playerID int64, limit int64) ([]dbmodels.CurrencyLedger, error) {
+	return s.queries.GetCurrencyLedgerByPlayer(ctx, dbmodels.GetCurrencyLedgerByPlayerParams{
+		PlayerID: playerID,
+		Limit:    limit,
+	})
+}
+
+// ReverseCurrencyLedgerEntry undoes a previous ledger entry by applying the
+// opposite change. The original entry is left untouched for auditing.
+func (s *Service) ReverseCurrencyLedgerEntry(ctx context.Context, ledgerID int64, note string) (dbmodels.SetPlayerCurrencyRow, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return dbmodels.SetPlayerCurrencyRow{}, err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	entry, err := queries.GetCurrencyLedgerEntry(ctx, ledgerID)
+	if err != nil {
+		return dbmodels.SetPlayerCurrencyRow{}, fmt.Errorf("getting ledger entry %d: %w", ledgerID, err)
+	}
+
+	newCurrency, err := s.changeCurrencyWith(ctx, queries, entry.PlayerID, CurrencyChange{
+		Pang:       -entry.PangDelta,
+		Points:     -entry.PointsDelta,
+		Reason:     CurrencyReasonReversal,
+		ItemTypeID: entry.ItemTypeID,
+		Note:       fmt.Sprintf("reverses #%d: %s", entry.LedgerID, note),
+	})
+	if err != nil {
+		return dbmodels.SetPlayerCurrencyRow{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return dbmodels.SetPlayerCurrencyRow{}, err
+	}
+
+	return newCurrency, nil
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurchaseItemRecordsLedger(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+
+	currency, err := s.PurchaseItem(ctx, player.PlayerID, ItemPurchase{PangTotal: 1000, ItemTypeID: 0x8000000})
+	require.NoError(t, err)
+	assert.Equal(t, player.Pang-1000, currency.Pang)
+
+	ledger, err := s.GetCurrencyLedger(ctx, player.PlayerID, 10)
+	require.NoError(t, err)
+	require.Len(t, ledger, 1)
+	assert.Equal(t, int64(CurrencyReasonPurchase), ledger[0].Reason)
+	assert.Equal(t, int64(-1000), ledger[0].PangDelta)
+	assert.Equal(t, currency.Pang, ledger[0].PangBalance)
+	assert.Equal(t, int64(0x8000000), ledger[0].ItemTypeID)
+}
+
+func TestPurchaseItemInsufficientFunds(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+
+	_, err := s.PurchaseItem(ctx, player.PlayerID, ItemPurchase{PangTotal: player.Pang + 1, ItemTypeID: 0x8000000})
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// Nothing should have been committed.
+	inventory, err := s.GetPlayerInventory(ctx, player.PlayerID)
+	require.NoError(t, err)
+	assert.Empty(t, inventory)
+	ledger, err := s.GetCurrencyLedger(ctx, player.PlayerID, 10)
+	require.NoError(t, err)
+	assert.Empty(t, ledger)
+	current, err := s.queries.GetPlayerCurrency(ctx, player.PlayerID)
+	require.NoError(t, err)
+	assert.Equal(t, player.Pang, current.Pang)
+}
+
+func TestPurchaseItemsAllOrNothing(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+
+	assertNothingBought := func() {
+		t.Helper()
+		inventory, err := s.GetPlayerInventory(ctx, player.PlayerID)
+		require.NoError(t, err)
+		assert.Empty(t, inventory)
+		current, err := s.queries.GetPlayerCurrency(ctx, player.PlayerID)
+		require.NoError(t, err)
+		assert.Equal(t, player.Pang, current.Pang)
+		sales, err := s.GetRareShopSales(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, sales)
+	}
+
+	// The cart costs more than the player has, though each item doesn't.
+	_, err := s.PurchaseItems(ctx, player.PlayerID, []ItemPurchase{
+		{PangTotal: player.Pang - 1, ItemTypeID: 0x8000000},
+		{PangTotal: 2, ItemTypeID: 0x8000001},
+	})
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+	assertNothingBought()
+
+	// A sold out Rare Shop item fails the whole cart.
+	_, err = s.PurchaseItems(ctx, player.PlayerID, []ItemPurchase{
+		{PangTotal: 1000, ItemTypeID: 0x8000000},
+		{PangTotal: 1000, ItemTypeID: 0x8000100, Rare: true, StockLimit: 1},
+		{PangTotal: 1000, ItemTypeID: 0x8000100, Rare: true, StockLimit: 1},
+	})
+	assert.ErrorIs(t, err, ErrRareItemSoldOut)
+	assertNothingBought()
+
+	currency, err := s.PurchaseItems(ctx, player.PlayerID, []ItemPurchase{
+		{PangTotal: 1000, ItemTypeID: 0x8000000},
+		{PangTotal: 1000, ItemTypeID: 0x8000100, Rare: true, StockLimit: 1},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, player.Pang-2000, currency.Pang)
+	inventory, err := s.GetPlayerInventory(ctx, player.PlayerID)
+	require.NoError(t, err)
+	assert.Len(t, inventory, 2)
+}
+
+func TestReverseCurrencyLedgerEntry(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+
+	_, err := s.GrantCurrency(ctx, player.PlayerID, 5000, 10, "compensation")
+	require.NoError(t, err)
+
+	ledger, err := s.GetCurrencyLedger(ctx, player.PlayerID, 10)
+	require.NoError(t, err)
+	require.Len(t, ledger, 1)
+
+	currency, err := s.ReverseCurrencyLedgerEntry(ctx, ledger[0].LedgerID, "granted in error")
+	require.NoError(t, err)
+	assert.Equal(t, player.Pang, currency.Pang)
+	assert.Equal(t, player.Points, currency.Points)
+
+	ledger, err = s.GetCurrencyLedger(ctx, player.PlayerID, 10)
+	require.NoError(t, err)
+	require.Len(t, ledger, 2)
+	assert.Equal(t, int64(CurrencyReasonReversal), ledger[0].Reason)
+	assert.Equal(t, int64(-5000), ledger[0].PangDelta)
+	assert.Equal(t, int64(-10), ledger[0].PointsDelta)
+}
@@ -0,0 +1,374 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pangbox/server/gen/dbmodels"
+)
+
+var (
+	ErrGuildNotFound            = errors.New("guild not found")
+	ErrGuildNameTaken           = errors.New("guild name already taken")
+	ErrAlreadyInGuild           = errors.New("player is already in a guild")
+	ErrNotInGuild               = errors.New("player is not in a guild")
+	ErrGuildPermission          = errors.New("not permitted by guild role")
+	ErrGuildApplicationNotFound = errors.New("guild application not found")
+
+	// ErrGuildMasterLeaving is returned when the guild master tries to leave
+	// a guild that still has other members. Leadership must be handed over
+	// first.
+	ErrGuildMasterLeaving = errors.New("guild master cannot leave while the guild has members")
+)
+
+// GuildRole is a guild member's role. Lower values outrank higher ones.
+// These values are persisted, so existing values must never be renumbered.
+type GuildRole int64
+
+const (
+	GuildRoleMaster    GuildRole = 1
+	GuildRoleSubMaster GuildRole = 2
+	GuildRoleMember    GuildRole = 3
+)
+
+func (s *Service) getGuildMemberWith(ctx context.Context, tx *dbmodels.Queries, playerID int64) (dbmodels.GuildMember, error) {
+	member, err := tx.GetGuildMember(ctx, playerID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return dbmodels.GuildMember{}, ErrNotInGuild
+	} else if err != nil {
+		return dbmodels.GuildMember{}, fmt.Errorf("getting guild member: %w", err)
+	}
+	return member, nil
+}
+
+func (s *Service) checkNotInGuildWith(ctx context.Context, tx *dbmodels.Queries, playerID int64) error {
+	_, err := s.getGuildMemberWith(ctx, tx, playerID)
+	if err == nil {
+		return ErrAlreadyInGuild
+	} else if !errors.Is(err, ErrNotInGuild) {
+		return err
+	}
+	return nil
+}
+
+func (s *Service) addGuildMemberWith(ctx context.Context, tx *dbmodels.Queries, guildID, playerID int64, role GuildRole, now time.Time) error {
+	if err := tx.AddGuildMember(ctx, dbmodels.AddGuildMemberParams{
+		PlayerID:   playerID,
+		GuildID:    guildID,
+		MemberRole: int64(role),
+		JoinedAt:   now.Unix(),
+	}); err != nil {
+		return fmt.Errorf("adding guild member: %w", err)
+	}
+	// Joining a guild withdraws any other outstanding applications.
+	if err := tx.DeletePlayerGuildApplications(ctx, playerID); err != nil {
+		return fmt.Errorf("deleting guild applications: %w", err)
+	}
+	return nil
+}
+
+// CreateGuild creates a new guild led by the given player.
+func (s *Service) CreateGuild(ctx context.Context, leaderID int64, name, description string, now time.Time) (dbmodels.Guild, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return dbmodels.Guild{}, err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	if err := s.checkNotInGuildWith(ctx, queries, leaderID); err != nil {
+		return dbmodels.Guild{}, err
+	}
+
+	_, err = queries.GetGuildByName(ctx, name)
+	if err == nil {
+		return dbmodels.Guild{}, ErrGuildNameTaken
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return dbmodels.Guild{}, fmt.Errorf("checking guild name: %w", err)
+	}
+
+	guild, err := queries.CreateGuild(ctx, dbmodels.CreateGuildParams{
+		Name:           name,
+		Description:    description,
+		LeaderPlayerID: leaderID,
+		CreatedAt:      now.Unix(),
+	})
+	if err != nil {
+		return dbmodels.Guild{}, fmt.Errorf("creating guild: %w", err)
+	}
+
+	if err := s.addGuildMemberWith(ctx, queries, guild.GuildID, leaderID, GuildRoleMaster, now); err != nil {
+		return dbmodels.Guild{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return dbmodels.Guild{}, err
+	}
+
+	return guild, nil
+}
+
+// ApplyToGuild asks to join a guild. Applying again replaces the previous
+// application's message.
+func (s *Service) ApplyToGuild(ctx context.Context, playerID, guildID int64, message string, now time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	if err := s.checkNotInGuildWith(ctx, queries, playerID); err != nil {
+		return err
+	}
+
+	if _, err := queries.GetGuild(ctx, guildID); errors.Is(err, sql.ErrNoRows) {
+		return ErrGuildNotFound
+	} else if err != nil {
+		return fmt.Errorf("getting guild: %w", err)
+	}
+
+	if err := queries.AddGuildApplication(ctx, dbmodels.AddGuildApplicationParams{
+		GuildID:   guildID,
+		PlayerID:  playerID,
+		Message:   message,
+		CreatedAt: now.Unix(),
+	}); err != nil {
+		return fmt.Errorf("adding guild application: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetGuildApplications returns the outstanding applications to a guild,
+// oldest first.
+func (s *Service) GetGuildApplications(ctx context.Context, guildID int64) ([]dbmodels.GuildApplication, error) {
+	return s.queries.GetGuildApplications(ctx, guildID)
+}
+
+// AcceptGuildApplication accepts a player's application to the actor's
+// guild. Only the guild master and sub-masters may accept applications.
+func (s *Service) AcceptGuildApplication(ctx context.Context, actorID, playerID int64, now time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	actor, err := s.getGuildMemberWith(ctx, queries, actorID)
+	if err != nil {
+		return err
+	}
+	if GuildRole(actor.MemberRole) > GuildRoleSubMaster {
+		return ErrGuildPermission
+	}
+
+	n, err := queries.DeleteGuildApplication(ctx, dbmodels.DeleteGuildApplicationParams{
+		GuildID:  actor.GuildID,
+		PlayerID: playerID,
+	})
+	if err != nil {
+		return fmt.Errorf("deleting guild application: %w", err)
+	}
+	if n == 0 {
+		return ErrGuildApplicationNotFound
+	}
+
+	if err := s.checkNotInGuildWith(ctx, queries, playerID); err != nil {
+		return err
+	}
+
+	if err := s.addGuildMemberWith(ctx, queries, actor.GuildID, playerID, GuildRoleMember, now); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// LeaveGuild removes the player from their guild. If the guild master is the
+// last member, the guild is disbanded.
+func (s *Service) LeaveGuild(ctx context.Context, playerID int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	member, err := s.getGuildMemberWith(ctx, queries, playerID)
+	if err != nil {
+		return err
+	}
+
+	if GuildRole(member.MemberRole) == GuildRoleMaster {
+		count, err := queries.GetGuildMemberCount(ctx, member.GuildID)
+		if err != nil {
+			return fmt.Errorf("counting guild members: %w", err)
+		}
+		if count > 1 {
+			return ErrGuildMasterLeaving
+		}
+		if err := queries.DeleteGuild(ctx, member.GuildID); err != nil {
+			return fmt.Errorf("deleting guild: %w", err)
+		}
+	}
+
+	if err := queries.RemoveGuildMember(ctx, playerID); err != nil {
+		return fmt.Errorf("removing guild member: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// getGuildMembersWith returns the guild memberships of an actor and a target
+// player, checking that they are in the same guild and that the actor
+// outranks the target.
+func (s *Service) getGuildMembersWith(ctx context.Context, tx *dbmodels.Queries, actorID, playerID int64) (dbmodels.GuildMember, dbmodels.GuildMember, error) {
+	actor, err := s.getGuildMemberWith(ctx, tx, actorID)
+	if err != nil {
+		return dbmodels.GuildMember{}, dbmodels.GuildMember{}, err
+	}
+	target, err := s.getGuildMemberWith(ctx, tx, playerID)
+	if err != nil {
+		return dbmodels.GuildMember{}, dbmodels.GuildMember{}, err
+	}
+	if target.GuildID != actor.GuildID || actor.MemberRole >= target.MemberRole {
+		return dbmodels.GuildMember{}, dbmodels.GuildMember{}, ErrGuildPermission
+	}
+	return actor, target, nil
+}
+
+// KickGuildMember removes a player from the actor's guild. The actor must
+// outrank the player being removed.
+func (s *Service) KickGuildMember(ctx context.Context, actorID, playerID int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	if _, _, err := s.getGuildMembersWith(ctx, queries, actorID, playerID); err != nil {
+		return err
+	}
+
+	if err := queries.RemoveGuildMember(ctx, playerID); err != nil {
+		return fmt.Errorf("removing guild member: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// SetGuildMemberRole changes the role of a member of the actor's guild. Only
+// the guild master may change roles. Making another member guild master hands
+// over leadership, and the previous master becomes a sub-master.
+func (s *Service) SetGuildMemberRole(ctx context.Context, actorID, playerID int64, role GuildRole) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	actor, _, err := s.getGuildMembersWith(ctx, queries, actorID, playerID)
+	if err != nil {
+		return err
+	}
+	if GuildRole(actor.MemberRole) != GuildRoleMaster {
+		return ErrGuildPermission
+	}
+
+	switch role {
+	case GuildRoleMaster:
+		if err := queries.SetGuildMemberRole(ctx, dbmodels.SetGuildMemberRoleParams{
+			MemberRole: int64(GuildRoleSubMaster),
+			PlayerID:   actorID,
+		}); err != nil {
+			return fmt.Errorf("setting guild member role: %w", err)
+		}
+		if err := queries.SetGuildLeader(ctx, dbmodels.SetGuildLeaderParams{
+			LeaderPlayerID: playerID,
+			GuildID:        actor.GuildID,
+		}); err != nil {
+			return fmt.Errorf("setting guild leader: %w", err)
+		}
+	case GuildRoleSubMaster, GuildRoleMember:
+	default:
+		return fmt.Errorf("invalid guild role %d", role)
+	}
+
+	if err := queries.SetGuildMemberRole(ctx, dbmodels.SetGuildMemberRoleParams{
+		MemberRole: int64(role),
+		PlayerID:   playerID,
+	}); err != nil {
+		return fmt.Errorf("setting guild member role: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetGuildList returns a page of the guild list, along with the total number
+// of pages. Pages are numbered from 1.
+func (s *Service) GetGuildList(ctx context.Context, page, pageSize int64) ([]dbmodels.GetGuildListPageRow, int64, error) {
+	count, err := s.queries.GetGuildCount(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("counting guilds: %w", err)
+	}
+	numPages := (count + pageSize - 1) / pageSize
+	if numPages == 0 {
+		numPages = 1
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	guilds, err := s.queries.GetGuildListPage(ctx, dbmodels.GetGuildListPageParams{
+		Limit:  pageSize,
+		Offset: (page - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("getting guild list: %w", err)
+	}
+
+	return guilds, numPages, nil
+}
+
+// GetPlayerGuild returns the guild the player is a member of, or
+// ErrNotInGuild.
+func (s *Service) GetPlayerGuild(ctx context.Context, playerID int64) (dbmodels.GetPlayerGuildRow, error) {
+	guild, err := s.queries.GetPlayerGuild(ctx, playerID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return dbmodels.GetPlayerGuildRow{}, ErrNotInGuild
+	} else if err != nil {
+		return dbmodels.GetPlayerGuildRow{}, fmt.Errorf("getting player guild: %w", err)
+	}
+	return guild, nil
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuildMembership(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	now := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	master := newTestPlayer(t, s)
+	sub, err := s.Register(ctx, "sub", "sub")
+	require.NoError(t, err)
+	member, err := s.Register(ctx, "member", "member")
+	require.NoError(t, err)
+
+	guild, err := s.CreateGuild(ctx, master.PlayerID, "Pangbox", "", now)
+	require.NoError(t, err)
+
+	_, err = s.CreateGuild(ctx, sub.PlayerID, "Pangbox", "", now)
+	assert.ErrorIs(t, err, ErrGuildNameTaken)
+	_, err = s.CreateGuild(ctx, master.PlayerID, "Other", "", now)
+	assert.ErrorIs(t, err, ErrAlreadyInGuild)
+
+	require.NoError(t, s.ApplyToGuild(ctx, sub.PlayerID, guild.GuildID, "hi", now))
+	require.NoError(t, s.ApplyToGuild(ctx, member.PlayerID, guild.GuildID, "hello", now))
+	applications, err := s.GetGuildApplications(ctx, guild.GuildID)
+	require.NoError(t, err)
+	assert.Len(t, applications, 2)
+
+	// Only the master and sub-masters can accept applications.
+	require.NoError(t, s.AcceptGuildApplication(ctx, master.PlayerID, sub.PlayerID, now))
+	assert.ErrorIs(t, s.AcceptGuildApplication(ctx, sub.PlayerID, member.PlayerID, now), ErrGuildPermission)
+	require.NoError(t, s.SetGuildMemberRole(ctx, master.PlayerID, sub.PlayerID, GuildRoleSubMaster))
+	require.NoError(t, s.AcceptGuildApplication(ctx, sub.PlayerID, member.PlayerID, now))
+	assert.ErrorIs(t, s.AcceptGuildApplication(ctx, sub.PlayerID, member.PlayerID, now), ErrGuildApplicationNotFound)
+
+	playerGuild, err := s.GetPlayerGuild(ctx, member.PlayerID)
+	require.NoError(t, err)
+	assert.Equal(t, "Pangbox", playerGuild.Name)
+	assert.Equal(t, int64(GuildRoleMember), playerGuild.MemberRole)
+
+	guilds, numPages, err := s.GetGuildList(ctx, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), numPages)
+	require.Len(t, guilds, 1)
+	assert.Equal(t, int64(3), guilds[0].NumMembers)
+
+	// Sub-masters cannot kick each other, or the master.
+	assert.ErrorIs(t, s.KickGuildMember(ctx, sub.PlayerID, master.PlayerID), ErrGuildPermission)
+	require.NoError(t, s.KickGuildMember(ctx, sub.PlayerID, member.PlayerID))
+	_, err = s.GetPlayerGuild(ctx, member.PlayerID)
+	assert.ErrorIs(t, err, ErrNotInGuild)
+
+	// The master must hand over leadership before leaving.
+	assert.ErrorIs(t, s.LeaveGuild(ctx, master.PlayerID), ErrGuildMasterLeaving)
+	require.NoError(t, s.SetGuildMemberRole(ctx, master.PlayerID, sub.PlayerID, GuildRoleMaster))
+	require.NoError(t, s.LeaveGuild(ctx, master.PlayerID))
+
+	playerGuild, err = s.GetPlayerGuild(ctx, sub.PlayerID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(GuildRoleMaster), playerGuild.MemberRole)
+
+	// The last member leaving disbands the guild.
+	require.NoError(t, s.LeaveGuild(ctx, sub.PlayerID))
+	guilds, _, err = s.GetGuildList(ctx, 1, 10)
+	require.NoError(t, err)
+	assert.Empty(t, guilds)
+}
@@ -0,0 +1,254 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pangbox/server/gen/dbmodels"
+	"github.com/pangbox/server/pangya"
+)
+
+// Item locations. Items stored outside the inventory keep their row, so
+// upgrades and time limits are kept when they are moved.
+const (
+	itemLocationInventory int64 = 0
+	itemLocationLocker    int64 = 1
+)
+
+// addTimeLimitedItemWith adds a time-limited item to the player's inventory.
+// If the player already has the same item on a time limit, its expiry is
+// extended instead of adding a second copy.
+func (s *Service) addTimeLimitedItemWith(ctx context.Context, tx *dbmodels.Queries, playerID, itemTypeID int64, now time.Time, duration time.Duration) error {
+	items, err := tx.GetItemsByTypeID(ctx, dbmodels.GetItemsByTypeIDParams{
+		PlayerID:   playerID,
+		ItemTypeID: itemTypeID,
+	})
+	if err != nil {
+		return fmt.Errorf("getting existing items: %w", err)
+	}
+
+	for _, item := range items {
+		if !item.ExpiresAt.Valid {
+			continue
+		}
+		base := now
+		if expiresAt := time.Unix(item.ExpiresAt.Int64, 0); expiresAt.After(base) {
+			base = expiresAt
+		}
+		_, err := tx.SetItemExpiry(ctx, dbmodels.SetItemExpiryParams{
+			ExpiresAt: sql.NullInt64{Valid: true, Int64: base.Add(duration).Unix()},
+			PlayerID:  playerID,
+			ItemID:    item.ItemID,
+		})
+		if err != nil {
+			return fmt.Errorf("extending item expiry: %w", err)
+		}
+		return nil
+	}
+
+	_, err = tx.AddItemToInventory(ctx, dbmodels.AddItemToInventoryParams{
+		PlayerID:    playerID,
+		ItemTypeID:  itemTypeID,
+		PurchasedAt: sql.NullInt64{Valid: true, Int64: now.Unix()},
+		ExpiresAt:   sql.NullInt64{Valid: true, Int64: now.Add(duration).Unix()},
+	})
+	if err != nil {
+		return fmt.Errorf("adding item to inventory: %w", err)
+	}
+	return nil
+}
+
+// grantItemWith gives an item to a player. A non-zero quantity adds to a
+// consumable stack; otherwise a single permanent item is added.
+func (s *Service) grantItemWith(ctx context.Context, tx *dbmodels.Queries, playerID, itemTypeID, quantity int64) error {
+	if quantity != 0 {
+		return s.incrementConsumableQuantityWith(ctx, tx, playerID, itemTypeID, quantity)
+	}
+	_, err := tx.AddItemToInventory(ctx, dbmodels.AddItemToInventoryParams{
+		PlayerID:    playerID,
+		ItemTypeID:  itemTypeID,
+		PurchasedAt: sql.NullInt64{Valid: true, Int64: time.Now().Unix()},
+	})
+	return err
+}
+
+// getOwnedItemWith returns an item from the player's inventory if it belongs
+// to the given group and has not expired as of now.
+func (s *Service) getOwnedItemWith(ctx context.Context, tx *dbmodels.Queries, playerID, itemID int64, group pangya.ItemGroup, now time.Time) (dbmodels.Inventory, bool, error) {
+	item, err := tx.GetItem(ctx, dbmodels.GetItemParams{
+		PlayerID: playerID,
+		ItemID:   itemID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return dbmodels.Inventory{}, false, nil
+	} else if err != nil {
+		return dbmodels.Inventory{}, false, fmt.Errorf("getting item: %w", err)
+	}
+	if pangya.ItemGroupOf(uint32(item.ItemTypeID)) != group {
+		return dbmodels.Inventory{}, false, nil
+	}
+	if item.ExpiresAt.Valid && item.ExpiresAt.Int64 <= now.Unix() {
+		return dbmodels.Inventory{}, false, nil
+	}
+	return item, true, nil
+}
+
+// unequipItemWith unequips an item from the player and their characters,
+// takes it out of their room if it was placed there, and returns any cards
+// equipped to it to the inventory. Character parts go back to the defaults
+// of the character.
+func (s *Service) unequipItemWith(ctx context.Context, tx *dbmodels.Queries, playerID, itemID int64, defaults PartDefaults) error {
+	if err := tx.UnequipPlayerItem(ctx, dbmodels.UnequipPlayerItemParams{
+		ItemID:   itemID,
+		PlayerID: playerID,
+	}); err != nil {
+		return fmt.Errorf("unequipping item from player: %w", err)
+	}
+	characters, err := tx.GetCharactersByPlayer(ctx, playerID)
+	if err != nil {
+		return fmt.Errorf("getting characters: %w", err)
+	}
+	for _, character := range characters {
+		parts := defaults(uint32(character.CharacterTypeID))
+		if err := tx.UnequipCharacterItem(ctx, dbmodels.UnequipCharacterItemParams{
+			ItemID:              sql.NullInt64{Valid: true, Int64: itemID},
+			Part00DefaultTypeID: int64(parts[0]),
+			Part01DefaultTypeID: int64(parts[1]),
+			Part02DefaultTypeID: int64(parts[2]),
+			Part03DefaultTypeID: int64(parts[3]),
+			Part04DefaultTypeID: int64(parts[4]),
+			Part05DefaultTypeID: int64(parts[5]),
+			Part06DefaultTypeID: int64(parts[6]),
+			Part07DefaultTypeID: int64(parts[7]),
+			Part08DefaultTypeID: int64(parts[8]),
+			Part09DefaultTypeID: int64(parts[9]),
+			Part10DefaultTypeID: int64(parts[10]),
+			Part11DefaultTypeID: int64(parts[11]),
+			Part12DefaultTypeID: int64(parts[12]),
+			Part13DefaultTypeID: int64(parts[13]),
+			Part14DefaultTypeID: int64(parts[14]),
+			Part15DefaultTypeID: int64(parts[15]),
+			Part16DefaultTypeID: int64(parts[16]),
+			Part17DefaultTypeID: int64(parts[17]),
+			Part18DefaultTypeID: int64(parts[18]),
+			Part19DefaultTypeID: int64(parts[19]),
+			Part20DefaultTypeID: int64(parts[20]),
+			Part21DefaultTypeID: int64(parts[21]),
+			Part22DefaultTypeID: int64(parts[22]),
+			Part23DefaultTypeID: int64(parts[23]),
+			CharacterID:         character.CharacterID,
+			PlayerID:            playerID,
+		}); err != nil {
+			return fmt.Errorf("unequipping item from character %d: %w", character.CharacterID, err)
+		}
+	}
+	if err := tx.RemoveMyRoomFurnitureItem(ctx, dbmodels.RemoveMyRoomFurnitureItemParams{
+		PlayerID: playerID,
+		ItemID:   itemID,
+	}); err != nil {
+		return fmt.Errorf("removing item from my room: %w", err)
+	}
+	if err := s.returnItemCardsWith(ctx, tx, playerID, itemID); err != nil {
+		return err
+	}
+	return nil
+}
+
+// returnItemWith moves an item stored outside of the inventory back into
+// its owner's inventory. Consumables without a time limit are added to the
+// existing stack, if any, so the player doesn't end up with two stacks.
+func (s *Service) returnItemWith(ctx context.Context, tx *dbmodels.Queries, item dbmodels.Inventory) error {
+	merge := false
+	if item.Quantity.Int64 != 0 && !item.ExpiresAt.Valid {
+		stacks, err := tx.GetItemsByTypeID(ctx, dbmodels.GetItemsByTypeIDParams{
+			PlayerID:   item.PlayerID,
+			ItemTypeID: item.ItemTypeID,
+		})
+		if err != nil {
+			return fmt.Errorf("getting existing items: %w", err)
+		}
+		merge = len(stacks) != 0
+	}
+	if merge {
+		if err := s.incrementConsumableQuantityWith(ctx, tx, item.PlayerID, item.ItemTypeID, item.Quantity.Int64); err != nil {
+			return fmt.Errorf("adding to item stack: %w", err)
+		}
+		if err := tx.RemoveItemFromInventory(ctx, dbmodels.RemoveItemFromInventoryParams{
+			PlayerID: item.PlayerID,
+			ItemID:   item.ItemID,
+		}); err != nil {
+			return fmt.Errorf("removing merged item: %w", err)
+		}
+		return nil
+	}
+	if _, err := tx.MoveItem(ctx, dbmodels.MoveItemParams{
+		NewPlayerID: item.PlayerID,
+		Location:    itemLocationInventory,
+		PlayerID:    item.PlayerID,
+		ItemID:      item.ItemID,
+	}); err != nil {
+		return fmt.Errorf("moving item to inventory: %w", err)
+	}
+	return nil
+}
+
+// RemoveExpiredItems unequips and deletes up to limit items whose time limit
+// has passed as of now. The removed items are returned. Items stored in the
+// locker are left alone until they are taken out. Expired parts are replaced
+// with the character's defaults, which defaults returns.
+func (s *Service) RemoveExpiredItems(ctx context.Context, now time.Time, limit int64, defaults PartDefaults) ([]dbmodels.Inventory, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	items, err := queries.GetExpiredItems(ctx, dbmodels.GetExpiredItemsParams{
+		ExpiresAt: sql.NullInt64{Valid: true, Int64: now.Unix()},
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting expired items: %w", err)
+	}
+
+	for _, item := range items {
+		if err := s.unequipItemWith(ctx, queries, item.PlayerID, item.ItemID, defaults); err != nil {
+			return nil, fmt.Errorf("unequipping item %d: %w", item.ItemID, err)
+		}
+		if err := queries.RemoveItemFromInventory(ctx, dbmodels.RemoveItemFromInventoryParams{
+			PlayerID: item.PlayerID,
+			ItemID:   item.ItemID,
+		}); err != nil {
+			return nil, fmt.Errorf("removing item %d: %w", item.ItemID, err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
@@ -0,0 +1,183 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pangbox/server/pangya"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeLimitedItemExpiry(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+
+	purchase := ItemPurchase{ItemTypeID: 0x10000000, Duration: 24 * time.Hour}
+	_, err := s.PurchaseItem(ctx, player.PlayerID, purchase)
+	require.NoError(t, err)
+
+	// Buying the same rental again extends it rather than adding a copy.
+	_, err = s.PurchaseItem(ctx, player.PlayerID, purchase)
+	require.NoError(t, err)
+
+	inventory, err := s.GetPlayerInventory(ctx, player.PlayerID)
+	require.NoError(t, err)
+	require.Len(t, inventory, 1)
+	item := inventory[0]
+	require.True(t, item.ExpiresAt.Valid)
+	assert.InDelta(t, time.Now().Add(48*time.Hour).Unix(), item.ExpiresAt.Int64, 5)
+
+	require.NoError(t, s.SetClubSet(ctx, player.PlayerID, item.ItemID))
+
+	removed, err := s.RemoveExpiredItems(ctx, time.Now().Add(24*time.Hour), 100, noPartDefaults)
+	require.NoError(t, err)
+	assert.Empty(t, removed)
+
+	removed, err = s.RemoveExpiredItems(ctx, time.Now().Add(49*time.Hour), 100, noPartDefaults)
+	require.NoError(t, err)
+	require.Len(t, removed, 1)
+	assert.Equal(t, item.ItemID, removed[0].ItemID)
+
+	inventory, err = s.GetPlayerInventory(ctx, player.PlayerID)
+	require.NoError(t, err)
+	assert.Empty(t, inventory)
+
+	equipped, err := s.queries.GetPlayerByUsername(ctx, player.Username)
+	require.NoError(t, err)
+	assert.False(t, equipped.ClubID.Valid)
+}
+
+func TestExpiredItemKeptInLocker(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+
+	_, err := s.PurchaseItem(ctx, player.PlayerID, ItemPurchase{ItemTypeID: 0x10000000, Duration: 24 * time.Hour})
+	require.NoError(t, err)
+	inventory, err := s.GetPlayerInventory(ctx, player.PlayerID)
+	require.NoError(t, err)
+	require.Len(t, inventory, 1)
+	item := inventory[0]
+	_, err = s.PutLockerItem(ctx, player.PlayerID, item.ItemID, noPartDefaults)
+	require.NoError(t, err)
+
+	// Stored rentals aren't swept.
+	removed, err := s.RemoveExpiredItems(ctx, time.Now().Add(25*time.Hour), 100, noPartDefaults)
+	require.NoError(t, err)
+	assert.Empty(t, removed)
+	lockerItems, err := s.GetLockerItems(ctx, player.PlayerID)
+	require.NoError(t, err)
+	require.Len(t, lockerItems, 1)
+
+	// Once taken out, the rental expires as usual.
+	require.NoError(t, s.TakeLockerItem(ctx, player.PlayerID, item.ItemID))
+	removed, err = s.RemoveExpiredItems(ctx, time.Now().Add(25*time.Hour), 100, noPartDefaults)
+	require.NoError(t, err)
+	require.Len(t, removed, 1)
+	assert.Equal(t, item.ItemID, removed[0].ItemID)
+}
+
+func TestExpiredCutInUnequipped(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+
+	character, err := s.AddCharacter(ctx, player.PlayerID, NewCharacterParams{CharTypeID: 0x04000001})
+	require.NoError(t, err)
+	_, err = s.PurchaseItem(ctx, player.PlayerID, ItemPurchase{ItemTypeID: 0x38800001, Duration: 24 * time.Hour})
+	require.NoError(t, err)
+	items, err := s.GetPlayerInventory(ctx, player.PlayerID)
+	require.NoError(t, err)
+	var cutIn uint32
+	for _, item := range items {
+		if item.ItemTypeID == 0x38800001 {
+			cutIn = uint32(item.ItemID)
+		}
+	}
+	require.NotZero(t, cutIn)
+
+	require.NoError(t, s.SetCharacterParts(ctx, player.PlayerID, pangya.PlayerCharacterData{
+		ID:      uint32(character.CharacterID),
+		CutInID: cutIn,
+	}, [24]uint32{}, func(uint32) (PartInfo, bool) { return PartInfo{}, false }, time.Now()))
+
+	removed, err := s.RemoveExpiredItems(ctx, time.Now().Add(25*time.Hour), 100, noPartDefaults)
+	require.NoError(t, err)
+	require.Len(t, removed, 1)
+
+	characters, err := s.GetCharacters(ctx, player.PlayerID)
+	require.NoError(t, err)
+	require.Len(t, characters, 1)
+	assert.Zero(t, characters[0].CutInID)
+}
+
+func TestExpiredPartReplacedWithDefault(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+
+	const (
+		charTypeID  = 0x04000001
+		defaultHead = 0x08040000
+		rentalHead  = 0x08040001
+	)
+	defaults := func(typeID uint32) [24]uint32 {
+		if typeID != charTypeID {
+			return [24]uint32{}
+		}
+		return [24]uint32{defaultHead}
+	}
+	character, err := s.AddCharacter(ctx, player.PlayerID, NewCharacterParams{
+		CharTypeID:         charTypeID,
+		DefaultPartTypeIDs: defaults(charTypeID),
+	})
+	require.NoError(t, err)
+	_, err = s.PurchaseItem(ctx, player.PlayerID, ItemPurchase{ItemTypeID: rentalHead, Duration: 24 * time.Hour})
+	require.NoError(t, err)
+	items, err := s.GetPlayerInventory(ctx, player.PlayerID)
+	require.NoError(t, err)
+	var head uint32
+	for _, item := range items {
+		if item.ItemTypeID == rentalHead {
+			head = uint32(item.ItemID)
+		}
+	}
+	require.NotZero(t, head)
+
+	data := pangya.PlayerCharacterData{ID: uint32(character.CharacterID)}
+	data.PartTypeIDs[0] = rentalHead
+	data.PartIDs[0] = head
+	require.NoError(t, s.SetCharacterParts(ctx, player.PlayerID, data, defaults(charTypeID), func(uint32) (PartInfo, bool) {
+		return PartInfo{Character: 1, Slot: 0}, true
+	}, time.Now()))
+
+	removed, err := s.RemoveExpiredItems(ctx, time.Now().Add(25*time.Hour), 100, defaults)
+	require.NoError(t, err)
+	require.Len(t, removed, 1)
+
+	characters, err := s.GetCharacters(ctx, player.PlayerID)
+	require.NoError(t, err)
+	require.Len(t, characters, 1)
+	assert.Equal(t, uint32(defaultHead), characters[0].PartTypeIDs[0])
+	assert.Zero(t, characters[0].PartIDs[0])
+}
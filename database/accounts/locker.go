@@ -0,0 +1,303 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pangbox/server/gen/dbmodels"
+)
+
+var (
+	ErrLockerPINNotSet       = errors.New("locker combination not set")
+	ErrLockerPINIncorrect    = errors.New("incorrect locker combination")
+	ErrLockerLocked          = errors.New("locker locked after too many attempts")
+	ErrLockerItemNotFound    = errors.New("locker item not found")
+	ErrLockerItemNotStorable = errors.New("item can't be stored in the locker")
+	ErrLockerInvalidAmount   = errors.New("invalid locker pang amount")
+)
+
+const (
+	// lockerMaxAttempts is the number of wrong combinations that can be
+	// entered in a row before the locker is locked.
+	lockerMaxAttempts = 5
+
+	// lockerLockout is how long the locker stays locked.
+	lockerLockout = 10 * time.Minute
+)
+
+// HasLockerPIN returns true if the player has set a locker combination.
+func (s *Service) HasLockerPIN(ctx context.Context, playerID int64) (bool, error) {
+	locker, err := s.queries.GetLocker(ctx, playerID)
+	if err != nil {
+		return false, fmt.Errorf("getting locker: %w", err)
+	}
+	return locker.LockerPinHash.Valid, nil
+}
+
+// SetLockerPIN sets the player's locker combination. If one is already set,
+// oldPIN must match it.
+func (s *Service) SetLockerPIN(ctx context.Context, playerID int64, oldPIN, newPIN string, now time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	locker, err := queries.GetLocker(ctx, playerID)
+	if err != nil {
+		return fmt.Errorf("getting locker: %w", err)
+	}
+	if locker.LockerPinHash.Valid {
+		if err := s.checkLockerPINWith(ctx, queries, playerID, locker, oldPIN, now); err != nil {
+			if errors.Is(err, ErrLockerPINIncorrect) {
+				if err := tx.Commit(); err != nil {
+					return err
+				}
+			}
+			return err
+		}
+	}
+
+	hash, err := s.hasher.Hash(newPIN)
+	if err != nil {
+		return fmt.Errorf("hashing locker combination: %w", err)
+	}
+	if err := queries.SetLockerPINHash(ctx, dbmodels.SetLockerPINHashParams{
+		PlayerID:      playerID,
+		LockerPinHash: sql.NullString{Valid: true, String: hash},
+	}); err != nil {
+		return fmt.Errorf("setting locker combination: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// OpenLocker checks pin against the player's locker combination. After
+// lockerMaxAttempts wrong combinations in a row, the locker is locked for a
+// while and ErrLockerLocked is returned until the lockout has passed.
+func (s *Service) OpenLocker(ctx context.Context, playerID int64, pin string, now time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	locker, err := queries.GetLocker(ctx, playerID)
+	if err != nil {
+		return fmt.Errorf("getting locker: %w", err)
+	}
+	if !locker.LockerPinHash.Valid {
+		return ErrLockerPINNotSet
+	}
+	checkErr := s.checkLockerPINWith(ctx, queries, playerID, locker, pin, now)
+	if checkErr != nil && !errors.Is(checkErr, ErrLockerPINIncorrect) {
+		return checkErr
+	}
+
+	// Failed attempts are committed too, so they count towards the lockout.
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return checkErr
+}
+
+// checkLockerPINWith checks pin against the locker combination, keeping
+// track of failed attempts.
+func (s *Service) checkLockerPINWith(ctx context.Context, tx *dbmodels.Queries, playerID int64, locker dbmodels.GetLockerRow, pin string, now time.Time) error {
+	if locker.LockerLockedUntil.Valid && now.Unix() < locker.LockerLockedUntil.Int64 {
+		return ErrLockerLocked
+	}
+
+	params := dbmodels.SetLockerFailedAttemptsParams{PlayerID: playerID}
+	checkErr := error(nil)
+	if !s.hasher.CheckHash(pin, locker.LockerPinHash.String) {
+		checkErr = ErrLockerPINIncorrect
+		params.LockerFailedAttempts = locker.LockerFailedAttempts + 1
+		if params.LockerFailedAttempts >= lockerMaxAttempts {
+			params.LockerFailedAttempts = 0
+			params.LockerLockedUntil = sql.NullInt64{Valid: true, Int64: now.Add(lockerLockout).Unix()}
+		}
+	}
+	if err := tx.SetLockerFailedAttempts(ctx, params); err != nil {
+		return fmt.Errorf("setting locker attempts: %w", err)
+	}
+	return checkErr
+}
+
+// GetLockerItems returns the items stored in the player's locker.
+func (s *Service) GetLockerItems(ctx context.Context, playerID int64) ([]dbmodels.Inventory, error) {
+	items, err := s.queries.GetItemsInLocation(ctx, dbmodels.GetItemsInLocationParams{
+		PlayerID: playerID,
+		Location: itemLocationLocker,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting locker items: %w", err)
+	}
+	return items, nil
+}
+
+// GetLockerPang returns the amount of Pang stored in the player's locker.
+func (s *Service) GetLockerPang(ctx context.Context, playerID int64) (int64, error) {
+	locker, err := s.queries.GetLocker(ctx, playerID)
+	if err != nil {
+		return 0, fmt.Errorf("getting locker: %w", err)
+	}
+	return locker.LockerPang, nil
+}
+
+// PutLockerItem moves an item from the player's inventory into their locker.
+// The item is unequipped first, and parts are replaced with the defaults of
+// the character. Characters can't be stored. The item keeps its ID and state,
+// such as upgrades and time limits, while it is stored.
+func (s *Service) PutLockerItem(ctx context.Context, playerID, itemID int64, defaults PartDefaults) (dbmodels.Inventory, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return dbmodels.Inventory{}, err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	if _, err := queries.GetItem(ctx, dbmodels.GetItemParams{
+		PlayerID: playerID,
+		ItemID:   itemID,
+	}); errors.Is(err, sql.ErrNoRows) {
+		return dbmodels.Inventory{}, ErrLockerItemNotFound
+	} else if err != nil {
+		return dbmodels.Inventory{}, fmt.Errorf("getting item: %w", err)
+	}
+
+	isCharacter, err := queries.IsCharacterItem(ctx, dbmodels.IsCharacterItemParams{
+		PlayerID: playerID,
+		ItemID:   itemID,
+	})
+	if err != nil {
+		return dbmodels.Inventory{}, fmt.Errorf("checking for character: %w", err)
+	}
+	if isCharacter != 0 {
+		return dbmodels.Inventory{}, ErrLockerItemNotStorable
+	}
+
+	if err := s.unequipItemWith(ctx, queries, playerID, itemID, defaults); err != nil {
+		return dbmodels.Inventory{}, err
+	}
+
+	lockerItem, err := queries.MoveItem(ctx, dbmodels.MoveItemParams{
+		NewPlayerID: playerID,
+		Location:    itemLocationLocker,
+		PlayerID:    playerID,
+		ItemID:      itemID,
+	})
+	if err != nil {
+		return dbmodels.Inventory{}, fmt.Errorf("moving item to locker: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return dbmodels.Inventory{}, err
+	}
+
+	return lockerItem, nil
+}
+
+// TakeLockerItem moves an item from the player's locker back into their
+// inventory. Consumables are added to the existing stack, if any.
+func (s *Service) TakeLockerItem(ctx context.Context, playerID, itemID int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	lockerItem, err := queries.GetItemInLocation(ctx, dbmodels.GetItemInLocationParams{
+		PlayerID: playerID,
+		ItemID:   itemID,
+		Location: itemLocationLocker,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrLockerItemNotFound
+	} else if err != nil {
+		return fmt.Errorf("getting locker item: %w", err)
+	}
+
+	if err := s.returnItemWith(ctx, queries, lockerItem); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// MoveLockerPang moves Pang between the player's balance and their locker. A
+// positive amount is deposited into the locker; a negative amount is
+// withdrawn. ErrInsufficientFunds is returned if either side would go
+// negative. The new balance and locker Pang are returned.
+func (s *Service) MoveLockerPang(ctx context.Context, playerID, amount int64) (pang, lockerPang int64, err error) {
+	if amount == 0 {
+		return 0, 0, ErrLockerInvalidAmount
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	locker, err := queries.GetLocker(ctx, playerID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("getting locker: %w", err)
+	}
+	lockerPang = locker.LockerPang + amount
+	if lockerPang < 0 {
+		return 0, 0, ErrInsufficientFunds
+	}
+
+	currency, err := s.changeCurrencyWith(ctx, queries, playerID, CurrencyChange{
+		Pang:   -amount,
+		Reason: CurrencyReasonLocker,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if err := queries.SetLockerPang(ctx, dbmodels.SetLockerPangParams{
+		PlayerID:   playerID,
+		LockerPang: lockerPang,
+	}); err != nil {
+		return 0, 0, fmt.Errorf("setting locker pang: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return currency.Pang, lockerPang, nil
+}
@@ -0,0 +1,156 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/pangbox/server/gen/dbmodels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockerPIN(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.ErrorIs(t, s.OpenLocker(ctx, player.PlayerID, "1234", now), ErrLockerPINNotSet)
+	require.NoError(t, s.SetLockerPIN(ctx, player.PlayerID, "", "1234", now))
+
+	set, err := s.HasLockerPIN(ctx, player.PlayerID)
+	require.NoError(t, err)
+	assert.True(t, set)
+	assert.NoError(t, s.OpenLocker(ctx, player.PlayerID, "1234", now))
+
+	// Changing the combination requires the old one.
+	assert.ErrorIs(t, s.SetLockerPIN(ctx, player.PlayerID, "0000", "5678", now), ErrLockerPINIncorrect)
+	require.NoError(t, s.SetLockerPIN(ctx, player.PlayerID, "1234", "5678", now))
+
+	// Too many wrong attempts lock the locker, even with the right combination.
+	for i := 0; i < lockerMaxAttempts; i++ {
+		assert.ErrorIs(t, s.OpenLocker(ctx, player.PlayerID, "0000", now), ErrLockerPINIncorrect)
+	}
+	assert.ErrorIs(t, s.OpenLocker(ctx, player.PlayerID, "5678", now), ErrLockerLocked)
+	assert.NoError(t, s.OpenLocker(ctx, player.PlayerID, "5678", now.Add(lockerLockout)))
+}
+
+func TestLockerItems(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+
+	item, err := s.queries.AddItemToInventory(ctx, dbmodels.AddItemToInventoryParams{
+		PlayerID:   player.PlayerID,
+		ItemTypeID: 0x18000000,
+		Quantity:   sql.NullInt64{Valid: true, Int64: 5},
+	})
+	require.NoError(t, err)
+
+	lockerItem, err := s.PutLockerItem(ctx, player.PlayerID, item.ItemID, noPartDefaults)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), lockerItem.Quantity.Int64)
+
+	inventory, err := s.GetPlayerInventory(ctx, player.PlayerID)
+	require.NoError(t, err)
+	for _, invItem := range inventory {
+		assert.NotEqual(t, item.ItemID, invItem.ItemID)
+	}
+	_, err = s.PutLockerItem(ctx, player.PlayerID, item.ItemID, noPartDefaults)
+	assert.ErrorIs(t, err, ErrLockerItemNotFound)
+
+	// Taking a consumable out adds to the existing stack.
+	require.NoError(t, s.grantItemWith(ctx, s.queries, player.PlayerID, 0x18000000, 2))
+	require.NoError(t, s.TakeLockerItem(ctx, player.PlayerID, lockerItem.ItemID))
+	items, err := s.queries.GetItemsByTypeID(ctx, dbmodels.GetItemsByTypeIDParams{PlayerID: player.PlayerID, ItemTypeID: 0x18000000})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, int64(7), items[0].Quantity.Int64)
+
+	lockerItems, err := s.GetLockerItems(ctx, player.PlayerID)
+	require.NoError(t, err)
+	assert.Empty(t, lockerItems)
+	assert.ErrorIs(t, s.TakeLockerItem(ctx, player.PlayerID, lockerItem.ItemID), ErrLockerItemNotFound)
+}
+
+func TestLockerKeepsItemState(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+
+	club, err := s.AddClubSet(ctx, player.PlayerID, 0x10000061)
+	require.NoError(t, err)
+	club, err = s.queries.SetClubStats(ctx, dbmodels.SetClubStatsParams{
+		ClubPower: 3,
+		ClubSpin:  1,
+		PlayerID:  player.PlayerID,
+		ItemID:    club.ItemID,
+	})
+	require.NoError(t, err)
+	rental, err := s.queries.AddItemToInventory(ctx, dbmodels.AddItemToInventoryParams{
+		PlayerID:    player.PlayerID,
+		ItemTypeID:  0x08000000,
+		PurchasedAt: sql.NullInt64{Valid: true, Int64: 1000},
+		ExpiresAt:   sql.NullInt64{Valid: true, Int64: 2000},
+	})
+	require.NoError(t, err)
+
+	for _, item := range []dbmodels.Inventory{club, rental} {
+		_, err := s.PutLockerItem(ctx, player.PlayerID, item.ItemID, noPartDefaults)
+		require.NoError(t, err)
+	}
+	lockerItems, err := s.GetLockerItems(ctx, player.PlayerID)
+	require.NoError(t, err)
+	assert.Len(t, lockerItems, 2)
+
+	for _, item := range []dbmodels.Inventory{club, rental} {
+		require.NoError(t, s.TakeLockerItem(ctx, player.PlayerID, item.ItemID))
+		taken, err := s.queries.GetItem(ctx, dbmodels.GetItemParams{PlayerID: player.PlayerID, ItemID: item.ItemID})
+		require.NoError(t, err)
+		assert.Equal(t, item, taken)
+	}
+}
+
+func TestLockerPang(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+
+	pang, lockerPang, err := s.MoveLockerPang(ctx, player.PlayerID, 5000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(15000), pang)
+	assert.Equal(t, int64(5000), lockerPang)
+
+	_, _, err = s.MoveLockerPang(ctx, player.PlayerID, -6000)
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+	_, _, err = s.MoveLockerPang(ctx, player.PlayerID, 20000)
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+
+	pang, lockerPang, err = s.MoveLockerPang(ctx, player.PlayerID, -2000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(17000), pang)
+	assert.Equal(t, int64(3000), lockerPang)
+
+	stored, err := s.GetLockerPang(ctx, player.PlayerID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3000), stored)
+}
@@ -0,0 +1,145 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pangbox/server/gen/dbmodels"
+)
+
+// ErrLoginBonusClaimed is returned when the player has already claimed
+// today's login bonus.
+var ErrLoginBonusClaimed = errors.New("login bonus already claimed today")
+
+// LoginReward is the reward for one day of a login bonus calendar.
+type LoginReward struct {
+	ItemTypeID int64
+	Quantity   int64
+	Pang       int64
+}
+
+// LoginBonusStatus describes a player's login bonus streak.
+type LoginBonusStatus struct {
+	// Streak is the number of consecutive days claimed, including today if
+	// ClaimedToday is set.
+	Streak       int64
+	ClaimedToday bool
+}
+
+// dayNumber returns the day number used for login bonus streaks and daily
+// quests. Days start at midnight UTC.
+func dayNumber(t time.Time) int64 {
+	return t.Unix() / 86400
+}
+
+func loginBonusStatusFromDB(row dbmodels.LoginBonusStreak, found bool, calendar string, now time.Time) LoginBonusStatus {
+	today := dayNumber(now)
+	if !found || row.Calendar != calendar {
+		return LoginBonusStatus{}
+	}
+	switch row.LastClaimDay {
+	case today:
+		return LoginBonusStatus{Streak: row.Streak, ClaimedToday: true}
+	case today - 1:
+		return LoginBonusStatus{Streak: row.Streak}
+	default:
+		return LoginBonusStatus{}
+	}
+}
+
+func (s *Service) getLoginBonusStatusWith(ctx context.Context, tx *dbmodels.Queries, playerID int64, calendar string, now time.Time) (LoginBonusStatus, error) {
+	row, err := tx.GetLoginBonusStreak(ctx, playerID)
+	found := true
+	if errors.Is(err, sql.ErrNoRows) {
+		found = false
+	} else if err != nil {
+		return LoginBonusStatus{}, fmt.Errorf("getting login bonus: %w", err)
+	}
+	return loginBonusStatusFromDB(row, found, calendar, now), nil
+}
+
+// GetLoginBonusStatus returns the player's streak for the given calendar.
+func (s *Service) GetLoginBonusStatus(ctx context.Context, playerID int64, calendar string, now time.Time) (LoginBonusStatus, error) {
+	return s.getLoginBonusStatusWith(ctx, s.queries, playerID, calendar, now)
+}
+
+// ClaimLoginBonus grants today's login reward from the given calendar. The
+// reward for the Nth consecutive day is rewards[(N-1) % len(rewards)]. A
+// streak is reset when a day is missed or the calendar changes.
+func (s *Service) ClaimLoginBonus(ctx context.Context, playerID int64, calendar string, rewards []LoginReward, now time.Time) (LoginBonusStatus, LoginReward, error) {
+	if len(rewards) == 0 {
+		return LoginBonusStatus{}, LoginReward{}, errors.New("login bonus calendar has no rewards")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return LoginBonusStatus{}, LoginReward{}, err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	status, err := s.getLoginBonusStatusWith(ctx, queries, playerID, calendar, now)
+	if err != nil {
+		return LoginBonusStatus{}, LoginReward{}, err
+	}
+	if status.ClaimedToday {
+		return status, LoginReward{}, ErrLoginBonusClaimed
+	}
+
+	reward := rewards[status.Streak%int64(len(rewards))]
+	status.Streak++
+	status.ClaimedToday = true
+
+	if _, err := queries.SetLoginBonusStreak(ctx, dbmodels.SetLoginBonusStreakParams{
+		PlayerID:     playerID,
+		Calendar:     calendar,
+		Streak:       status.Streak,
+		LastClaimDay: dayNumber(now),
+	}); err != nil {
+		return LoginBonusStatus{}, LoginReward{}, fmt.Errorf("setting login bonus: %w", err)
+	}
+
+	if reward.ItemTypeID != 0 {
+		if err := s.grantItemWith(ctx, queries, playerID, reward.ItemTypeID, reward.Quantity); err != nil {
+			return LoginBonusStatus{}, LoginReward{}, fmt.Errorf("granting login bonus item: %w", err)
+		}
+	}
+
+	if reward.Pang != 0 {
+		if _, err := s.changeCurrencyWith(ctx, queries, playerID, CurrencyChange{
+			Pang:   reward.Pang,
+			Reason: CurrencyReasonLoginBonus,
+			Note:   fmt.Sprintf("%s day %d", calendar, status.Streak),
+		}); err != nil {
+			return LoginBonusStatus{}, LoginReward{}, fmt.Errorf("granting login bonus pang: %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return LoginBonusStatus{}, LoginReward{}, err
+	}
+
+	return status, reward, nil
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginBonusStreak(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+
+	rewards := []LoginReward{
+		{Pang: 100},
+		{ItemTypeID: 0x18000004, Quantity: 3},
+		{Pang: 300},
+	}
+	day := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	status, reward, err := s.ClaimLoginBonus(ctx, player.PlayerID, "July", rewards, day)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), status.Streak)
+	assert.Equal(t, rewards[0], reward)
+
+	_, _, err = s.ClaimLoginBonus(ctx, player.PlayerID, "July", rewards, day.Add(time.Hour))
+	assert.ErrorIs(t, err, ErrLoginBonusClaimed)
+
+	status, reward, err = s.ClaimLoginBonus(ctx, player.PlayerID, "July", rewards, day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), status.Streak)
+	assert.Equal(t, rewards[1], reward)
+
+	// Missing a day resets the streak.
+	status, reward, err = s.ClaimLoginBonus(ctx, player.PlayerID, "July", rewards, day.AddDate(0, 0, 3))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), status.Streak)
+	assert.Equal(t, rewards[0], reward)
+
+	// So does switching to a new calendar.
+	status, err = s.GetLoginBonusStatus(ctx, player.PlayerID, "August", day.AddDate(0, 0, 4))
+	require.NoError(t, err)
+	assert.Equal(t, LoginBonusStatus{}, status)
+
+	currency, err := s.queries.GetPlayerCurrency(ctx, player.PlayerID)
+	require.NoError(t, err)
+	assert.Equal(t, player.Pang+200, currency.Pang)
+}
@@ -0,0 +1,234 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pangbox/server/gen/dbmodels"
+)
+
+// ErrMailNotFound is returned when a mail does not exist or does not belong
+// to the player.
+var ErrMailNotFound = errors.New("mail not found")
+
+// MailItem is an item attached to a mail. A non-zero quantity adds to a
+// consumable stack; otherwise a single item is added to the inventory.
+type MailItem struct {
+	ItemTypeID int64
+	Quantity   int64
+}
+
+// NewMail describes a mail to send.
+type NewMail struct {
+	// SenderPlayerID is the player sending the mail, or zero for mail sent
+	// by the system, e.g. compensation or event rewards.
+	SenderPlayerID    int64
+	SenderNickname    string
+	RecipientPlayerID int64
+	Body              string
+	Pang              int64
+	Items             []MailItem
+}
+
+// InboxMail is a mail along with its attachments.
+type InboxMail struct {
+	dbmodels.Mail
+	Attachments []dbmodels.MailAttachment
+}
+
+// SendMail delivers a mail to a player's inbox.
+func (s *Service) SendMail(ctx context.Context, mail NewMail) (dbmodels.Mail, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return dbmodels.Mail{}, err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	result, err := s.sendMailWith(ctx, queries, mail)
+	if err != nil {
+		return dbmodels.Mail{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return dbmodels.Mail{}, err
+	}
+
+	return result, nil
+}
+
+func (s *Service) sendMailWith(ctx context.Context, tx *dbmodels.Queries, mail NewMail) (dbmodels.Mail, error) {
+	if mail.Pang < 0 {
+		return dbmodels.Mail{}, fmt.Errorf("invalid pang attachment %d", mail.Pang)
+	}
+
+	senderPlayerID := sql.NullInt64{}
+	if mail.SenderPlayerID != 0 {
+		senderPlayerID = sql.NullInt64{Valid: true, Int64: mail.SenderPlayerID}
+	}
+
+	result, err := tx.CreateMail(ctx, dbmodels.CreateMailParams{
+		SenderPlayerID:    senderPlayerID,
+		SenderNickname:    mail.SenderNickname,
+		RecipientPlayerID: mail.RecipientPlayerID,
+		Body:              mail.Body,
+		Pang:              mail.Pang,
+		CreatedAt:         time.Now().Unix(),
+	})
+	if err != nil {
+		return dbmodels.Mail{}, fmt.Errorf("creating mail: %w", err)
+	}
+
+	for _, item := range mail.Items {
+		if item.Quantity < 0 {
+			return dbmodels.Mail{}, fmt.Errorf("invalid quantity %d for item %08x", item.Quantity, item.ItemTypeID)
+		}
+		_, err := tx.AddMailAttachment(ctx, dbmodels.AddMailAttachmentParams{
+			MailID:     result.MailID,
+			ItemTypeID: item.ItemTypeID,
+			Quantity:   item.Quantity,
+		})
+		if err != nil {
+			return dbmodels.Mail{}, fmt.Errorf("adding mail attachment: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// GetInbox returns a page of the player's inbox, newest first, along with the
+// total number of pages. Pages are numbered from 1.
+func (s *Service) GetInbox(ctx context.Context, playerID, page, pageSize int64) ([]InboxMail, int64, error) {
+	count, err := s.queries.CountMail(ctx, playerID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("counting mail: %w", err)
+	}
+	numPages := (count + pageSize - 1) / pageSize
+	if numPages == 0 {
+		numPages = 1
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	mails, err := s.queries.GetInboxPage(ctx, dbmodels.GetInboxPageParams{
+		RecipientPlayerID: playerID,
+		Limit:             pageSize,
+		Offset:            (page - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("getting inbox: %w", err)
+	}
+
+	result := make([]InboxMail, 0, len(mails))
+	for _, mail := range mails {
+		attachments, err := s.queries.GetMailAttachments(ctx, mail.MailID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("getting attachments for mail %d: %w", mail.MailID, err)
+		}
+		result = append(result, InboxMail{Mail: mail, Attachments: attachments})
+	}
+
+	return result, numPages, nil
+}
+
+// CountUnreadMail returns the number of unread mails in the player's inbox.
+func (s *Service) CountUnreadMail(ctx context.Context, playerID int64) (int64, error) {
+	return s.queries.CountUnreadMail(ctx, playerID)
+}
+
+// ReadMail marks a mail as read and moves any attachments that have not been
+// claimed yet into the player's inventory. The returned mail reflects its
+// state before it was read, so callers can tell if anything was claimed.
+func (s *Service) ReadMail(ctx context.Context, playerID, mailID int64) (InboxMail, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return InboxMail{}, err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	mail, err := queries.GetMail(ctx, dbmodels.GetMailParams{
+		RecipientPlayerID: playerID,
+		MailID:            mailID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return InboxMail{}, ErrMailNotFound
+	} else if err != nil {
+		return InboxMail{}, fmt.Errorf("getting mail: %w", err)
+	}
+
+	attachments, err := queries.GetMailAttachments(ctx, mail.MailID)
+	if err != nil {
+		return InboxMail{}, fmt.Errorf("getting mail attachments: %w", err)
+	}
+
+	if !mail.IsRead {
+		if err := queries.SetMailRead(ctx, dbmodels.SetMailReadParams{
+			RecipientPlayerID: playerID,
+			MailID:            mailID,
+		}); err != nil {
+			return InboxMail{}, fmt.Errorf("marking mail read: %w", err)
+		}
+	}
+
+	if !mail.Claimed {
+		if err := s.claimMailWith(ctx, queries, mail, attachments); err != nil {
+			return InboxMail{}, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return InboxMail{}, err
+	}
+
+	return InboxMail{Mail: mail, Attachments: attachments}, nil
+}
+
+func (s *Service) claimMailWith(ctx context.Context, tx *dbmodels.Queries, mail dbmodels.Mail, attachments []dbmodels.MailAttachment) error {
+	for _, attachment := range attachments {
+		if err := s.grantItemWith(ctx, tx, mail.RecipientPlayerID, attachment.ItemTypeID, attachment.Quantity); err != nil {
+			return fmt.Errorf("adding item from mail: %w", err)
+		}
+	}
+
+	if mail.Pang != 0 {
+		_, err := s.changeCurrencyWith(ctx, tx, mail.RecipientPlayerID, CurrencyChange{
+			Pang:   mail.Pang,
+			Reason: CurrencyReasonMail,
+			Note:   fmt.Sprintf("mail #%d from %s", mail.MailID, mail.SenderNickname),
+		})
+		if err != nil {
+			return fmt.Errorf("adding pang from mail: %w", err)
+		}
+	}
+
+	return tx.SetMailClaimed(ctx, dbmodels.SetMailClaimedParams{
+		RecipientPlayerID: mail.RecipientPlayerID,
+		MailID:            mail.MailID,
+	})
+}
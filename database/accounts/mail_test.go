@@ -0,0 +1,97 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMailAttachmentsClaimedOnce(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+
+	mail, err := s.SendMail(ctx, NewMail{
+		SenderNickname:    "@Pangbox",
+		RecipientPlayerID: player.PlayerID,
+		Body:              "Sorry for the downtime!",
+		Pang:              3000,
+		Items: []MailItem{
+			{ItemTypeID: 0x18000000, Quantity: 10},
+			{ItemTypeID: 0x10000000},
+		},
+	})
+	require.NoError(t, err)
+
+	unread, err := s.CountUnreadMail(ctx, player.PlayerID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), unread)
+
+	read, err := s.ReadMail(ctx, player.PlayerID, mail.MailID)
+	require.NoError(t, err)
+	assert.False(t, read.Claimed)
+	assert.Len(t, read.Attachments, 2)
+
+	// Reading again must not grant the attachments twice.
+	read, err = s.ReadMail(ctx, player.PlayerID, mail.MailID)
+	require.NoError(t, err)
+	assert.True(t, read.Claimed)
+
+	currency, err := s.queries.GetPlayerCurrency(ctx, player.PlayerID)
+	require.NoError(t, err)
+	assert.Equal(t, player.Pang+3000, currency.Pang)
+
+	inventory, err := s.GetPlayerInventory(ctx, player.PlayerID)
+	require.NoError(t, err)
+	assert.Len(t, inventory, 2)
+
+	unread, err = s.CountUnreadMail(ctx, player.PlayerID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), unread)
+}
+
+func TestInboxPaging(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+
+	for i := 0; i < 5; i++ {
+		_, err := s.SendMail(ctx, NewMail{
+			SenderNickname:    "@Pangbox",
+			RecipientPlayerID: player.PlayerID,
+			Body:              "hello",
+		})
+		require.NoError(t, err)
+	}
+
+	page, numPages, err := s.GetInbox(ctx, player.PlayerID, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), numPages)
+	assert.Len(t, page, 2)
+
+	page, _, err = s.GetInbox(ctx, player.PlayerID, 3, 2)
+	require.NoError(t, err)
+	assert.Len(t, page, 1)
+
+	_, err = s.ReadMail(ctx, player.PlayerID+1, page[0].MailID)
+	assert.ErrorIs(t, err, ErrMailNotFound)
+}
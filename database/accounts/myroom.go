@@ -0,0 +1,94 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/pangbox/server/gen/dbmodels"
+)
+
+// ErrFurnitureNotOwned is returned when a My Room layout places an item the
+// player doesn't own, or places the same item more than once.
+var ErrFurnitureNotOwned = errors.New("furniture item not owned by player")
+
+// FurniturePlacement is the position of a furniture item in My Room.
+type FurniturePlacement struct {
+	ItemID   int64
+	X, Y, Z  float64
+	Rotation float64
+}
+
+// SetMyRoomLayout replaces the player's My Room layout. Every placed item
+// must be in the player's inventory; otherwise ErrFurnitureNotOwned is
+// returned and the layout is left unchanged.
+func (s *Service) SetMyRoomLayout(ctx context.Context, playerID int64, layout []FurniturePlacement) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	if err := queries.ClearMyRoomFurniture(ctx, playerID); err != nil {
+		return fmt.Errorf("clearing my room layout: %w", err)
+	}
+
+	placed := make(map[int64]struct{}, len(layout))
+	for _, furniture := range layout {
+		if _, ok := placed[furniture.ItemID]; ok {
+			return ErrFurnitureNotOwned
+		}
+		placed[furniture.ItemID] = struct{}{}
+
+		if _, err := queries.GetItem(ctx, dbmodels.GetItemParams{
+			PlayerID: playerID,
+			ItemID:   furniture.ItemID,
+		}); errors.Is(err, sql.ErrNoRows) {
+			return ErrFurnitureNotOwned
+		} else if err != nil {
+			return fmt.Errorf("getting furniture item %d: %w", furniture.ItemID, err)
+		}
+
+		if err := queries.AddMyRoomFurniture(ctx, dbmodels.AddMyRoomFurnitureParams{
+			ItemID:   furniture.ItemID,
+			PlayerID: playerID,
+			PosX:     furniture.X,
+			PosY:     furniture.Y,
+			PosZ:     furniture.Z,
+			Rotation: furniture.Rotation,
+		}); err != nil {
+			return fmt.Errorf("placing furniture item %d: %w", furniture.ItemID, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetMyRoomLayout returns the furniture placed in the player's My Room.
+func (s *Service) GetMyRoomLayout(ctx context.Context, playerID int64) ([]dbmodels.GetMyRoomFurnitureRow, error) {
+	furniture, err := s.queries.GetMyRoomFurniture(ctx, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("getting my room layout: %w", err)
+	}
+	return furniture, nil
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pangbox/server/gen/dbmodels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMyRoomLayout(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+	other, err := s.Register(ctx, "other", "other")
+	require.NoError(t, err)
+
+	table, err := s.queries.AddItemToInventory(ctx, dbmodels.AddItemToInventoryParams{PlayerID: player.PlayerID, ItemTypeID: 0x48000001})
+	require.NoError(t, err)
+	chair, err := s.queries.AddItemToInventory(ctx, dbmodels.AddItemToInventoryParams{PlayerID: player.PlayerID, ItemTypeID: 0x48000002})
+	require.NoError(t, err)
+	otherItem, err := s.queries.AddItemToInventory(ctx, dbmodels.AddItemToInventoryParams{PlayerID: other.PlayerID, ItemTypeID: 0x48000001})
+	require.NoError(t, err)
+
+	require.NoError(t, s.SetMyRoomLayout(ctx, player.PlayerID, []FurniturePlacement{
+		{ItemID: table.ItemID, X: 1, Y: 0, Z: 2, Rotation: 90},
+		{ItemID: chair.ItemID, X: 3, Y: 0, Z: 4},
+	}))
+	layout, err := s.GetMyRoomLayout(ctx, player.PlayerID)
+	require.NoError(t, err)
+	require.Len(t, layout, 2)
+	assert.Equal(t, table.ItemTypeID, layout[0].ItemTypeID)
+	assert.Equal(t, 90.0, layout[0].Rotation)
+
+	// Items owned by someone else can't be placed, and a bad layout leaves the
+	// old one in place.
+	err = s.SetMyRoomLayout(ctx, player.PlayerID, []FurniturePlacement{{ItemID: otherItem.ItemID}})
+	assert.ErrorIs(t, err, ErrFurnitureNotOwned)
+	err = s.SetMyRoomLayout(ctx, player.PlayerID, []FurniturePlacement{{ItemID: table.ItemID}, {ItemID: table.ItemID}})
+	assert.ErrorIs(t, err, ErrFurnitureNotOwned)
+	layout, err = s.GetMyRoomLayout(ctx, player.PlayerID)
+	require.NoError(t, err)
+	assert.Len(t, layout, 2)
+
+	// Furniture that leaves the inventory is removed from the layout.
+	require.NoError(t, s.queries.RemoveItemFromInventory(ctx, dbmodels.RemoveItemFromInventoryParams{PlayerID: player.PlayerID, ItemID: chair.ItemID}))
+	layout, err = s.GetMyRoomLayout(ctx, player.PlayerID)
+	require.NoError(t, err)
+	assert.Len(t, layout, 1)
+}
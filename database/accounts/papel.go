@@ -0,0 +1,149 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/pangbox/server/gen/dbmodels"
+)
+
+// PapelItem is an item won from a Papel Shop play. A non-zero Quantity adds
+// to a consumable stack; otherwise a single permanent item is added.
+type PapelItem struct {
+	ItemTypeID int64
+	Quantity   int64
+}
+
+// PapelPlay describes a single play of a Papel Shop.
+type PapelPlay struct {
+	// TicketTypeID is the type of ticket that can be used to play instead of
+	// paying Pang. Zero means tickets are not accepted.
+	TicketTypeID int64
+	PangCost     int64
+	Items        []PapelItem
+
+	// Pool is the name of the pool the items were drawn from. If set, the
+	// player's pity counter for the pool is reset when Rare is true and
+	// advanced otherwise.
+	Pool string
+	Rare bool
+}
+
+// PapelResult is the outcome of a Papel Shop play.
+type PapelResult struct {
+	// TicketItemID is the inventory item ID of the ticket that was used, or
+	// zero if Pang was paid instead.
+	TicketItemID int64
+	Pang         int64
+	Points       int64
+}
+
+// PlayPapel pays for a Papel Shop play and adds the items won to the player's
+// inventory. A ticket is used if the player has one; otherwise PangCost is
+// charged, failing with ErrInsufficientFunds if the player can't afford it.
+func (s *Service) PlayPapel(ctx context.Context, playerID int64, play PapelPlay) (PapelResult, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return PapelResult{}, err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	result := PapelResult{}
+	if play.TicketTypeID != 0 {
+		tickets, err := queries.GetItemsByTypeID(ctx, dbmodels.GetItemsByTypeIDParams{
+			PlayerID:   playerID,
+			ItemTypeID: play.TicketTypeID,
+		})
+		if err != nil {
+			return PapelResult{}, fmt.Errorf("getting papel tickets: %w", err)
+		}
+		if len(tickets) > 0 {
+			result.TicketItemID, err = s.decrementConsumableQuantityWith(ctx, queries, playerID, play.TicketTypeID)
+			if err != nil {
+				return PapelResult{}, fmt.Errorf("using papel ticket: %w", err)
+			}
+		}
+	}
+
+	if result.TicketItemID == 0 {
+		if _, err := s.changeCurrencyWith(ctx, queries, playerID, CurrencyChange{
+			Pang:   -play.PangCost,
+			Reason: CurrencyReasonPapel,
+		}); err != nil {
+			return PapelResult{}, err
+		}
+	}
+
+	for _, item := range play.Items {
+		if err := s.grantItemWith(ctx, queries, playerID, item.ItemTypeID, item.Quantity); err != nil {
+			return PapelResult{}, fmt.Errorf("adding papel item %08x: %w", item.ItemTypeID, err)
+		}
+	}
+
+	if play.Pool != "" {
+		if play.Rare {
+			err = queries.ResetPapelPity(ctx, dbmodels.ResetPapelPityParams{
+				PlayerID: playerID,
+				PoolName: play.Pool,
+			})
+		} else {
+			err = queries.IncrementPapelPity(ctx, dbmodels.IncrementPapelPityParams{
+				PlayerID: playerID,
+				PoolName: play.Pool,
+			})
+		}
+		if err != nil {
+			return PapelResult{}, fmt.Errorf("updating papel pity: %w", err)
+		}
+	}
+
+	currency, err := queries.GetPlayerCurrency(ctx, playerID)
+	if err != nil {
+		return PapelResult{}, fmt.Errorf("getting balance: %w", err)
+	}
+	result.Pang = currency.Pang
+	result.Points = currency.Points
+
+	err = tx.Commit()
+	if err != nil {
+		return PapelResult{}, err
+	}
+
+	return result, nil
+}
+
+// GetPapelPity returns the number of plays the player has made on a Papel Shop
+// pool since last winning a rare item from it.
+func (s *Service) GetPapelPity(ctx context.Context, playerID int64, pool string) (int64, error) {
+	plays, err := s.queries.GetPapelPity(ctx, dbmodels.GetPapelPityParams{
+		PlayerID: playerID,
+		PoolName: pool,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("getting papel pity: %w", err)
+	}
+	return plays, nil
+}
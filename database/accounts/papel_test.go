@@ -0,0 +1,112 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/pangbox/server/gen/dbmodels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayPapel(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+
+	const ticketTypeID = 0x1A000083
+	play := PapelPlay{
+		TicketTypeID: ticketTypeID,
+		PangCost:     500,
+		Items: []PapelItem{
+			{ItemTypeID: 0x18000004, Quantity: 2},
+			{ItemTypeID: 0x08000001},
+		},
+	}
+
+	result, err := s.PlayPapel(ctx, player.PlayerID, play)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), result.TicketItemID)
+	assert.Equal(t, int64(19500), result.Pang)
+
+	items, err := s.queries.GetItemsByTypeID(ctx, dbmodels.GetItemsByTypeIDParams{PlayerID: player.PlayerID, ItemTypeID: 0x18000004})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, int64(2), items[0].Quantity.Int64)
+
+	// A ticket is used instead of Pang when the player has one.
+	_, err = s.queries.AddItemToInventory(ctx, dbmodels.AddItemToInventoryParams{
+		PlayerID:   player.PlayerID,
+		ItemTypeID: ticketTypeID,
+		Quantity:   sql.NullInt64{Valid: true, Int64: 1},
+	})
+	require.NoError(t, err)
+	result, err = s.PlayPapel(ctx, player.PlayerID, play)
+	require.NoError(t, err)
+	assert.NotEqual(t, int64(0), result.TicketItemID)
+	assert.Equal(t, int64(19500), result.Pang)
+
+	tickets, err := s.queries.GetItemsByTypeID(ctx, dbmodels.GetItemsByTypeIDParams{PlayerID: player.PlayerID, ItemTypeID: ticketTypeID})
+	require.NoError(t, err)
+	assert.Empty(t, tickets)
+
+	// Nothing is given if the player can't pay.
+	play.PangCost = 100000
+	_, err = s.PlayPapel(ctx, player.PlayerID, play)
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+
+	items, err = s.queries.GetItemsByTypeID(ctx, dbmodels.GetItemsByTypeIDParams{PlayerID: player.PlayerID, ItemTypeID: 0x18000004})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, int64(4), items[0].Quantity.Int64)
+}
+
+func TestPapelPity(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+
+	plays, err := s.GetPapelPity(ctx, player.PlayerID, "black")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), plays)
+
+	play := PapelPlay{PangCost: 500, Pool: "black"}
+	for i := 0; i < 3; i++ {
+		_, err = s.PlayPapel(ctx, player.PlayerID, play)
+		require.NoError(t, err)
+	}
+	plays, err = s.GetPapelPity(ctx, player.PlayerID, "black")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), plays)
+
+	// Counters are kept per pool.
+	plays, err = s.GetPapelPity(ctx, player.PlayerID, "event")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), plays)
+
+	// Winning a rare item resets the counter.
+	play.Rare = true
+	_, err = s.PlayPapel(ctx, player.PlayerID, play)
+	require.NoError(t, err)
+	plays, err = s.GetPapelPity(ctx, player.PlayerID, "black")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), plays)
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pangbox/server/gen/dbmodels"
+)
+
+// DailyQuest is a quest in the daily rotation along with its reward.
+type DailyQuest struct {
+	QuestTypeID int64
+	Goal        int64
+	ItemTypeID  int64
+	Quantity    int64
+	Pang        int64
+}
+
+// GetDailyQuestProgress returns the player's progress on the quests of the
+// day containing now. Quests without any progress are omitted.
+func (s *Service) GetDailyQuestProgress(ctx context.Context, playerID int64, now time.Time) ([]dbmodels.DailyQuest, error) {
+	return s.queries.GetDailyQuests(ctx, dbmodels.GetDailyQuestsParams{
+		PlayerID: playerID,
+		Day:      dayNumber(now),
+	})
+}
+
+// AddDailyQuestProgress adds progress towards one of the day's quests.
+// Progress is not capped at the quest's goal. The first time the goal is
+// reached, the quest's reward is granted and the quest is marked claimed.
+func (s *Service) AddDailyQuestProgress(ctx context.Context, playerID int64, quest DailyQuest, amount int64, now time.Time) (dbmodels.DailyQuest, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return dbmodels.DailyQuest{}, err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+	day := dayNumber(now)
+
+	progress, err := queries.AddDailyQuestProgress(ctx, dbmodels.AddDailyQuestProgressParams{
+		PlayerID:    playerID,
+		Day:         day,
+		QuestTypeID: quest.QuestTypeID,
+		Progress:    amount,
+	})
+	if err != nil {
+		return dbmodels.DailyQuest{}, fmt.Errorf("adding quest progress: %w", err)
+	}
+
+	if !progress.Claimed && progress.Progress >= quest.Goal {
+		if err := s.claimDailyQuestWith(ctx, queries, playerID, quest, day); err != nil {
+			return dbmodels.DailyQuest{}, err
+		}
+		progress.Claimed = true
+	}
+
+	return progress, tx.Commit()
+}
+
+// claimDailyQuestWith marks a completed quest claimed and grants its reward,
+// unless it was already claimed.
+func (s *Service) claimDailyQuestWith(ctx context.Context, queries *dbmodels.Queries, playerID int64, quest DailyQuest, day int64) error {
+	n, err := queries.ClaimDailyQuest(ctx, dbmodels.ClaimDailyQuestParams{
+		PlayerID:    playerID,
+		Day:         day,
+		QuestTypeID: quest.QuestTypeID,
+	})
+	if err != nil {
+		return fmt.Errorf("claiming quest: %w", err)
+	}
+	if n == 0 {
+		// Already claimed.
+		return nil
+	}
+
+	if quest.ItemTypeID != 0 {
+		if err := s.grantItemWith(ctx, queries, playerID, quest.ItemTypeID, quest.Quantity); err != nil {
+			return fmt.Errorf("granting quest item: %w", err)
+		}
+	}
+
+	if quest.Pang != 0 {
+		if _, err := s.changeCurrencyWith(ctx, queries, playerID, CurrencyChange{
+			Pang:   quest.Pang,
+			Reason: CurrencyReasonQuest,
+			Note:   fmt.Sprintf("daily quest %08x", quest.QuestTypeID),
+		}); err != nil {
+			return fmt.Errorf("granting quest pang: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDailyQuest(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+
+	quest := DailyQuest{QuestTypeID: 0x74000001, Goal: 9, Pang: 1000}
+	day := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	pang := func() int64 {
+		t.Helper()
+		currency, err := s.queries.GetPlayerCurrency(ctx, player.PlayerID)
+		require.NoError(t, err)
+		return currency.Pang
+	}
+	startPang := pang()
+
+	progress, err := s.AddDailyQuestProgress(ctx, player.PlayerID, quest, 6, day)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), progress.Progress)
+	assert.False(t, progress.Claimed)
+	assert.Equal(t, startPang, pang())
+
+	// Reaching the goal grants the reward.
+	progress, err = s.AddDailyQuestProgress(ctx, player.PlayerID, quest, 6, day)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), progress.Progress)
+	assert.True(t, progress.Claimed)
+	assert.Equal(t, startPang+1000, pang())
+
+	// Going past the goal doesn't grant it again.
+	progress, err = s.AddDailyQuestProgress(ctx, player.PlayerID, quest, 1, day)
+	require.NoError(t, err)
+	assert.Equal(t, int64(13), progress.Progress)
+	assert.Equal(t, startPang+1000, pang())
+
+	// Progress starts over the next day.
+	quests, err := s.GetDailyQuestProgress(ctx, player.PlayerID, day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Empty(t, quests)
+
+	quests, err = s.GetDailyQuestProgress(ctx, player.PlayerID, day)
+	require.NoError(t, err)
+	require.Len(t, quests, 1)
+	assert.True(t, quests[0].Claimed)
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/pangbox/server/gen/dbmodels"
+)
+
+// ErrRareItemSoldOut is returned when a Rare Shop item has no stock left.
+var ErrRareItemSoldOut = errors.New("rare shop item sold out")
+
+// PurchaseRareItem buys an item from the Rare Shop. If stockLimit is non-zero,
+// only that many of the item can be sold across all players; once it is
+// reached, ErrRareItemSoldOut is returned and nothing is charged.
+func (s *Service) PurchaseRareItem(ctx context.Context, playerID int64, purchase ItemPurchase, stockLimit int64) (dbmodels.SetPlayerCurrencyRow, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return dbmodels.SetPlayerCurrencyRow{}, err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	newCurrency, err := s.purchaseRareItemWith(ctx, queries, playerID, purchase, stockLimit)
+	if err != nil {
+		return dbmodels.SetPlayerCurrencyRow{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return dbmodels.SetPlayerCurrencyRow{}, err
+	}
+
+	return newCurrency, nil
+}
+
+func (s *Service) purchaseRareItemWith(ctx context.Context, queries *dbmodels.Queries, playerID int64, purchase ItemPurchase, stockLimit int64) (dbmodels.SetPlayerCurrencyRow, error) {
+	if stockLimit != 0 {
+		sold, err := queries.GetRareShopSale(ctx, purchase.ItemTypeID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return dbmodels.SetPlayerCurrencyRow{}, fmt.Errorf("getting rare shop sales: %w", err)
+		}
+		if sold >= stockLimit {
+			return dbmodels.SetPlayerCurrencyRow{}, ErrRareItemSoldOut
+		}
+	}
+	if err := queries.AddRareShopSale(ctx, purchase.ItemTypeID); err != nil {
+		return dbmodels.SetPlayerCurrencyRow{}, fmt.Errorf("recording rare shop sale: %w", err)
+	}
+	return s.purchaseItemWith(ctx, queries, playerID, purchase)
+}
+
+// GetRareShopSales returns how many of each Rare Shop item have been sold,
+// keyed by item type ID.
+func (s *Service) GetRareShopSales(ctx context.Context) (map[int64]int64, error) {
+	rows, err := s.queries.GetRareShopSales(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting rare shop sales: %w", err)
+	}
+	sales := make(map[int64]int64, len(rows))
+	for _, row := range rows {
+		sales[row.ItemTypeID] = row.Sold
+	}
+	return sales, nil
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pangbox/server/gen/dbmodels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurchaseRareItem(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+	other, err := s.Register(ctx, "other", "other")
+	require.NoError(t, err)
+
+	const rareTypeID = 0x08000100
+	purchase := ItemPurchase{PangTotal: 1000, ItemTypeID: rareTypeID}
+
+	currency, err := s.PurchaseRareItem(ctx, player.PlayerID, purchase, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(19000), currency.Pang)
+	_, err = s.PurchaseRareItem(ctx, other.PlayerID, purchase, 2)
+	require.NoError(t, err)
+
+	// Stock is shared between players.
+	_, err = s.PurchaseRareItem(ctx, player.PlayerID, purchase, 2)
+	assert.ErrorIs(t, err, ErrRareItemSoldOut)
+
+	balance, err := s.queries.GetPlayerCurrency(ctx, player.PlayerID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(19000), balance.Pang)
+
+	items, err := s.queries.GetItemsByTypeID(ctx, dbmodels.GetItemsByTypeIDParams{PlayerID: player.PlayerID, ItemTypeID: rareTypeID})
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+
+	sales, err := s.GetRareShopSales(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]int64{rareTypeID: 2}, sales)
+
+	// A failed payment doesn't use up stock.
+	purchase.ItemTypeID = 0x08000101
+	purchase.PangTotal = 100000
+	_, err = s.PurchaseRareItem(ctx, player.PlayerID, purchase, 1)
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+	sales, err = s.GetRareShopSales(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), sales[0x08000101])
+}
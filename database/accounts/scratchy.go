@@ -0,0 +1,76 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/pangbox/server/gen/dbmodels"
+)
+
+// ErrNoScratchyCard is returned when a player without a Scratchy card tries
+// to play.
+var ErrNoScratchyCard = errors.New("no scratchy card in inventory")
+
+// ScratchyPrize is an item won from a Scratchy card. A non-zero Quantity adds
+// to a consumable stack; otherwise a single permanent item is added.
+type ScratchyPrize struct {
+	ItemTypeID int64
+	Quantity   int64
+}
+
+// PlayScratchy uses up one of the player's Scratchy cards of type cardTypeID
+// and adds the prize to their inventory. The inventory item ID of the card is
+// returned.
+func (s *Service) PlayScratchy(ctx context.Context, playerID, cardTypeID int64, prize ScratchyPrize) (int64, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	cards, err := queries.GetItemsByTypeID(ctx, dbmodels.GetItemsByTypeIDParams{
+		PlayerID:   playerID,
+		ItemTypeID: cardTypeID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("getting scratchy cards: %w", err)
+	}
+	if len(cards) == 0 {
+		return 0, ErrNoScratchyCard
+	}
+	cardItemID, err := s.decrementConsumableQuantityWith(ctx, queries, playerID, cardTypeID)
+	if err != nil {
+		return 0, fmt.Errorf("using scratchy card: %w", err)
+	}
+
+	if err := s.grantItemWith(ctx, queries, playerID, prize.ItemTypeID, prize.Quantity); err != nil {
+		return 0, fmt.Errorf("adding scratchy prize %08x: %w", prize.ItemTypeID, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return cardItemID, nil
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pangbox/server/gen/dbmodels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayScratchy(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+
+	const cardTypeID = 0x1A000040
+	prize := ScratchyPrize{ItemTypeID: 0x18000000, Quantity: 3}
+
+	_, err := s.PlayScratchy(ctx, player.PlayerID, cardTypeID, prize)
+	assert.ErrorIs(t, err, ErrNoScratchyCard)
+
+	require.NoError(t, s.grantItemWith(ctx, s.queries, player.PlayerID, cardTypeID, 1))
+	cardItemID, err := s.PlayScratchy(ctx, player.PlayerID, cardTypeID, prize)
+	require.NoError(t, err)
+	assert.NotEqual(t, int64(0), cardItemID)
+
+	cards, err := s.queries.GetItemsByTypeID(ctx, dbmodels.GetItemsByTypeIDParams{PlayerID: player.PlayerID, ItemTypeID: cardTypeID})
+	require.NoError(t, err)
+	assert.Empty(t, cards)
+
+	items, err := s.queries.GetItemsByTypeID(ctx, dbmodels.GetItemsByTypeIDParams{PlayerID: player.PlayerID, ItemTypeID: prize.ItemTypeID})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, int64(3), items[0].Quantity.Int64)
+}
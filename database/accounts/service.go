@@ -35,6 +35,12 @@ import (
 var (
 	ErrInvalidPassword = errors.New("invalid password")
 	ErrUnknownUsername = errors.New("unknown user")
+
+	ErrCharacterNotFound       = errors.New("character not found")
+	ErrCharacterPartNotOwned   = errors.New("character part not owned")
+	ErrCharacterPartInvalid    = errors.New("part does not fit character slot")
+	ErrCharacterAuxPartInvalid = errors.New("invalid aux part")
+	ErrCharacterCutInInvalid   = errors.New("invalid cut-in")
 )
 
 const sessionTimeout = 15 * time.Minute
@@ -234,17 +240,6 @@ func (s *Service) SetCharacter(ctx context.Context, playerID int64, characterID
 	return err
 }
 
-func (s *Service) SetCaddie(ctx context.Context, playerID int64, caddieID int64) error {
-	_, err := s.queries.SetPlayerCaddie(ctx, dbmodels.SetPlayerCaddieParams{
-		PlayerID: playerID,
-		CaddieID: sql.NullInt64{Valid: true, Int64: caddieID},
-	})
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
 func (s *Service) getConsumablesWith(ctx context.Context, tx *dbmodels.Queries, playerID int64) ([10]uint32, error) {
 	row, err := tx.GetPlayerConsumables(ctx, playerID)
 	if err != nil {
@@ -478,7 +473,24 @@ func (s *Service) SetClubSet(ctx context.Context, playerID int64, clubsetID int6
 	return nil
 }
 
-func (s *Service) PurchaseItem(ctx context.Context, playerID, pangTotal, pointTotal, itemTypeID, quantity int64) (dbmodels.SetPlayerCurrencyRow, error) {
+// ItemPurchase describes a single item bought from the shop.
+type ItemPurchase struct {
+	PangTotal  int64
+	PointTotal int64
+	ItemTypeID int64
+	Quantity   int64
+
+	// Duration is how long a time-limited item lasts. Zero means the item is
+	// permanent.
+	Duration time.Duration
+
+	// Rare is set for Rare Shop items bought with PurchaseItems, and
+	// StockLimit is passed on as PurchaseRareItem's stockLimit.
+	Rare       bool
+	StockLimit int64
+}
+
+func (s *Service) PurchaseItem(ctx context.Context, playerID int64, purchase ItemPurchase) (dbmodels.SetPlayerCurrencyRow, error) {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return dbmodels.SetPlayerCurrencyRow{}, err
@@ -487,25 +499,82 @@ func (s *Service) PurchaseItem(ctx context.Context, playerID, pangTotal, pointTo
 
 	queries := s.queries.WithTx(tx)
 
-	currency, err := queries.GetPlayerCurrency(ctx, playerID)
+	newCurrency, err := s.purchaseItemWith(ctx, queries, playerID, purchase)
 	if err != nil {
-		return dbmodels.SetPlayerCurrencyRow{}, fmt.Errorf("getting player currency: %w", err)
+		return dbmodels.SetPlayerCurrencyRow{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return dbmodels.SetPlayerCurrencyRow{}, err
 	}
 
-	if quantity != 0 {
-		if err := s.incrementConsumableQuantityWith(ctx, queries, playerID, itemTypeID, quantity); err != nil {
+	return newCurrency, nil
+}
+
+// PurchaseItems buys several items at once, such as a shopping cart. Either
+// all of the items are bought, or none of them are and nothing is charged.
+// The balance after the last item is returned.
+func (s *Service) PurchaseItems(ctx context.Context, playerID int64, purchases []ItemPurchase) (dbmodels.SetPlayerCurrencyRow, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return dbmodels.SetPlayerCurrencyRow{}, err
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	newCurrency := dbmodels.SetPlayerCurrencyRow{}
+	for _, purchase := range purchases {
+		if purchase.Rare {
+			newCurrency, err = s.purchaseRareItemWith(ctx, queries, playerID, purchase, purchase.StockLimit)
+		} else {
+			newCurrency, err = s.purchaseItemWith(ctx, queries, playerID, purchase)
+		}
+		if err != nil {
+			return dbmodels.SetPlayerCurrencyRow{}, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return dbmodels.SetPlayerCurrencyRow{}, err
+	}
+
+	return newCurrency, nil
+}
+
+func (s *Service) purchaseItemWith(ctx context.Context, queries *dbmodels.Queries, playerID int64, purchase ItemPurchase) (dbmodels.SetPlayerCurrencyRow, error) {
+	newCurrency, err := s.changeCurrencyWith(ctx, queries, playerID, CurrencyChange{
+		Pang:       -purchase.PangTotal,
+		Points:     -purchase.PointTotal,
+		Reason:     CurrencyReasonPurchase,
+		ItemTypeID: purchase.ItemTypeID,
+	})
+	if err != nil {
+		return dbmodels.SetPlayerCurrencyRow{}, err
+	}
+
+	now := time.Now()
+	if purchase.Quantity != 0 {
+		if err := s.incrementConsumableQuantityWith(ctx, queries, playerID, purchase.ItemTypeID, purchase.Quantity); err != nil {
 			return dbmodels.SetPlayerCurrencyRow{}, fmt.Errorf("adding consumable quantity to inventory: %w", err)
 		}
+	} else if purchase.Duration != 0 {
+		if err := s.addTimeLimitedItemWith(ctx, queries, playerID, purchase.ItemTypeID, now, purchase.Duration); err != nil {
+			return dbmodels.SetPlayerCurrencyRow{}, fmt.Errorf("adding time-limited item to inventory: %w", err)
+		}
 	} else {
 		item, err := queries.AddItemToInventory(ctx, dbmodels.AddItemToInventoryParams{
-			PlayerID:   playerID,
-			ItemTypeID: itemTypeID,
+			PlayerID:    playerID,
+			ItemTypeID:  purchase.ItemTypeID,
+			PurchasedAt: sql.NullInt64{Valid: true, Int64: now.Unix()},
 		})
 		if err != nil {
 			return dbmodels.SetPlayerCurrencyRow{}, fmt.Errorf("adding item to inventory: %w", err)
 		}
 		// TODO: should use IFF data
-		if itemTypeID >= 0x4000000 && itemTypeID < 0x40000FF {
+		if purchase.ItemTypeID >= 0x4000000 && purchase.ItemTypeID < 0x40000FF {
 			if _, err := queries.CreateCharacter(ctx, dbmodels.CreateCharacterParams{
 				PlayerID: playerID,
 				ItemID:   item.ItemID,
@@ -515,20 +584,6 @@ func (s *Service) PurchaseItem(ctx context.Context, playerID, pangTotal, pointTo
 		}
 	}
 
-	newCurrency, err := queries.SetPlayerCurrency(ctx, dbmodels.SetPlayerCurrencyParams{
-		PlayerID: playerID,
-		Pang:     currency.Pang - pangTotal,
-		Points:   currency.Points - pointTotal,
-	})
-	if err != nil {
-		return dbmodels.SetPlayerCurrencyRow{}, fmt.Errorf("setting player currency: %w", err)
-	}
-
-	err = tx.Commit()
-	if err != nil {
-		return dbmodels.SetPlayerCurrencyRow{}, err
-	}
-
 	return newCurrency, nil
 }
 
@@ -571,34 +626,16 @@ func (s *Service) UseItem(ctx context.Context, playerID, itemTypeID int64, playe
 	return nil
 }
 
-func (s *Service) AddPang(ctx context.Context, playerID, pang int64) (int64, error) {
-	tx, err := s.db.BeginTx(ctx, nil)
-	if err != nil {
-		return 0, err
-	}
-	defer tx.Rollback()
-
-	queries := s.queries.WithTx(tx)
-
-	currency, err := queries.GetPlayerCurrency(ctx, playerID)
-	if err != nil {
-		return 0, err
-	}
-
-	newCurrency, err := queries.SetPlayerCurrency(ctx, dbmodels.SetPlayerCurrencyParams{
-		PlayerID: playerID,
-		Pang:     currency.Pang + pang,
-		Points:   currency.Points,
+// AddPang adds (or, with a negative value, removes) Pang from a player,
+// recording the change in the currency ledger. It returns the new balance.
+func (s *Service) AddPang(ctx context.Context, playerID, pang int64, reason CurrencyReason) (int64, error) {
+	newCurrency, err := s.ChangeCurrency(ctx, playerID, CurrencyChange{
+		Pang:   pang,
+		Reason: reason,
 	})
 	if err != nil {
 		return 0, err
 	}
-
-	err = tx.Commit()
-	if err != nil {
-		return 0, err
-	}
-
 	return newCurrency.Pang, nil
 }
 
@@ -689,156 +726,173 @@ func (s *Service) SetDecoration(ctx context.Context, playerId int64, decoration
 	return nil
 }
 
-func (s *Service) getPartIDWith(ctx context.Context, tx *dbmodels.Queries, playerID int64, itemID uint32) (sql.NullInt64, error) {
+// getPartIDWith resolves a part slot sent by the client. A part without an
+// item must either be the character's default for the slot or the part it
+// already has there; otherwise the player must own a matching item.
+func (s *Service) getPartIDWith(ctx context.Context, tx *dbmodels.Queries, playerID int64, itemID, typeID uint32, allowed [2]uint32, now time.Time) (sql.NullInt64, error) {
 	if itemID == 0 {
+		if typeID != 0 && typeID != allowed[0] && typeID != allowed[1] {
+			return sql.NullInt64{}, ErrCharacterPartNotOwned
+		}
 		return sql.NullInt64{}, nil
 	}
-	item, err := tx.GetItem(ctx, dbmodels.GetItemParams{
-		PlayerID: playerID,
-		ItemID:   int64(itemID),
-	})
+	item, ok, err := s.getOwnedItemWith(ctx, tx, playerID, int64(itemID), pangya.ItemGroupPart, now)
 	if err != nil {
 		return sql.NullInt64{}, err
+	} else if !ok || item.ItemTypeID != int64(typeID) {
+		return sql.NullInt64{}, ErrCharacterPartNotOwned
 	}
 	return sql.NullInt64{Valid: true, Int64: item.ItemID}, nil
 }
 
-func (s *Service) SetCharacterParts(ctx context.Context, playerID int64, data pangya.PlayerCharacterData) error {
-	tx, err := s.db.BeginTx(ctx, nil)
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
-
-	queries := s.queries.WithTx(tx)
-
-	part00Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[0])
-	if err != nil {
-		return err
-	}
-	part01Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[1])
-	if err != nil {
-		return err
-	}
-	part02Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[2])
-	if err != nil {
-		return err
-	}
-	part03Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[3])
-	if err != nil {
-		return err
-	}
-	part04Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[4])
-	if err != nil {
-		return err
-	}
-	part05Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[5])
-	if err != nil {
-		return err
-	}
-	part06Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[6])
-	if err != nil {
-		return err
-	}
-	part07Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[7])
-	if err != nil {
-		return err
-	}
-	part08Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[8])
-	if err != nil {
-		return err
-	}
-	part09Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[9])
-	if err != nil {
-		return err
-	}
-	part10Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[10])
-	if err != nil {
-		return err
-	}
-	part11Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[11])
-	if err != nil {
-		return err
-	}
-	part12Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[12])
-	if err != nil {
-		return err
-	}
-	part13Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[13])
-	if err != nil {
-		return err
-	}
-	part14Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[14])
-	if err != nil {
-		return err
-	}
-	part15Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[15])
-	if err != nil {
-		return err
-	}
-	part16Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[16])
-	if err != nil {
-		return err
-	}
-	part17Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[17])
-	if err != nil {
-		return err
+// getAuxPartIDWith resolves an aux part (ring or accessory) slot.
+func (s *Service) getAuxPartIDWith(ctx context.Context, tx *dbmodels.Queries, playerID int64, itemID uint32, now time.Time) (sql.NullInt64, error) {
+	if itemID == 0 {
+		return sql.NullInt64{}, nil
 	}
-	part18Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[18])
+	_, ok, err := s.getOwnedItemWith(ctx, tx, playerID, int64(itemID), pangya.ItemGroupAuxPart, now)
 	if err != nil {
-		return err
+		return sql.NullInt64{}, err
+	} else if !ok {
+		return sql.NullInt64{}, ErrCharacterAuxPartInvalid
 	}
-	part19Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[19])
-	if err != nil {
-		return err
+	return sql.NullInt64{Valid: true, Int64: int64(itemID)}, nil
+}
+
+func characterPartTypeIDs(character dbmodels.GetCharacterRow) [24]uint32 {
+	return [24]uint32{
+		uint32(character.Part00ItemTypeID), uint32(character.Part01ItemTypeID), uint32(character.Part02ItemTypeID), uint32(character.Part03ItemTypeID),
+		uint32(character.Part04ItemTypeID), uint32(character.Part05ItemTypeID), uint32(character.Part06ItemTypeID), uint32(character.Part07ItemTypeID),
+		uint32(character.Part08ItemTypeID), uint32(character.Part09ItemTypeID), uint32(character.Part10ItemTypeID), uint32(character.Part11ItemTypeID),
+		uint32(character.Part12ItemTypeID), uint32(character.Part13ItemTypeID), uint32(character.Part14ItemTypeID), uint32(character.Part15ItemTypeID),
+		uint32(character.Part16ItemTypeID), uint32(character.Part17ItemTypeID), uint32(character.Part18ItemTypeID), uint32(character.Part19ItemTypeID),
+		uint32(character.Part20ItemTypeID), uint32(character.Part21ItemTypeID), uint32(character.Part22ItemTypeID), uint32(character.Part23ItemTypeID),
 	}
-	part20Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[20])
-	if err != nil {
-		return err
+}
+
+func characterPartItemIDs(character dbmodels.GetCharacterRow) [24]sql.NullInt64 {
+	return [24]sql.NullInt64{
+		character.Part00ItemID, character.Part01ItemID, character.Part02ItemID, character.Part03ItemID,
+		character.Part04ItemID, character.Part05ItemID, character.Part06ItemID, character.Part07ItemID,
+		character.Part08ItemID, character.Part09ItemID, character.Part10ItemID, character.Part11ItemID,
+		character.Part12ItemID, character.Part13ItemID, character.Part14ItemID, character.Part15ItemID,
+		character.Part16ItemID, character.Part17ItemID, character.Part18ItemID, character.Part19ItemID,
+		character.Part20ItemID, character.Part21ItemID, character.Part22ItemID, character.Part23ItemID,
 	}
-	part21Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[21])
+}
+
+// PartInfo is what the IFF says about a character part.
+type PartInfo struct {
+	Character uint8
+	Slot      int
+}
+
+// PartLookup returns the IFF entry of a character part type, or false if
+// there is no such part.
+type PartLookup func(typeID uint32) (PartInfo, bool)
+
+// PartDefaults returns the default part type IDs of a character type.
+type PartDefaults func(charTypeID uint32) [24]uint32
+
+// SetCharacterParts validates and saves the parts equipped on one of the
+// player's characters. Each part must exist in the IFF, which lookupPart
+// reads, be for the character and go in the slot it is sent in. It must be
+// owned unless it is one of defaults, the character's default part type IDs.
+// The cut-in must be an owned cut-in that hasn't expired. Nothing is saved if
+// any part is invalid.
+func (s *Service) SetCharacterParts(ctx context.Context, playerID int64, data pangya.PlayerCharacterData, defaults [24]uint32, lookupPart PartLookup, now time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
-	part22Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[22])
-	if err != nil {
-		return err
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	character, err := queries.GetCharacter(ctx, int64(data.ID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrCharacterNotFound
+	} else if err != nil {
+		return fmt.Errorf("getting character: %w", err)
+	}
+	if character.PlayerID != playerID {
+		return ErrCharacterNotFound
+	}
+
+	// Parts without an item can only be kept if they are defaults, so a
+	// client can't equip arbitrary parts by leaving out the item ID.
+	current := characterPartTypeIDs(character)
+	currentItems := characterPartItemIDs(character)
+	charIndex := uint8(character.CharacterTypeID)
+	var parts [24]sql.NullInt64
+	for i, typeID := range data.PartTypeIDs {
+		if typeID != 0 {
+			part, ok := lookupPart(typeID)
+			if !ok || part.Character != charIndex || part.Slot != i {
+				return ErrCharacterPartInvalid
+			}
+		}
+		allowed := [2]uint32{defaults[i]}
+		if !currentItems[i].Valid {
+			allowed[1] = current[i]
+		}
+		parts[i], err = s.getPartIDWith(ctx, queries, playerID, data.PartIDs[i], typeID, allowed, now)
+		if err != nil {
+			return err
+		}
 	}
-	part23Item, err := s.getPartIDWith(ctx, queries, playerID, data.PartIDs[23])
-	if err != nil {
-		return err
+
+	var auxParts [5]sql.NullInt64
+	for i, itemID := range data.AuxParts {
+		for _, other := range data.AuxParts[:i] {
+			if itemID != 0 && itemID == other {
+				return ErrCharacterAuxPartInvalid
+			}
+		}
+		auxParts[i], err = s.getAuxPartIDWith(ctx, queries, playerID, itemID, now)
+		if err != nil {
+			return err
+		}
 	}
-	cutIn, err := s.getPartIDWith(ctx, queries, playerID, data.CutInID)
-	if err != nil {
-		return err
+
+	cutIn := sql.NullInt64{}
+	if data.CutInID != 0 {
+		item, ok, err := s.getOwnedItemWith(ctx, queries, playerID, int64(data.CutInID), pangya.ItemGroupSkin, now)
+		if err != nil {
+			return fmt.Errorf("getting cut-in: %w", err)
+		} else if !ok || pangya.SkinKindOf(uint32(item.ItemTypeID)) != pangya.SkinKindCutIn {
+			return ErrCharacterCutInInvalid
+		}
+		cutIn = sql.NullInt64{Valid: true, Int64: item.ItemID}
 	}
 
-	queries.SetCharacterParts(ctx, dbmodels.SetCharacterPartsParams{
+	if _, err := queries.SetCharacterParts(ctx, dbmodels.SetCharacterPartsParams{
 		CharacterID:      int64(data.ID),
-		Part00ItemID:     part00Item,
-		Part01ItemID:     part01Item,
-		Part02ItemID:     part02Item,
-		Part03ItemID:     part03Item,
-		Part04ItemID:     part04Item,
-		Part05ItemID:     part05Item,
-		Part06ItemID:     part06Item,
-		Part07ItemID:     part07Item,
-		Part08ItemID:     part08Item,
-		Part09ItemID:     part09Item,
-		Part10ItemID:     part10Item,
-		Part11ItemID:     part11Item,
-		Part12ItemID:     part12Item,
-		Part13ItemID:     part13Item,
-		Part14ItemID:     part14Item,
-		Part15ItemID:     part15Item,
-		Part16ItemID:     part16Item,
-		Part17ItemID:     part17Item,
-		Part18ItemID:     part18Item,
-		Part19ItemID:     part19Item,
-		Part20ItemID:     part20Item,
-		Part21ItemID:     part21Item,
-		Part22ItemID:     part22Item,
-		Part23ItemID:     part23Item,
+		PlayerID:         playerID,
+		Part00ItemID:     parts[0],
+		Part01ItemID:     parts[1],
+		Part02ItemID:     parts[2],
+		Part03ItemID:     parts[3],
+		Part04ItemID:     parts[4],
+		Part05ItemID:     parts[5],
+		Part06ItemID:     parts[6],
+		Part07ItemID:     parts[7],
+		Part08ItemID:     parts[8],
+		Part09ItemID:     parts[9],
+		Part10ItemID:     parts[10],
+		Part11ItemID:     parts[11],
+		Part12ItemID:     parts[12],
+		Part13ItemID:     parts[13],
+		Part14ItemID:     parts[14],
+		Part15ItemID:     parts[15],
+		Part16ItemID:     parts[16],
+		Part17ItemID:     parts[17],
+		Part18ItemID:     parts[18],
+		Part19ItemID:     parts[19],
+		Part20ItemID:     parts[20],
+		Part21ItemID:     parts[21],
+		Part22ItemID:     parts[22],
+		Part23ItemID:     parts[23],
 		Part00ItemTypeID: int64(data.PartTypeIDs[0]),
 		Part01ItemTypeID: int64(data.PartTypeIDs[1]),
 		Part02ItemTypeID: int64(data.PartTypeIDs[2]),
@@ -863,16 +917,17 @@ func (s *Service) SetCharacterParts(ctx context.Context, playerID int64, data pa
 		Part21ItemTypeID: int64(data.PartTypeIDs[21]),
 		Part22ItemTypeID: int64(data.PartTypeIDs[22]),
 		Part23ItemTypeID: int64(data.PartTypeIDs[23]),
+		AuxPart0ID:       auxParts[0],
+		AuxPart1ID:       auxParts[1],
+		AuxPart2ID:       auxParts[2],
+		AuxPart3ID:       auxParts[3],
+		AuxPart4ID:       auxParts[4],
 		CutInID:          cutIn,
-	})
-
-	err = tx.Commit()
-	if err != nil {
-		return err
+	}); err != nil {
+		return fmt.Errorf("setting character parts: %w", err)
 	}
 
-	return nil
-
+	return tx.Commit()
 }
 
 // AddSession adds a new session for a player.
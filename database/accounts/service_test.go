@@ -0,0 +1,65 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pangbox/server/common/hash"
+	"github.com/pangbox/server/database"
+	"github.com/pangbox/server/gen/dbmodels"
+	_ "github.com/pangbox/server/migrations"
+	"github.com/pressly/goose/v3"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+
+	db, err := database.OpenDBWithDriver("sqlite", ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	// Each connection to :memory: is a separate database.
+	db.SetMaxOpenConns(1)
+
+	require.NoError(t, goose.Up(db, "."))
+
+	return NewService(Options{
+		Logger:   zerolog.Nop(),
+		Database: db,
+		Hasher:   hash.Null{},
+	})
+}
+
+func newTestPlayer(t *testing.T, s *Service) dbmodels.Player {
+	t.Helper()
+
+	player, err := s.Register(context.Background(), "test", "test")
+	require.NoError(t, err)
+	return player
+}
+
+// noPartDefaults is used by tests that don't care which parts characters go
+// back to.
+func noPartDefaults(uint32) [24]uint32 {
+	return [24]uint32{}
+}
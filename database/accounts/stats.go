@@ -0,0 +1,76 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/pangbox/server/gen/dbmodels"
+	"github.com/pangbox/server/pangya"
+)
+
+// GetPlayerStats returns the player's accumulated statistics. Players who
+// have not played yet get zeroed statistics.
+func (s *Service) GetPlayerStats(ctx context.Context, playerID int64) (dbmodels.PlayerStat, error) {
+	stats, err := s.queries.GetPlayerStats(ctx, playerID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return dbmodels.PlayerStat{PlayerID: playerID}, nil
+	} else if err != nil {
+		return dbmodels.PlayerStat{}, err
+	}
+	return stats, nil
+}
+
+// AddPlayerStats adds the statistics from a game (or part of one) to the
+// player's totals. Counters are summed, while the longest distances and best
+// Pang only replace the stored values if they are higher.
+func (s *Service) AddPlayerStats(ctx context.Context, playerID int64, stats pangya.PlayerStats) (dbmodels.PlayerStat, error) {
+	result, err := s.queries.AddPlayerStats(ctx, dbmodels.AddPlayerStatsParams{
+		PlayerID:       playerID,
+		TotalStrokes:   int64(stats.TotalStrokes),
+		TotalPutts:     int64(stats.TotalPutts),
+		LongestDrive:   float64(stats.LongestDrive),
+		PangyaHits:     int64(stats.PangyaHits),
+		Timeouts:       int64(stats.Timeouts),
+		Obs:            int64(stats.OBs),
+		TotalDistance:  int64(stats.TotalDistance),
+		TotalHoles:     int64(stats.TotalHoles),
+		HoleUnfinished: int64(stats.HoleUnfinished),
+		TotalHio:       int64(stats.TotalHIO),
+		BunkersHit:     int64(stats.BunkersHit),
+		FairwaysHit:    int64(stats.FairwaysHit),
+		TotalAlbatross: int64(stats.TotalAlbatross),
+		PuttIns:        int64(stats.PuttIns),
+		LongestPutt:    float64(stats.LongestPutt),
+		LongestChip:    float64(stats.LongestChip),
+		TotalScore:     int64(stats.TotalScore),
+		BestPang:       int64(stats.BestPangTotal),
+		GamesPlayed:    int64(stats.GamesPlayed),
+		Quits:          int64(stats.Quits),
+		TeamHole:       int64(stats.TeamHole),
+		TeamWin:        int64(stats.TeamWin),
+		TeamGame:       int64(stats.TeamGame),
+	})
+	if err != nil {
+		return dbmodels.PlayerStat{}, fmt.Errorf("adding player stats: %w", err)
+	}
+	return result, nil
+}
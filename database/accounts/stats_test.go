@@ -0,0 +1,63 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package accounts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pangbox/server/pangya"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddPlayerStats(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	player := newTestPlayer(t, s)
+
+	stats, err := s.GetPlayerStats(ctx, player.PlayerID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), stats.GamesPlayed)
+
+	_, err = s.AddPlayerStats(ctx, player.PlayerID, pangya.PlayerStats{
+		TotalStrokes:  40,
+		LongestDrive:  250,
+		GamesPlayed:   1,
+		BestPangTotal: 900,
+	})
+	require.NoError(t, err)
+
+	stats, err = s.AddPlayerStats(ctx, player.PlayerID, pangya.PlayerStats{
+		TotalStrokes:  36,
+		LongestDrive:  210,
+		GamesPlayed:   1,
+		BestPangTotal: 1200,
+		TeamHole:      18,
+		TeamWin:       1,
+		TeamGame:      1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(76), stats.TotalStrokes)
+	assert.Equal(t, float64(250), stats.LongestDrive)
+	assert.Equal(t, int64(2), stats.GamesPlayed)
+	assert.Equal(t, int64(1200), stats.BestPang)
+	assert.Equal(t, int64(18), stats.TeamHole)
+	assert.Equal(t, int64(1), stats.TeamWin)
+	assert.Equal(t, int64(1), stats.TeamGame)
+}
@@ -93,10 +93,25 @@ type RoomAction struct {
 	Departure   *uint32             `struct-if:"ActionType == 8"`
 }
 
+// Room types, as set in RoomState.RoomType. Only the types the server plays
+// differently are named.
+const (
+	RoomTypeStroke  byte = 0x00
+	RoomTypeMatch   byte = 0x01
+	RoomTypeTourney byte = 0x03
+)
+
+// MatchMaxPlayers is the number of players in a match play game.
+const MatchMaxPlayers = 2
+
+// TourneyMaxPlayers is the number of players that can play in a tournament
+// room.
+const TourneyMaxPlayers = 30
+
 type GamePhase int
 
 const (
-	LobbyPhase GamePhase = 1
+	LobbyPhase GamePhase = iota + 1
 	WaitingLoad
 	InGame
 )
@@ -327,8 +327,8 @@ type UpdateCharacter struct {
 	CharacterID uint32
 }
 
-type UpdateUnknown1 struct {
-	Unknown uint32
+type UpdateMascot struct {
+	MascotID uint32
 }
 
 type UpdateUnknown2 struct {
@@ -346,7 +346,7 @@ type ClientEquipmentUpdate struct {
 	Comet       *UpdateComet                `struct-if:"Type == 3"`
 	Decoration  *UpdateDecoration           `struct-if:"Type == 4"`
 	Character   *UpdateCharacter            `struct-if:"Type == 5"`
-	Unknown1    *UpdateUnknown1             `struct-if:"Type == 8"`
+	Mascot      *UpdateMascot               `struct-if:"Type == 8"`
 	Unknown2    *UpdateUnknown2             `struct-if:"Type == 9"`
 }
 
@@ -201,6 +201,10 @@ type CharacterUpdated struct {
 	CharacterID uint32
 }
 
+type MascotUpdated struct {
+	MascotID uint32
+}
+
 type EquipmentUpdateType uint8
 
 const (
@@ -210,11 +214,22 @@ const (
 	UpdatedComet       EquipmentUpdateType = 3
 	UpdatedDecoration  EquipmentUpdateType = 4
 	UpdatedCharacter   EquipmentUpdateType = 5
+	UpdatedMascot      EquipmentUpdateType = 8
+)
+
+// EquipmentUpdateStatus is the result of an equipment update. Only
+// EquipmentUpdateOK is confirmed; EquipmentUpdateFailed is a best guess that
+// the client displays as a generic failure.
+type EquipmentUpdateStatus uint8
+
+const (
+	EquipmentUpdateFailed EquipmentUpdateStatus = 0x00
+	EquipmentUpdateOK     EquipmentUpdateStatus = 0x04
 )
 
 type ServerPlayerEquipmentUpdated struct {
 	ServerMessage_
-	Status      uint8
+	Status      EquipmentUpdateStatus
 	Type        uint8
 	CharParts   *pangya.PlayerCharacterData `struct-if:"Type == 0"`
 	Caddie      *CaddieUpdated              `struct-if:"Type == 1"`
@@ -222,6 +237,7 @@ type ServerPlayerEquipmentUpdated struct {
 	Comet       *CometUpdated               `struct-if:"Type == 3"`
 	Decoration  *DecorationUpdated          `struct-if:"Type == 4"`
 	Character   *CharacterUpdated           `struct-if:"Type == 5"`
+	Mascot      *MascotUpdated              `struct-if:"Type == 8"`
 }
 
 // ServerCharData contains the user's characters.
@@ -237,6 +253,10 @@ type ServerPlayerEquipment struct {
 	Equipment pangya.PlayerEquipment
 }
 
+// InventoryFlagTimeLimited marks an inventory item as a rental, which makes the
+// client show the rental end date.
+const InventoryFlagTimeLimited = 0x20
+
 type InventoryItem struct {
 	ItemID          uint32
 	ItemTypeID      uint32
@@ -624,9 +644,21 @@ type ServerRoomFinishGame struct {
 	Standings  []PlayerGameResult `struct:"sizefrom=NumPlayers"`
 }
 
+// PurchaseStatus is the result of a shop purchase. Only PurchaseOK and
+// PurchaseInsufficientFunds are confirmed; the others are best guesses that
+// the client displays as a generic failure.
+type PurchaseStatus uint32
+
+const (
+	PurchaseOK                PurchaseStatus = 0
+	PurchaseInsufficientFunds PurchaseStatus = 1
+	PurchaseItemUnavailable   PurchaseStatus = 2
+	PurchasePriceMismatch     PurchaseStatus = 3
+)
+
 type ServerPurchaseItemResponse struct {
 	ServerMessage_
-	Status uint32
+	Status PurchaseStatus
 	Pang   uint64
 	Points uint64
 }
@@ -657,7 +689,7 @@ type ServerGuildListPage struct {
 	Status     uint32
 	Page       uint32
 	NumPages   uint32
-	GuildCount uint16
+	GuildCount uint16 `struct:"sizeof=Guilds"`
 	Guilds     []pangya.GuildData
 }
 
@@ -972,9 +1004,17 @@ type Server0231 struct {
 	ServerMessage_
 }
 
+// ServerLoginBonusStatus describes the player's daily login bonus. Item is
+// today's reward and NextItem is the reward for the following day.
 type ServerLoginBonusStatus struct {
 	ServerMessage_
-	Unknown []byte
+	Status         uint32
+	ClaimedToday   bool
+	ItemTypeID     uint32
+	Quantity       uint32
+	NextItemTypeID uint32
+	NextQuantity   uint32
+	Streak         uint32
 }
 
 type ServerEventLobbyJoined struct {
@@ -0,0 +1,70 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package room
+
+import (
+	"context"
+	"time"
+
+	"github.com/pangbox/server/database/accounts"
+	"github.com/pangbox/server/gameconfig"
+)
+
+// RecordAchievementEvent advances every achievement counter tied to event for
+// the given player, completing stages and granting their rewards as their
+// goals are reached. Achievements sharing a counter only advance it once. The
+// newly completed stages are returned.
+func RecordAchievementEvent(ctx context.Context, accountsService *accounts.Service, configProvider gameconfig.Provider, playerID int64, event gameconfig.AchievementEvent, amount uint32) ([]accounts.AchievementStage, error) {
+	counters := []uint32{}
+	stages := map[uint32][]accounts.AchievementStage{}
+	for _, achievement := range configProvider.GetAchievements() {
+		if achievement.Event != event {
+			continue
+		}
+		if _, ok := stages[achievement.CounterTypeID]; !ok {
+			counters = append(counters, achievement.CounterTypeID)
+		}
+		for _, stage := range achievement.Stages {
+			stages[achievement.CounterTypeID] = append(stages[achievement.CounterTypeID], accounts.AchievementStage{
+				AchievementTypeID: int64(achievement.TypeID),
+				StageTypeID:       int64(stage.TypeID),
+				Goal:              int64(stage.Goal),
+				ItemTypeID:        int64(stage.ItemTypeID),
+				Quantity:          int64(stage.Quantity),
+				Pang:              int64(stage.Pang),
+			})
+		}
+	}
+
+	now := time.Now()
+	var completed []accounts.AchievementStage
+	for _, counterTypeID := range counters {
+		_, newlyCompleted, err := accountsService.AddAchievementCounter(ctx, playerID, int64(counterTypeID), int64(amount), stages[counterTypeID], now)
+		if err != nil {
+			return completed, err
+		}
+		completed = append(completed, newlyCompleted...)
+	}
+	return completed, nil
+}
+
+func (r *Room) recordAchievementEvent(ctx context.Context, player *RoomPlayer, event gameconfig.AchievementEvent) {
+	if _, err := RecordAchievementEvent(ctx, r.accounts, r.lobby.configProvider, int64(player.Entry.PlayerID), event, 1); err != nil {
+		r.log.Error().Err(err).Str("event", string(event)).Msg("failed recording achievement event")
+	}
+}
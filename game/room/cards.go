@@ -0,0 +1,41 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package room
+
+import (
+	"context"
+)
+
+// loadCards loads the player's equipped cards and adds up their effects for
+// the game that is starting.
+func (r *Room) loadCards(ctx context.Context, player *RoomPlayer) {
+	player.CardPangRate = 0
+	player.CardExpRate = 0
+
+	slots, err := r.accounts.GetCardSlots(ctx, int64(player.Entry.PlayerID))
+	if err != nil {
+		r.log.Error().Err(err).Uint32("player", player.Entry.PlayerID).Msg("failed loading equipped cards")
+		return
+	}
+	for _, slot := range slots {
+		if card, ok := r.lobby.configProvider.GetCard(uint32(slot.CardTypeID)); ok {
+			player.CardPangRate += card.PangRate
+			player.CardExpRate += card.ExpRate
+		}
+	}
+}
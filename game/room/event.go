@@ -209,11 +209,53 @@ type RoomGameShotSync struct {
 
 type RoomGameHoleInfo struct {
 	roomEvent
-	Par  uint8
-	TeeX float32
-	TeeZ float32
-	PinX float32
-	PinZ float32
+	ConnID uint32
+	Par    uint8
+	TeeX   float32
+	TeeZ   float32
+	PinX   float32
+	PinZ   float32
+}
+
+// RoomShotTimeUp is sent by a player's shot timer when they run out of time
+// to take their shot. Turn is the turn the timer was started for.
+type RoomShotTimeUp struct {
+	roomEvent
+	ConnID uint32
+	Turn   int
+}
+
+// RoomGameTimeUp is sent by the game timer when the game's time limit is
+// reached. Game is the game the timer was started for.
+type RoomGameTimeUp struct {
+	roomEvent
+	Game int
+}
+
+// RoomPlayerDisconnect is sent when a player's connection is lost. During a
+// game, the room keeps the player's place for a while so that they can
+// reconnect; the promise resolves to true if it did.
+type RoomPlayerDisconnect struct {
+	roomEvent
+	ConnID uint32
+}
+
+// RoomPlayerReconnect puts a player whose place in the game was kept back
+// into it on their new connection. The promise resolves to the connection
+// ID the room knows the player by.
+type RoomPlayerReconnect struct {
+	roomEvent
+	PlayerID   uint32
+	Conn       *gamepacket.ServerConn
+	UpdateFunc func()
+}
+
+// RoomPlayerReconnectTimeout is sent when a disconnected player has run out
+// of time to reconnect. Disconnect is the disconnection it was sent for.
+type RoomPlayerReconnectTimeout struct {
+	roomEvent
+	ConnID     uint32
+	Disconnect int
 }
 
 type ChatMessage struct {
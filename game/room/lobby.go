@@ -41,6 +41,24 @@ type Lobby struct {
 	players        *orderedmap.OrderedMap[uint32, *LobbyPlayer]
 	accounts       *accounts.Service
 	configProvider gameconfig.Provider
+	server         Server
+}
+
+// Server is the game server that hosts the lobby, for the things rooms
+// need to tell it about players who aren't in them.
+type Server interface {
+	// NotifyMail tells a player that they have new mail, if they are
+	// connected.
+	NotifyMail(ctx context.Context, playerID int64) error
+
+	// AddRejoin records that a room is keeping a player's place in a game
+	// after they lost their connection, so that they are put back into it
+	// when they log back in.
+	AddRejoin(playerID int64, room *Room)
+
+	// RemoveRejoin forgets that a room was keeping a player's place in a
+	// game.
+	RemoveRejoin(playerID int64, room *Room)
 }
 
 type LobbyPlayer struct {
@@ -49,13 +67,14 @@ type LobbyPlayer struct {
 	Joined time.Time
 }
 
-func NewLobby(ctx context.Context, log zerolog.Logger, accounts *accounts.Service, configProvider gameconfig.Provider) *Lobby {
+func NewLobby(ctx context.Context, log zerolog.Logger, accounts *accounts.Service, configProvider gameconfig.Provider, server Server) *Lobby {
 	lobby := &Lobby{
 		log:            log,
 		storage:        new(Storage),
 		players:        orderedmap.New[uint32, *LobbyPlayer](),
 		accounts:       accounts,
 		configProvider: configProvider,
+		server:         server,
 	}
 	lobby.TryStart(ctx, lobby.task)
 	return lobby
@@ -78,6 +97,7 @@ func (l *Lobby) GetRoom(ctx context.Context, roomNumber int16) *Room {
 }
 
 func (l *Lobby) broadcast(ctx context.Context, message gamepacket.ServerMessage) error {
+	var data []byte
 	group, ctx := errgroup.WithContext(ctx)
 	for pair := l.players.Oldest(); pair != nil; pair = pair.Next() {
 		player := pair.Value
@@ -87,8 +107,14 @@ func (l *Lobby) broadcast(ctx context.Context, message gamepacket.ServerMessage)
 			continue
 		}
 
+		if data == nil {
+			var err error
+			if data, err = player.Conn.PackMessage(message); err != nil {
+				return err
+			}
+		}
 		group.Go(func() error {
-			return player.Conn.SendMessage(ctx, message)
+			return player.Conn.SendPacket(ctx, data)
 		})
 	}
 	return group.Wait()
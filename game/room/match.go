@@ -0,0 +1,105 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package room
+
+import (
+	"errors"
+
+	gamemodel "github.com/pangbox/server/game/model"
+	gamepacket "github.com/pangbox/server/game/packet"
+	"golang.org/x/exp/slices"
+)
+
+func (r *Room) isMatchPlay() bool {
+	return r.state.RoomType == gamemodel.RoomTypeMatch
+}
+
+// checkCanStart returns an error if the room's game mode can't be played
+// with the players in the room.
+func (r *Room) checkCanStart() error {
+	if r.isMatchPlay() && r.players.Len() != gamemodel.MatchMaxPlayers {
+		return errors.New("match play needs exactly two players")
+	}
+	return nil
+}
+
+// applyRoomTypeLimits caps the room size for game modes with a fixed number
+// of players.
+func (r *Room) applyRoomTypeLimits() {
+	if r.isMatchPlay() && r.state.MaxUsers > gamemodel.MatchMaxPlayers {
+		r.state.MaxUsers = gamemodel.MatchMaxPlayers
+	}
+	if r.isTourney() && r.state.MaxUsers > gamemodel.TourneyMaxPlayers {
+		r.state.MaxUsers = gamemodel.TourneyMaxPlayers
+	}
+}
+
+// scoreMatchHole awards the hole that just ended to the player with the
+// fewest strokes on it. If the fewest strokes are shared, the hole is
+// halved and nobody wins it.
+func (r *Room) scoreMatchHole() {
+	var winner *RoomPlayer
+	halved := false
+	for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
+		if pair.Value.Spectator {
+			continue
+		}
+		switch {
+		case winner == nil || pair.Value.LastTotal < winner.LastTotal:
+			winner = &pair.Value
+			halved = false
+		case pair.Value.LastTotal == winner.LastTotal:
+			halved = true
+		}
+	}
+	if winner != nil && !halved {
+		winner.HolesWon++
+	}
+}
+
+// matchMargin returns how many holes a player is ahead of their closest
+// opponent; negative if they are behind.
+func (r *Room) matchMargin(player *RoomPlayer) int {
+	best := 0
+	for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
+		if pair.Value.Entry.ConnID != player.Entry.ConnID && pair.Value.HolesWon > best {
+			best = pair.Value.HolesWon
+		}
+	}
+	return player.HolesWon - best
+}
+
+// matchDecided returns true once a player leads by more holes than are left
+// to play, so the match can end early.
+func (r *Room) matchDecided() bool {
+	holesLeft := int(r.state.NumHoles) - int(r.state.CurrentHole)
+	for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
+		if r.matchMargin(&pair.Value) > holesLeft {
+			return true
+		}
+	}
+	return false
+}
+
+// sortMatchStandings orders game results by holes won. In match play, the
+// result score is the player's margin in holes.
+func sortMatchStandings(standings []gamepacket.PlayerGameResult) {
+	slices.SortFunc(standings, func(a, b gamepacket.PlayerGameResult) bool {
+		return a.Score > b.Score
+	})
+}
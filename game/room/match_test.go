@@ -0,0 +1,131 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package room
+
+import (
+	"testing"
+
+	gamemodel "github.com/pangbox/server/game/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreMatchHole(t *testing.T) {
+	tests := []struct {
+		name      string
+		lastTotal [2]int8
+		holesWon  [2]int
+	}{
+		{name: "fewest strokes wins", lastTotal: [2]int8{4, 3}, holesWon: [2]int{0, 1}},
+		{name: "first player wins", lastTotal: [2]int8{2, 5}, holesWon: [2]int{1, 0}},
+		{name: "same strokes halves the hole", lastTotal: [2]int8{4, 4}, holesWon: [2]int{0, 0}},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			r := newBareRoom(t, gamemodel.RoomState{
+				RoomType:    gamemodel.RoomTypeMatch,
+				NumHoles:    3,
+				CurrentHole: 1,
+			}, RoomPlayer{LastTotal: test.lastTotal[0]}, RoomPlayer{LastTotal: test.lastTotal[1]})
+			r.scoreMatchHole()
+			assert.Equal(t, test.holesWon[0], r.players.Value(1).HolesWon)
+			assert.Equal(t, test.holesWon[1], r.players.Value(2).HolesWon)
+		})
+	}
+}
+
+func TestMatchDecided(t *testing.T) {
+	tests := []struct {
+		name        string
+		currentHole uint8
+		holesWon    [2]int
+		decided     bool
+	}{
+		{name: "lead equal to holes left", currentHole: 1, holesWon: [2]int{1, 0}, decided: false},
+		{name: "lead greater than holes left", currentHole: 2, holesWon: [2]int{2, 0}, decided: true},
+		{name: "trailing player can still tie", currentHole: 2, holesWon: [2]int{0, 1}, decided: false},
+		{name: "either player can be ahead", currentHole: 2, holesWon: [2]int{0, 2}, decided: true},
+		{name: "all square after last hole", currentHole: 3, holesWon: [2]int{1, 1}, decided: false},
+		{name: "ahead after last hole", currentHole: 3, holesWon: [2]int{1, 0}, decided: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			r := newBareRoom(t, gamemodel.RoomState{
+				RoomType:    gamemodel.RoomTypeMatch,
+				NumHoles:    3,
+				CurrentHole: test.currentHole,
+			}, RoomPlayer{HolesWon: test.holesWon[0]}, RoomPlayer{HolesWon: test.holesWon[1]})
+			assert.Equal(t, test.decided, r.matchDecided())
+		})
+	}
+}
+
+// playMatchHole plays out the current hole, with each player holing out in
+// the given number of strokes.
+func (r *testRoom) playMatchHole(strokes map[uint32]int8) {
+	r.t.Helper()
+
+	hole := r.state.CurrentHole
+	for r.state.GamePhase == gamemodel.InGame && r.state.CurrentHole == hole {
+		active := r.state.ActiveConnID
+		for connID := range strokes {
+			require.NoError(r.t, r.send(RoomGameShotSync{ConnID: connID, Data: gamemodel.ShotSyncData{ActiveConnID: active}}))
+		}
+		if r.player(active).Stroke == strokes[active] {
+			require.NoError(r.t, r.send(RoomGameHoleEnd{ConnID: active}))
+		}
+		for connID := range strokes {
+			require.NoError(r.t, r.send(RoomGameTurnEnd{ConnID: connID}))
+		}
+	}
+}
+
+func TestMatchHalvedHole(t *testing.T) {
+	r := newTestRoom(t, gamemodel.RoomState{RoomType: gamemodel.RoomTypeMatch})
+	a := r.join("a")
+	b := r.join("b")
+	r.startGame()
+
+	r.playMatchHole(map[uint32]int8{a: 3, b: 3})
+	assert.Equal(t, uint8(2), r.state.CurrentHole)
+	assert.Zero(t, r.player(a).HolesWon)
+	assert.Zero(t, r.player(b).HolesWon)
+}
+
+func TestMatchEarlyFinish(t *testing.T) {
+	r := newTestRoom(t, gamemodel.RoomState{RoomType: gamemodel.RoomTypeMatch, NumHoles: 3})
+	a := r.join("a")
+	b := r.join("b")
+	r.startGame()
+
+	// One up with two to play: the match goes on.
+	r.playMatchHole(map[uint32]int8{a: 2, b: 3})
+	assert.Equal(t, gamemodel.InGame, r.state.GamePhase)
+	assert.Equal(t, uint8(2), r.state.CurrentHole)
+	assert.Equal(t, 1, r.player(a).HolesWon)
+
+	// Two up with one to play: the match is over.
+	r.playMatchHole(map[uint32]int8{a: 2, b: 4})
+	assert.Equal(t, gamemodel.LobbyPhase, r.state.GamePhase)
+	assert.Equal(t, 2, r.player(a).HolesWon)
+	assert.Zero(t, r.player(b).HolesWon)
+	assert.Equal(t, 2, r.matchMargin(r.player(a)))
+	assert.Equal(t, -2, r.matchMargin(r.player(b)))
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package room
+
+import (
+	"context"
+	"time"
+
+	"github.com/pangbox/server/database/accounts"
+	"github.com/pangbox/server/gameconfig"
+)
+
+// recordQuestProgress adds progress to the player's daily quests that count
+// objective on the room's course. Quests that reach their goal grant their
+// reward right away.
+func (r *Room) recordQuestProgress(ctx context.Context, player *RoomPlayer, objective gameconfig.QuestObjective, amount uint32) {
+	if amount == 0 {
+		return
+	}
+	now := time.Now()
+	for _, quest := range r.lobby.configProvider.GetDailyQuests(now) {
+		if !quest.Matches(objective, r.state.Course) {
+			continue
+		}
+		if _, err := r.accounts.AddDailyQuestProgress(ctx, int64(player.Entry.PlayerID), accounts.DailyQuest{
+			QuestTypeID: int64(quest.TypeID),
+			Goal:        int64(quest.Goal),
+			ItemTypeID:  int64(quest.ItemTypeID),
+			Quantity:    int64(quest.Quantity),
+			Pang:        int64(quest.Pang),
+		}, int64(amount), now); err != nil {
+			r.log.Error().Err(err).Uint32("quest", quest.TypeID).Msg("failed recording quest progress")
+		}
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package room
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	gamemodel "github.com/pangbox/server/game/model"
+	gamepacket "github.com/pangbox/server/game/packet"
+)
+
+// When a player loses their connection during a game, their place in it is
+// kept for a while. Their turns are skipped, and holes they can't finish
+// are given up, until they log back in and are put back into the game.
+
+// reconnectGracePeriod is how long a disconnected player's place in a game
+// is kept for them.
+const reconnectGracePeriod = 2 * time.Minute
+
+// giveUpOverPar is the number of strokes over par scored on a hole that a
+// player gave up on because they were disconnected.
+const giveUpOverPar = 3
+
+func (r *Room) handlePlayerDisconnect(ctx context.Context, event RoomPlayerDisconnect) (bool, error) {
+	pair := r.players.GetPair(event.ConnID)
+	if pair == nil {
+		return false, errors.New("user not in room")
+	}
+	if r.state.GamePhase == gamemodel.LobbyPhase || pair.Value.Spectator {
+		return false, r.removePlayer(ctx, event.ConnID)
+	}
+
+	player := &pair.Value
+	r.log.Info().Uint32("conn id", event.ConnID).Msg("keeping place in game for disconnected player")
+	r.lobby.server.AddRejoin(int64(player.Entry.PlayerID), r)
+	player.Disconnected = true
+	player.rejoining = false
+	player.ShotSync = nil
+	r.stopShotTimer(player)
+	r.startReconnectTimer(ctx, player)
+	return true, r.continueWithout(ctx, player)
+}
+
+// continueWithout moves the game along if it was waiting on a player who is
+// now absent.
+func (r *Room) continueWithout(ctx context.Context, player *RoomPlayer) error {
+	if r.isTourney() || !r.anyPresent() {
+		return nil
+	}
+	switch {
+	case r.state.GamePhase == gamemodel.WaitingLoad:
+		if r.checkGameReady() {
+			return r.startHole(ctx)
+		}
+	case r.state.ShotSync != nil:
+		if r.checkShotSync() {
+			r.completeShotSync(ctx)
+		}
+	case r.checkShouldEndTurn():
+		return r.endTurn(ctx)
+	case r.state.ActiveConnID == player.Entry.ConnID && !player.StartShot:
+		return r.nextTurn(ctx)
+	}
+	return nil
+}
+
+// anyPresent returns true if any player in the room is taking part in the
+// game.
+func (r *Room) anyPresent() bool {
+	for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
+		if !pair.Value.absent() {
+			return true
+		}
+	}
+	return false
+}
+
+// giveUpHole ends the current hole for an absent player who didn't finish
+// it. It counts as unfinished, and scores at least giveUpOverPar strokes
+// over par.
+func (r *Room) giveUpHole(player *RoomPlayer) {
+	hole := r.playerHole(player)
+	if hole == nil {
+		return
+	}
+	if limit := int8(hole.Par) + giveUpOverPar; player.Stroke < limit {
+		player.Stroke = limit
+	}
+	player.Stats.HoleUnfinished++
+	r.finishHole(player)
+}
+
+// startReconnectTimer gives a disconnected player reconnectGracePeriod to
+// come back before they are removed from the room.
+func (r *Room) startReconnectTimer(ctx context.Context, player *RoomPlayer) {
+	r.stopReconnectTimer(player)
+	player.disconnects++
+	connID, disconnect := player.Entry.ConnID, player.disconnects
+	player.reconnectTimer = time.AfterFunc(reconnectGracePeriod, func() {
+		if _, err := r.Send(ctx, RoomPlayerReconnectTimeout{ConnID: connID, Disconnect: disconnect}); err != nil {
+			r.log.Debug().Err(err).Msg("room closed before reconnect timer expired")
+		}
+	})
+}
+
+func (r *Room) stopReconnectTimer(player *RoomPlayer) {
+	if player.reconnectTimer != nil {
+		player.reconnectTimer.Stop()
+		player.reconnectTimer = nil
+	}
+}
+
+func (r *Room) handlePlayerReconnectTimeout(ctx context.Context, event RoomPlayerReconnectTimeout) error {
+	pair := r.players.GetPair(event.ConnID)
+	if pair == nil || !pair.Value.Disconnected || pair.Value.disconnects != event.Disconnect {
+		return nil
+	}
+	pair.Value.reconnectTimer = nil
+	r.log.Info().Uint32("conn id", event.ConnID).Msg("disconnected player did not come back in time")
+	return r.removePlayer(ctx, event.ConnID)
+}
+
+// removeDisconnected removes the players who are still disconnected once
+// the game is over.
+func (r *Room) removeDisconnected(ctx context.Context) {
+	var connIDs []uint32
+	for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
+		if pair.Value.Disconnected {
+			connIDs = append(connIDs, pair.Key)
+		}
+	}
+	for _, connID := range connIDs {
+		if err := r.removePlayer(ctx, connID); err != nil {
+			r.log.Debug().Err(err).Uint32("conn id", connID).Msg("error removing disconnected player")
+		}
+	}
+}
+
+func (r *Room) handlePlayerReconnect(ctx context.Context, event RoomPlayerReconnect) (uint32, error) {
+	var player *RoomPlayer
+	for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
+		if pair.Value.Disconnected && pair.Value.Entry.PlayerID == event.PlayerID {
+			player = &pair.Value
+			break
+		}
+	}
+	if player == nil {
+		return 0, errors.New("no game to reconnect to")
+	}
+
+	r.log.Info().Uint32("conn id", player.Entry.ConnID).Msg("player reconnected to game")
+	r.stopReconnectTimer(player)
+	player.Disconnected = false
+	player.rejoining = true
+	player.GameReady = false
+	player.Conn = event.Conn
+	player.UpdateFunc = event.UpdateFunc
+	return player.Entry.ConnID, r.sendGame(ctx, player)
+}
+
+// sendGame loads a player who reconnected back into the game, from the hole
+// they were on. Once they're ready, resumePlayer catches them up.
+func (r *Room) sendGame(ctx context.Context, player *RoomPlayer) error {
+	firstHole := int(r.state.CurrentHole) - 1
+	if r.isTourney() {
+		firstHole = int(player.Hole) - 1
+	}
+	gameTimerMS := uint32(0)
+	if r.gameTimer != nil {
+		gameTimerMS = uint32(time.Until(r.gameEnd).Milliseconds())
+	}
+	msgs := []gamepacket.ServerMessage{
+		&gamepacket.ServerRoomJoin{
+			RoomName:    r.state.RoomName,
+			RoomNumber:  r.state.RoomNumber,
+			EventNumber: 0,
+		},
+		r.roomStatus(),
+		r.playerList(),
+	}
+	msgs = append(msgs, r.gameInit(), r.gameData(firstHole, gameTimerMS))
+	for _, msg := range msgs {
+		if err := player.Conn.SendMessage(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resumePlayer starts the current hole for a player who reconnected, and
+// tells them whose turn it is.
+func (r *Room) resumePlayer(ctx context.Context, player *RoomPlayer) error {
+	player.rejoining = false
+	if r.isTourney() {
+		return r.startTourneyHole(ctx, player)
+	}
+	for _, msg := range r.holeStart {
+		if err := player.Conn.SendMessage(ctx, msg); err != nil {
+			return err
+		}
+	}
+	// The turn may have moved on since the hole started.
+	return player.Conn.SendMessage(ctx, &gamepacket.ServerRoomActiveUserAnnounce{
+		ConnID: r.state.ActiveConnID,
+	})
+}
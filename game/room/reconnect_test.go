@@ -0,0 +1,89 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package room
+
+import (
+	"testing"
+
+	gamemodel "github.com/pangbox/server/game/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisconnectRejoin(t *testing.T) {
+	r := newTestRoom(t, gamemodel.RoomState{})
+	a := r.join("a")
+	b := r.join("b")
+	r.startGame()
+	playerID := int64(r.player(a).Entry.PlayerID)
+	require.Equal(t, a, r.state.ActiveConnID)
+
+	// The player's place is kept, and their turn is skipped.
+	require.True(t, r.disconnect(a))
+	assert.Same(t, r.Room, r.server.rejoin(playerID))
+	assert.True(t, r.player(a).Disconnected)
+	assert.Equal(t, b, r.state.ActiveConnID)
+
+	// They log back in and load back into the game.
+	promise, err := r.Send(r.ctx, RoomPlayerReconnect{
+		PlayerID:   uint32(playerID),
+		Conn:       r.newConn(),
+		UpdateFunc: func() {},
+	})
+	require.NoError(t, err)
+	connID, err := promise.Wait(r.ctx)
+	require.NoError(t, err)
+	assert.Equal(t, a, connID)
+	assert.False(t, r.player(a).Disconnected)
+	assert.True(t, r.player(a).absent())
+	require.NoError(t, r.send(RoomGameReady{ConnID: a}))
+	assert.False(t, r.player(a).absent())
+	assert.Equal(t, gamemodel.InGame, r.state.GamePhase)
+
+	// If they are gone again when the game ends, the room forgets them.
+	require.True(t, r.disconnect(a))
+	assert.Same(t, r.Room, r.server.rejoin(playerID))
+	require.NoError(t, r.send(RoomGameTimeUp{Game: r.gameNumber}))
+	assert.Nil(t, r.server.rejoin(playerID))
+	assert.Nil(t, r.players.GetPair(a))
+	assert.NotNil(t, r.players.GetPair(b))
+}
+
+func TestDisconnectTimeout(t *testing.T) {
+	r := newTestRoom(t, gamemodel.RoomState{})
+	a := r.join("a")
+	b := r.join("b")
+	r.startGame()
+	playerID := int64(r.player(a).Entry.PlayerID)
+
+	require.True(t, r.disconnect(a))
+	assert.Same(t, r.Room, r.server.rejoin(playerID))
+
+	// A timer left over from an earlier disconnection does nothing.
+	require.NoError(t, r.send(RoomPlayerReconnectTimeout{ConnID: a, Disconnect: r.player(a).disconnects - 1}))
+	assert.NotNil(t, r.players.GetPair(a))
+	assert.Same(t, r.Room, r.server.rejoin(playerID))
+
+	// Once they run out of time, their place in the game is gone, and
+	// logging back in doesn't send them back to the room.
+	require.NoError(t, r.send(RoomPlayerReconnectTimeout{ConnID: a, Disconnect: r.player(a).disconnects}))
+	assert.Nil(t, r.players.GetPair(a))
+	assert.Nil(t, r.server.rejoin(playerID))
+	assert.Equal(t, gamemodel.InGame, r.state.GamePhase)
+	assert.Equal(t, b, r.state.ActiveConnID)
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/pangbox/server/database/accounts"
 	gamemodel "github.com/pangbox/server/game/model"
 	gamepacket "github.com/pangbox/server/game/packet"
+	"github.com/pangbox/server/gameconfig"
 	"github.com/pangbox/server/pangya"
 	"github.com/rs/zerolog"
 	orderedmap "github.com/wk8/go-ordered-map/v2"
@@ -45,6 +46,19 @@ type Room struct {
 	players  *orderedmap.OrderedMap[uint32, RoomPlayer]
 	lobby    *Lobby
 	accounts *accounts.Service
+
+	// gameNumber counts the games played in the room, so that timers can
+	// tell if the game they were started for is still going.
+	gameNumber int
+	gameTimer  *time.Timer
+
+	// gameStart, gameSeed and gameEnd describe the game in progress, and
+	// holeStart are the messages that started the current hole. They are
+	// sent again to players who reconnect.
+	gameStart time.Time
+	gameSeed  uint32
+	gameEnd   time.Time
+	holeStart []gamepacket.ServerMessage
 }
 
 type RoomPlayer struct {
@@ -65,6 +79,50 @@ type RoomPlayer struct {
 	Score      int32
 	TurnOrder  int
 	Distance   float64
+	Stats      pangya.PlayerStats
+
+	// HolesWon is the number of holes the player has won in match play.
+	HolesWon int
+
+	// Hole is the hole the player is playing in a tournament (1-based).
+	Hole uint8
+
+	// The card rates are the percentages the player's equipped cards add to
+	// the Pang and EXP earned in a game. They are loaded when a game starts.
+	CardPangRate uint32
+	CardExpRate  uint32
+
+	// shotTimer ends the player's turn if they take too long, and shotTurn
+	// tells apart the turns it was started for.
+	shotTimer *time.Timer
+	shotTurn  int
+
+	// timeouts counts the turns in a row the player let time out on the
+	// current hole, and passed is set when they let their last turn time
+	// out, until someone else has taken a turn.
+	timeouts int
+	passed   bool
+
+	// Disconnected is set while the player's place in a game is kept for
+	// them after they lost their connection. reconnectTimer gives up on
+	// them, and disconnects tells apart the disconnections it was started
+	// for. rejoining is set while a player who reconnected loads back in.
+	Disconnected   bool
+	reconnectTimer *time.Timer
+	disconnects    int
+	rejoining      bool
+
+	// Spectator is set for players who joined the room while a game was in
+	// progress. They wait it out without turns or timers, and play from
+	// the next game.
+	Spectator bool
+}
+
+// absent returns true for players who can't currently take part in the
+// game, because they are disconnected, still loading back into it, or only
+// spectating.
+func (p *RoomPlayer) absent() bool {
+	return p.Disconnected || p.rejoining || p.Spectator
 }
 
 func (r *Room) Start(ctx context.Context, state gamemodel.RoomState, lobby *Lobby, accounts *accounts.Service) bool {
@@ -83,6 +141,7 @@ func (r *Room) Start(ctx context.Context, state gamemodel.RoomState, lobby *Lobb
 		r.state.HoleProgression = state.HoleProgression
 		r.state.NaturalWind = state.NaturalWind
 		r.state.GamePhase = gamemodel.LobbyPhase
+		r.applyRoomTypeLimits()
 		r.players = orderedmap.New[uint32, RoomPlayer]()
 		r.lobby = lobby
 		r.accounts = accounts
@@ -110,11 +169,24 @@ func (r *Room) GetRoomInfo(ctx context.Context) (gamemodel.RoomInfo, error) {
 }
 
 func (r *Room) broadcast(ctx context.Context, msg gamepacket.ServerMessage) error {
+	var data []byte
 	group, ctx := errgroup.WithContext(ctx)
 	for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
 		player := pair.Value
+		// Spectators still hear about the game.
+		if player.Disconnected || player.rejoining {
+			continue
+		}
+		// The message is packed once, and every player is sent the same
+		// packet.
+		if data == nil {
+			var err error
+			if data, err = player.Conn.PackMessage(msg); err != nil {
+				return err
+			}
+		}
 		group.Go(func() error {
-			return player.Conn.SendMessage(ctx, msg)
+			return player.Conn.SendPacket(ctx, data)
 		})
 	}
 	return group.Wait()
@@ -140,6 +212,12 @@ func (r *Room) getRoomPlayerList() []gamemodel.RoomPlayerEntry {
 
 func (r *Room) task(ctx context.Context, t *actor.Task[RoomEvent]) error {
 	defer func() {
+		r.stopTimers()
+		for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
+			if pair.Value.Disconnected {
+				r.lobby.server.RemoveRejoin(int64(pair.Value.Entry.PlayerID), r)
+			}
+		}
 		r.state.Active = false
 		r.lobby.Send(ctx, LobbyRoomRemove{
 			Room: r.state,
@@ -190,6 +268,27 @@ func (r *Room) handleEvent(ctx context.Context, t *actor.Task[RoomEvent], msg ac
 	case RoomPlayerLeave:
 		return rejectOnError(r.handlePlayerLeave(ctx, event))
 
+	case RoomPlayerDisconnect:
+		kept, err := r.handlePlayerDisconnect(ctx, event)
+		if err != nil {
+			msg.Promise.Reject(err)
+		} else {
+			msg.Promise.Resolve(kept)
+		}
+		return nil
+
+	case RoomPlayerReconnect:
+		connID, err := r.handlePlayerReconnect(ctx, event)
+		if err != nil {
+			msg.Promise.Reject(err)
+		} else {
+			msg.Promise.Resolve(connID)
+		}
+		return nil
+
+	case RoomPlayerReconnectTimeout:
+		return rejectOnError(r.handlePlayerReconnectTimeout(ctx, event))
+
 	case RoomPlayerUpdateData:
 		return rejectOnError(r.handlePlayerUpdateData(ctx, event))
 
@@ -253,6 +352,12 @@ func (r *Room) handleEvent(ctx context.Context, t *actor.Task[RoomEvent], msg ac
 	case RoomGameHoleInfo:
 		return rejectOnError(r.handleRoomGameHoleInfo(ctx, event))
 
+	case RoomShotTimeUp:
+		return rejectOnError(r.handleRoomShotTimeUp(ctx, event))
+
+	case RoomGameTimeUp:
+		return rejectOnError(r.handleRoomGameTimeUp(ctx, event))
+
 	case ChatMessage:
 		return rejectOnError(r.handleChatMessage(ctx, event))
 
@@ -299,6 +404,7 @@ func (r *Room) handlePlayerJoin(ctx context.Context, event RoomPlayerJoin) error
 		Conn:       event.Conn,
 		PlayerData: event.PlayerData,
 		UpdateFunc: event.UpdateFunc,
+		Spectator:  r.state.GamePhase != gamemodel.LobbyPhase,
 	})
 	if present {
 		return errors.New("already in room")
@@ -440,14 +546,20 @@ func (r *Room) handleRoomSettingsChange(ctx context.Context, event RoomSettingsC
 			r.state.NaturalWind = *change.NaturalWind
 		}
 	}
+	r.applyRoomTypeLimits()
 
 	r.stateUpdated(ctx)
 	return nil
 }
 
 func (r *Room) handleRoomStartGame(ctx context.Context, event RoomStartGame) error {
+	if err := r.checkCanStart(); err != nil {
+		return err
+	}
+
 	r.state.Open = false
 	r.state.GamePhase = gamemodel.WaitingLoad
+	r.gameNumber++
 	r.stateUpdated(ctx)
 
 	// Pick hole numbers to play.
@@ -485,14 +597,8 @@ func (r *Room) handleRoomStartGame(ctx context.Context, event RoomStartGame) err
 	r.broadcast(ctx, &gamepacket.Server0077{Unknown: 0x64})
 
 	// Send game init packet.
-	now := time.Now()
-	gameInit := &gamepacket.ServerGameInit{
-		SubType: gamepacket.GameInitTypeFull,
-		Full: &gamepacket.GameInitFull{
-			NumPlayers: byte(r.players.Len()),
-			Players:    make([]gamepacket.GamePlayer, r.players.Len()),
-		},
-	}
+	r.gameStart = time.Now()
+	r.gameSeed = rand.Uint32()
 	r.state.StartPlayers = r.players.Len()
 	for i, pair := 0, r.players.Oldest(); pair != nil; pair = pair.Next() {
 		// Clear ready status.
@@ -500,39 +606,70 @@ func (r *Room) handleRoomStartGame(ctx context.Context, event RoomStartGame) err
 
 		// Set initial player state.
 		pair.Value.TurnOrder = i
+		pair.Value.GameReady = false
 		pair.Value.Distance = math.Inf(1)
 		pair.Value.Stroke = 0
 		pair.Value.LastTotal = 0
+		pair.Value.timeouts = 0
+		pair.Value.passed = false
 		pair.Value.TurnEnd = false
 		pair.Value.HoleEnd = false
 		pair.Value.GameEnd = false
-
-		player := pair.Value
-		gameInit.Full.Players[i] = gamepacket.GamePlayer{
-			Number:     uint16(i + 1),
-			PlayerData: player.PlayerData,
-			StartTime:  pangya.NewSystemTime(now),
-			NumCards:   0,
-		}
+		pair.Value.HolesWon = 0
+		pair.Value.Hole = 1
+		r.loadCards(ctx, &pair.Value)
 
 		i++
 	}
-	r.broadcast(ctx, gameInit)
+	r.broadcast(ctx, r.gameInit())
 
 	// Send room game data packet.
+	r.broadcast(ctx, r.gameData(0, r.state.GameTimerMS))
+
+	// TODO
+	r.broadcast(ctx, &gamepacket.Server016A{Unknown: 1, Unknown2: 0x24bd})
+
+	r.startGameTimer(ctx)
+
+	return nil
+}
+
+// gameInit returns the message that tells clients who is playing the game.
+func (r *Room) gameInit() *gamepacket.ServerGameInit {
+	gameInit := &gamepacket.ServerGameInit{
+		SubType: gamepacket.GameInitTypeFull,
+		Full:    &gamepacket.GameInitFull{},
+	}
+	for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
+		player := pair.Value
+		if player.Spectator {
+			continue
+		}
+		gameInit.Full.Players = append(gameInit.Full.Players, gamepacket.GamePlayer{
+			Number:     uint16(len(gameInit.Full.Players) + 1),
+			PlayerData: player.PlayerData,
+			StartTime:  pangya.NewSystemTime(r.gameStart),
+		})
+	}
+	gameInit.Full.NumPlayers = byte(len(gameInit.Full.Players))
+	return gameInit
+}
+
+// gameData returns the message that tells clients which holes to play,
+// starting from the given hole (0-based), and how much time is left.
+func (r *Room) gameData(firstHole int, gameTimerMS uint32) *gamepacket.ServerRoomGameData {
 	gameData := &gamepacket.ServerRoomGameData{
 		Course:          r.state.Course,
 		Unknown:         0x0,
 		HoleProgression: r.state.HoleProgression,
-		NumHoles:        r.state.NumHoles,
+		NumHoles:        r.state.NumHoles - uint8(firstHole),
 		Unknown2:        0x0,
 		ShotTimerMS:     r.state.ShotTimerMS,
-		GameTimerMS:     r.state.GameTimerMS,
-		RandomSeed:      rand.Uint32(),
+		GameTimerMS:     gameTimerMS,
+		RandomSeed:      r.gameSeed,
 	}
 	// Copy hole data from state.
-	for i := uint8(0); i < r.state.NumHoles; i++ {
-		stateHole := r.state.Holes[i]
+	for i, stateHole := range r.state.Holes[firstHole:] {
 		gameData.Holes[i] = gamepacket.HoleInfo{
 			HoleID:  stateHole.HoleID,
 			HoleNum: stateHole.HoleNum,
@@ -540,12 +677,7 @@ func (r *Room) handleRoomStartGame(ctx context.Context, event RoomStartGame) err
 			Course:  stateHole.Course,
 		}
 	}
-	r.broadcast(ctx, gameData)
-
-	// TODO
-	r.broadcast(ctx, &gamepacket.Server016A{Unknown: 1, Unknown2: 0x24bd})
-
-	return nil
+	return gameData
 }
 
 func (r *Room) handleRoomLoadingProgress(ctx context.Context, event RoomLoadingProgress) error {
@@ -556,17 +688,50 @@ func (r *Room) handleRoomLoadingProgress(ctx context.Context, event RoomLoadingP
 }
 
 func (r *Room) handleRoomGameReady(ctx context.Context, event RoomGameReady) error {
-	if pair := r.players.GetPair(event.ConnID); pair != nil {
-		pair.Value.GameReady = true
+	// A player may finish loading after the game was already over.
+	if r.state.GamePhase == gamemodel.LobbyPhase {
+		return nil
+	}
+	pair := r.players.GetPair(event.ConnID)
+	if pair == nil || pair.Value.Spectator {
+		return nil
+	}
+	pair.Value.GameReady = true
+	if pair.Value.rejoining {
+		return r.resumePlayer(ctx, &pair.Value)
 	}
-	if r.checkGameReady() {
+	if r.isTourney() {
+		return r.startTourneyHole(ctx, &pair.Value)
+	}
+	if r.state.GamePhase == gamemodel.WaitingLoad && r.checkGameReady() {
 		r.startHole(ctx)
 	}
 	return nil
 }
 
+// gamePlayer returns the player with the given connection ID if a game is
+// being played and they are playing in it, or nil otherwise. Events about
+// a game that come in after it ended, such as a shot sync that arrives
+// after the game timer ran out, are dropped.
+func (r *Room) gamePlayer(connID uint32) *RoomPlayer {
+	if r.state.GamePhase != gamemodel.InGame {
+		return nil
+	}
+	pair := r.players.GetPair(connID)
+	if pair == nil || pair.Value.Spectator {
+		return nil
+	}
+	return &pair.Value
+}
+
 func (r *Room) handleRoomGameShotCommit(ctx context.Context, event RoomGameShotCommit) error {
-	return r.broadcast(ctx, &gamepacket.ServerRoomShotAnnounce{
+	player := r.gamePlayer(event.ConnID)
+	if player == nil {
+		return nil
+	}
+	player.StartShot = true
+	r.stopShotTimer(player)
+	return r.broadcastShot(ctx, event.ConnID, &gamepacket.ServerRoomShotAnnounce{
 		ConnID:           event.ConnID,
 		ShotStrength:     event.ShotStrength,
 		ShotAccuracy:     event.ShotAccuracy,
@@ -578,28 +743,41 @@ func (r *Room) handleRoomGameShotCommit(ctx context.Context, event RoomGameShotC
 }
 
 func (r *Room) handleRoomGameShotRotate(ctx context.Context, event RoomGameShotRotate) error {
-	return r.broadcast(ctx, &gamepacket.ServerRoomShotRotateAnnounce{
+	if r.gamePlayer(event.ConnID) == nil {
+		return nil
+	}
+	return r.broadcastShot(ctx, event.ConnID, &gamepacket.ServerRoomShotRotateAnnounce{
 		ConnID: event.ConnID,
 		Angle:  event.Angle,
 	})
 }
 
 func (r *Room) handleRoomGameShotPower(ctx context.Context, event RoomGameShotPower) error {
-	return r.broadcast(ctx, &gamepacket.ServerRoomShotPowerAnnounce{
+	if r.gamePlayer(event.ConnID) == nil {
+		return nil
+	}
+	return r.broadcastShot(ctx, event.ConnID, &gamepacket.ServerRoomShotPowerAnnounce{
 		ConnID: event.ConnID,
 		Level:  event.Level,
 	})
 }
 
 func (r *Room) handleRoomGameShotClubChange(ctx context.Context, event RoomGameShotClubChange) error {
-	return r.broadcast(ctx, &gamepacket.ServerRoomClubChangeAnnounce{
+	player := r.gamePlayer(event.ConnID)
+	if player == nil {
+		return nil
+	}
+	return r.broadcastShot(ctx, event.ConnID, &gamepacket.ServerRoomClubChangeAnnounce{
 		ConnID: event.ConnID,
 		Club:   event.Club,
 	})
 }
 
 func (r *Room) handleRoomGameShotItemUse(ctx context.Context, event RoomGameShotItemUse) error {
-	return r.broadcast(ctx, &gamepacket.ServerRoomItemUseAnnounce{
+	if r.gamePlayer(event.ConnID) == nil {
+		return nil
+	}
+	return r.broadcastShot(ctx, event.ConnID, &gamepacket.ServerRoomItemUseAnnounce{
 		ConnID:     event.ConnID,
 		ItemTypeID: event.ItemTypeID,
 	})
@@ -613,7 +791,10 @@ func (r *Room) handleRoomGameTypingIndicator(ctx context.Context, event RoomGame
 }
 
 func (r *Room) handleRoomGameShotCometRelief(ctx context.Context, event RoomGameShotCometRelief) error {
-	return r.broadcast(ctx, &gamepacket.ServerRoomShotCometReliefAnnounce{
+	if r.gamePlayer(event.ConnID) == nil {
+		return nil
+	}
+	return r.broadcastShot(ctx, event.ConnID, &gamepacket.ServerRoomShotCometReliefAnnounce{
 		ConnID: event.ConnID,
 		X:      event.X,
 		Y:      event.Y,
@@ -626,8 +807,13 @@ func (r *Room) handleRoomGameTurn(ctx context.Context, event RoomGameTurn) error
 }
 
 func (r *Room) handleRoomGameTurnEnd(ctx context.Context, event RoomGameTurnEnd) error {
-	if pair := r.players.GetPair(event.ConnID); pair != nil {
-		pair.Value.TurnEnd = true
+	player := r.gamePlayer(event.ConnID)
+	if player == nil {
+		return nil
+	}
+	player.TurnEnd = true
+	if r.isTourney() {
+		return r.endTourneyTurn(ctx, player)
 	}
 	if r.checkShouldEndTurn() {
 		return r.endTurn(ctx)
@@ -636,16 +822,59 @@ func (r *Room) handleRoomGameTurnEnd(ctx context.Context, event RoomGameTurnEnd)
 }
 
 func (r *Room) handleRoomGameHoleEnd(ctx context.Context, event RoomGameHoleEnd) error {
-	if pair := r.players.GetPair(event.ConnID); pair != nil {
-		pair.Value.HoleEnd = true
-		pair.Value.Score += int32(pair.Value.Stroke) - int32(r.currentHole().Par)
-		pair.Value.LastTotal = pair.Value.Stroke
-		pair.Value.Stroke = 0
+	player := r.gamePlayer(event.ConnID)
+	if player == nil {
+		return nil
+	}
+	hole := r.playerHole(player)
+	if hole == nil {
+		return errors.New("no hole in play")
 	}
+	if player.HoleEnd {
+		// The hole was already given up for the player while they were
+		// away, and counted as unfinished.
+		event.Stats.HoleUnfinished = 0
+		player.addHoleStats(event.Stats, hole.Par)
+		return nil
+	}
+	player.addHoleStats(event.Stats, hole.Par)
+	r.recordQuestProgress(ctx, player, gameconfig.QuestObjectiveHoles, 1)
+	r.recordQuestProgress(ctx, player, gameconfig.QuestObjectivePangyaHits, event.Stats.PangyaHits)
+	strokes := player.Stroke
+	if strokes == 1 {
+		r.recordAchievementEvent(ctx, player, gameconfig.AchievementEventHoleInOne)
+		r.recordQuestProgress(ctx, player, gameconfig.QuestObjectiveHoleInOne, 1)
+	} else if strokes > 1 && event.Stats.TotalPutts == 0 && event.Stats.HoleUnfinished == 0 {
+		// Holed out without putting.
+		r.recordAchievementEvent(ctx, player, gameconfig.AchievementEventChipIn)
+	}
+	r.finishHole(player)
 	return nil
 }
 
+// finishHole scores the player's strokes on the current hole and marks them
+// as done with it.
+func (r *Room) finishHole(player *RoomPlayer) {
+	hole := r.playerHole(player)
+	if hole == nil {
+		return
+	}
+	player.HoleEnd = true
+	player.Score += int32(player.Stroke) - int32(hole.Par)
+	player.LastTotal = player.Stroke
+	player.Stroke = 0
+	player.timeouts = 0
+	player.passed = false
+}
+
 func (r *Room) handleRoomGameShotSync(ctx context.Context, event RoomGameShotSync) error {
+	player := r.gamePlayer(event.ConnID)
+	if player == nil {
+		return nil
+	}
+	if r.isTourney() {
+		return r.handleTourneyShotSync(ctx, player, event)
+	}
 	syncData := event.Data
 	if r.state.ShotSync == nil {
 		r.state.ShotSync = &syncData
@@ -655,37 +884,56 @@ func (r *Room) handleRoomGameShotSync(ctx context.Context, event RoomGameShotSyn
 			r.log.Warn().Msgf("Shot sync mismatch: %#v vs %#v", r.state.ShotSync, syncData)
 		}
 	}
-	if pair := r.players.GetPair(event.ConnID); pair != nil {
-		pair.Value.ShotSync = r.state.ShotSync
-	}
+	player.ShotSync = r.state.ShotSync
 	if r.checkShotSync() {
-		r.broadcast(ctx, &gamepacket.ServerRoomShotSync{
-			Data: *r.state.ShotSync,
-		})
-		if pair := r.players.GetPair(r.state.ShotSync.ActiveConnID); pair != nil {
-			pair.Value.StartShot = true
-			pair.Value.Pang = uint64(r.state.ShotSync.Pang)
-			pair.Value.BonusPang = uint64(r.state.ShotSync.BonusPang)
+		r.completeShotSync(ctx)
+	}
+	return nil
+}
 
-			// TODO: Sometimes we need to increment twice, need to compare packets
-			pair.Value.Stroke++
+// completeShotSync announces a shot once every player has synced it.
+func (r *Room) completeShotSync(ctx context.Context) {
+	r.broadcast(ctx, &gamepacket.ServerRoomShotSync{
+		Data: *r.state.ShotSync,
+	})
+	if pair := r.players.GetPair(r.state.ShotSync.ActiveConnID); pair != nil {
+		r.applyShot(&pair.Value, *r.state.ShotSync)
+	} else {
+		r.log.Warn().Uint32("active connection id", r.state.ShotSync.ActiveConnID).Msg("couldn't find connection")
+	}
+	for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
+		pair.Value.ShotSync = nil
+	}
+	r.state.ShotSync = nil
+}
 
-			dx := float64(r.currentHole().PinX) - float64(r.state.ShotSync.X)
-			dy := float64(r.currentHole().PinZ) - float64(r.state.ShotSync.Z)
-			pair.Value.Distance = math.Sqrt(dx*dx + dy*dy)
-		} else {
-			r.log.Warn().Uint32("active connection id", r.state.ShotSync.ActiveConnID).Msg("couldn't find connection")
-		}
-		for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
-			pair.Value.ShotSync = nil
-		}
-		r.state.ShotSync = nil
+// applyShot counts a stroke for the player once their shot is synced.
+func (r *Room) applyShot(player *RoomPlayer, data gamemodel.ShotSyncData) {
+	player.StartShot = true
+	player.timeouts = 0
+	player.Pang = uint64(data.Pang)
+	player.BonusPang = uint64(data.BonusPang)
+
+	// TODO: Sometimes we need to increment twice, need to compare packets
+	player.Stroke++
+	player.Stats.TotalStrokes++
+
+	if hole := r.playerHole(player); hole != nil {
+		dx := float64(hole.PinX) - float64(data.X)
+		dy := float64(hole.PinZ) - float64(data.Z)
+		player.Distance = math.Sqrt(dx*dx + dy*dy)
 	}
-	return nil
 }
 
 func (r *Room) handleRoomGameHoleInfo(ctx context.Context, event RoomGameHoleInfo) error {
-	hole := r.currentHole()
+	player := r.gamePlayer(event.ConnID)
+	if player == nil {
+		return nil
+	}
+	hole := r.playerHole(player)
+	if hole == nil {
+		return errors.New("no hole in play")
+	}
 
 	// TODO: It'd probably be better to not rely on the client for this if possible.
 	hole.Par = event.Par
@@ -715,6 +963,9 @@ func (r *Room) endTurn(ctx context.Context) error {
 	}
 	for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
 		pair.Value.TurnEnd = false
+		if pair.Key != r.state.ActiveConnID {
+			pair.Value.passed = false
+		}
 	}
 	return r.nextTurn(ctx)
 }
@@ -722,12 +973,14 @@ func (r *Room) endTurn(ctx context.Context) error {
 func (r *Room) nextTurn(ctx context.Context) error {
 	if pair := r.players.GetPair(r.state.ActiveConnID); pair != nil {
 		pair.Value.StartShot = false
+		r.stopShotTimer(&pair.Value)
 	}
 	nextPlayer := r.getNextPlayer()
 	if nextPlayer == nil {
 		return r.endHole(ctx)
 	}
 	r.state.ActiveConnID = nextPlayer.Entry.ConnID
+	r.startShotTimer(ctx, nextPlayer)
 	r.broadcast(ctx, &gamepacket.ServerRoomActiveUserAnnounce{
 		ConnID: r.state.ActiveConnID,
 	})
@@ -741,11 +994,22 @@ func (r *Room) getNextPlayer() *RoomPlayer {
 		if pair.Value.HoleEnd {
 			continue
 		}
+		// Skip the turns of players who aren't here to take them.
+		if pair.Value.absent() {
+			continue
+		}
 		// If we don't have a candidate yet, then use the first player we see.
 		if nextPlayer == nil {
 			nextPlayer = &pair.Value
 			continue
 		}
+		// Players who let their turn time out go after everyone else.
+		if pair.Value.passed != nextPlayer.passed {
+			if !pair.Value.passed {
+				nextPlayer = &pair.Value
+			}
+			continue
+		}
 		// Prefer players further away.
 		if pair.Value.Distance > nextPlayer.Distance {
 			nextPlayer = &pair.Value
@@ -765,6 +1029,17 @@ func (r *Room) getNextPlayer() *RoomPlayer {
 }
 
 func (r *Room) endHole(ctx context.Context) error {
+	for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
+		if pair.Value.absent() && !pair.Value.Spectator && !pair.Value.HoleEnd {
+			r.giveUpHole(&pair.Value)
+		}
+	}
+	if r.isMatchPlay() {
+		r.scoreMatchHole()
+		if r.matchDecided() {
+			return r.endGame(ctx)
+		}
+	}
 	if r.haveNextHole() {
 		r.advanceHole()
 		r.broadcast(ctx, &gamepacket.ServerRoomFinishHole{})
@@ -801,27 +1076,48 @@ func (r *Room) setupNextTurnOrder() {
 }
 
 func (r *Room) endGame(ctx context.Context) error {
-	if r.players.Len() == 0 {
+	r.stopTimers()
+	defer r.returnToLobby(ctx)
+	numPlaying := 0
+	for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
+		if !pair.Value.Spectator {
+			numPlaying++
+		}
+	}
+	if numPlaying == 0 {
 		return nil
 	}
+	var tourneyPlaces map[uint32]uint8
+	if r.isTourney() {
+		tourneyPlaces = r.tourneyPlaces()
+	}
 	results := &gamepacket.ServerRoomFinishGame{
-		NumPlayers: uint8(r.players.Len()),
-		Standings:  make([]gamepacket.PlayerGameResult, r.players.Len()),
+		NumPlayers: uint8(numPlaying),
+		Standings:  make([]gamepacket.PlayerGameResult, numPlaying),
 	}
+	season := r.lobby.configProvider.GetSeason(time.Now())
 	for i, pair := 0, r.players.Oldest(); pair != nil; pair = pair.Next() {
+		if pair.Value.Spectator {
+			continue
+		}
 		clearBonus := r.lobby.configProvider.GetCourseBonus(r.state.Course, r.state.StartPlayers, int(r.state.NumHoles))
 		exp := int(clearBonus / 2) // TODO: it should be based on course difficulty I believe.
 		bonusPang := pair.Value.BonusPang
 		bonusPang += clearBonus
+		bonusPang += (pair.Value.Pang + bonusPang) * uint64(pair.Value.CardPangRate) / 100
+		exp += exp * int(pair.Value.CardExpRate) / 100
 		results.Standings[i].ConnID = pair.Value.Entry.ConnID
 		results.Standings[i].Pang = pair.Value.Pang
 		results.Standings[i].Score = int8(pair.Value.Score)
+		if r.isMatchPlay() {
+			results.Standings[i].Score = int8(r.matchMargin(&pair.Value))
+		}
 		results.Standings[i].BonusPang = bonusPang
 		results.Standings[i].Exp = uint16(exp)
 
 		totalPang := bonusPang + pair.Value.Pang
 
-		newPang, err := r.accounts.AddPang(ctx, int64(pair.Value.Entry.PlayerID), int64(totalPang))
+		newPang, err := r.accounts.AddPang(ctx, int64(pair.Value.Entry.PlayerID), int64(totalPang), accounts.CurrencyReasonGameReward)
 		if err != nil {
 			r.log.Error().Err(err).Msg("failed giving game-ending pang")
 		}
@@ -831,44 +1127,99 @@ func (r *Room) endGame(ctx context.Context) error {
 			r.log.Error().Err(err).Msg("failed giving game-ending exp")
 		}
 
+		_, _, err = r.accounts.AddCaddieExp(ctx, int64(pair.Value.Entry.PlayerID), exp)
+		if err != nil && !errors.Is(err, accounts.ErrNoCaddie) {
+			r.log.Error().Err(err).Msg("failed giving game-ending caddie exp")
+		}
+
 		if err := pair.Value.Conn.SendMessage(ctx, &gamepacket.ServerPangBalanceData{PangsRemaining: uint64(newPang)}); err != nil {
 			r.log.Error().Err(err).Msg("failed informing player of game-ending pang")
 		}
 
+		if _, err := r.accounts.AddCourseResult(ctx, int64(pair.Value.Entry.PlayerID), accounts.CourseResult{
+			Season:     season,
+			CourseID:   r.state.Course,
+			CharTypeID: pair.Value.PlayerData.EquippedCharacter.CharTypeID,
+			Score:      pair.Value.Score,
+			Pang:       totalPang,
+			Strokes:    pair.Value.Stats.TotalStrokes,
+			Putts:      pair.Value.Stats.TotalPutts,
+			Holes:      pair.Value.Stats.TotalHoles,
+		}); err != nil {
+			r.log.Error().Err(err).Msg("failed saving course record")
+		}
+
+		if r.isTourney() {
+			results.Standings[i].Place = tourneyPlaces[pair.Value.Entry.ConnID]
+			r.mailTourneyPrize(ctx, &pair.Value, results.Standings[i].Place)
+		}
+
+		r.recordAchievementEvent(ctx, &pair.Value, gameconfig.AchievementEventGamePlayed)
+		r.recordQuestProgress(ctx, &pair.Value, gameconfig.QuestObjectiveGames, 1)
+
+		pair.Value.Stats.TotalScore = pair.Value.Score
+		pair.Value.Stats.BestPangTotal = totalPang
+		pair.Value.Stats.GamesPlayed = 1
+		if _, err := r.accounts.AddPlayerStats(ctx, int64(pair.Value.Entry.PlayerID), pair.Value.Stats); err != nil {
+			r.log.Error().Err(err).Msg("failed saving game statistics")
+		}
+
 		// Tell conn to update player so that it sees new EXP/etc.
 		pair.Value.UpdateFunc()
 
 		pair.Value.Score = 0
 		pair.Value.Pang = 0
 		pair.Value.BonusPang = 0
+		pair.Value.Stats = pangya.PlayerStats{}
 		pair.Value.HoleEnd = false
 		pair.Value.ShotSync = nil
+		pair.Value.rejoining = false
 
 		i++
 	}
-	slices.SortFunc(results.Standings, func(a, b gamepacket.PlayerGameResult) bool {
-		return a.Score < b.Score
-	})
-	results.Standings[0].Place = 1
-	for i := 1; i < len(results.Standings); i++ {
-		if results.Standings[i-1].Score == results.Standings[i].Score {
-			// If tie: use placement of tied player(s)
-			results.Standings[i].Place = results.Standings[i-1].Place
-		} else {
-			// If not tie: use position in standing as placement
-			results.Standings[i].Place = uint8(i + 1)
+	if r.isMatchPlay() {
+		sortMatchStandings(results.Standings)
+	} else {
+		slices.SortFunc(results.Standings, func(a, b gamepacket.PlayerGameResult) bool {
+			return a.Score < b.Score
+		})
+	}
+	if r.isTourney() {
+		slices.SortStableFunc(results.Standings, func(a, b gamepacket.PlayerGameResult) bool {
+			return a.Place < b.Place
+		})
+	} else {
+		results.Standings[0].Place = 1
+		for i := 1; i < len(results.Standings); i++ {
+			if results.Standings[i-1].Score == results.Standings[i].Score {
+				// If tie: use placement of tied player(s)
+				results.Standings[i].Place = results.Standings[i-1].Place
+			} else {
+				// If not tie: use position in standing as placement
+				results.Standings[i].Place = uint8(i + 1)
+			}
 		}
 	}
 	r.broadcast(ctx, results)
+	return nil
+}
+
+// returnToLobby puts the room back into the lobby phase once a game is over.
+// Spectators get to play in the next game.
+func (r *Room) returnToLobby(ctx context.Context) {
+	for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
+		pair.Value.Spectator = false
+	}
 	r.state.Open = true
 	r.state.CurrentHole = 0
+	r.state.ShotSync = nil
 	r.state.GamePhase = gamemodel.LobbyPhase
-	return nil
+	r.removeDisconnected(ctx)
 }
 
 func (r *Room) checkGameReady() bool {
 	for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
-		if !pair.Value.GameReady {
+		if !pair.Value.GameReady && !pair.Value.absent() {
 			return false
 		}
 	}
@@ -877,7 +1228,7 @@ func (r *Room) checkGameReady() bool {
 
 func (r *Room) checkShotSync() bool {
 	for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
-		if pair.Value.ShotSync == nil {
+		if pair.Value.ShotSync == nil && !pair.Value.absent() {
 			return false
 		}
 	}
@@ -886,7 +1237,7 @@ func (r *Room) checkShotSync() bool {
 
 func (r *Room) checkShouldEndTurn() bool {
 	for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
-		if !pair.Value.TurnEnd {
+		if !pair.Value.TurnEnd && !pair.Value.absent() {
 			return false
 		}
 	}
@@ -895,60 +1246,71 @@ func (r *Room) checkShouldEndTurn() bool {
 
 func (r *Room) startHole(ctx context.Context) error {
 	r.state.GamePhase = gamemodel.InGame
-	r.broadcast(ctx, &gamepacket.ServerRoomSetWeather{
-		Weather: 0,
-	})
-	wind := rand.Intn(8) + 1
-	r.broadcast(ctx, &gamepacket.ServerRoomSetWind{
-		Wind:    uint8(wind),
-		Unknown: 0,
-		Heading: uint16(rand.Intn(256)),
-		Reset:   true,
-	})
 	nextPlayer := r.getNextPlayer()
 	if nextPlayer == nil {
 		r.log.Error().Msg("nextPlayer == nil in startHole?")
 		return nil
 	}
 	r.state.ActiveConnID = nextPlayer.Entry.ConnID
-	r.broadcast(ctx, &gamepacket.ServerRoomStartHole{
-		ConnID: r.state.ActiveConnID,
-	})
-	// TODO: These blobs are taken from an old packet dump. Not exactly sure what they are for.
-	r.broadcast(ctx, &gamepacket.Server0151{Unknown: []byte{
-		0x0d, 0x00, 0x57, 0x5f, 0x42, 0x49, 0x47, 0x42, 0x4f, 0x4e, 0x47, 0x44, 0x41, 0x52, 0x49, 0x00,
-		0x03, 0x01, 0x03, 0x02, 0x03, 0x03, 0x02, 0x00, 0x02, 0x02, 0x02, 0x03, 0x01, 0x01, 0x00, 0x01,
-		0x00, 0x03, 0x02, 0x00, 0x00, 0x00, 0x02, 0x03, 0x03, 0x00, 0x01, 0x01, 0x03, 0x00, 0x02, 0x03,
-		0x01, 0x03, 0x03, 0x01, 0x02, 0x00, 0x03, 0x00, 0x02, 0x00, 0x00, 0x02, 0x00, 0x03, 0x03, 0x03,
-		0x02, 0x02, 0x02, 0x03, 0x01, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x02, 0x01, 0x01, 0x00, 0x03,
-		0x00, 0x01, 0x00, 0x03, 0x03, 0x03, 0x02, 0x00, 0x01, 0x01, 0x02, 0x03, 0x03, 0x01, 0x02, 0x00,
-		0x00, 0x02, 0x03, 0x02, 0x00, 0x00, 0x03, 0x02, 0x03, 0x00, 0x03, 0x00, 0x03, 0x02, 0x03, 0x02,
-		0x03, 0x00, 0x03,
-	}})
-	r.broadcast(ctx, &gamepacket.Server0151{Unknown: []byte{
-		0x0d, 0x00, 0x52, 0x5f, 0x42, 0x49, 0x47, 0x42, 0x4f, 0x4e, 0x47, 0x44, 0x41, 0x52, 0x49, 0x01,
-		0x02, 0x00, 0x00, 0x01, 0x03, 0x01, 0x00, 0x01, 0x02, 0x02, 0x02, 0x03, 0x03, 0x02, 0x02, 0x01,
-		0x01, 0x03, 0x03, 0x00, 0x02, 0x02, 0x02, 0x03, 0x01, 0x02, 0x02, 0x03, 0x00, 0x00, 0x00, 0x02,
-		0x03, 0x03, 0x01, 0x02, 0x01, 0x03, 0x01, 0x00, 0x01, 0x00, 0x02, 0x00, 0x00, 0x00, 0x02, 0x03,
-		0x01, 0x00, 0x02, 0x02, 0x00, 0x02, 0x03, 0x00, 0x03, 0x03, 0x01, 0x03, 0x02, 0x01, 0x02, 0x03,
-		0x03, 0x03, 0x00, 0x00, 0x01, 0x00, 0x02, 0x00, 0x00, 0x01, 0x03, 0x00, 0x01, 0x02, 0x00, 0x00,
-		0x02, 0x02, 0x03, 0x00, 0x01, 0x02, 0x01, 0x02, 0x01, 0x03, 0x02, 0x01, 0x01, 0x03, 0x01, 0x02,
-		0x00, 0x02, 0x01,
-	}})
-	r.broadcast(ctx, &gamepacket.Server0151{Unknown: []byte{
-		0x0f, 0x00, 0x43, 0x4c, 0x55, 0x42, 0x53, 0x45, 0x54, 0x5f, 0x4d, 0x49, 0x52, 0x41, 0x43, 0x4c,
-		0x45, 0x01, 0x01, 0x01, 0x02, 0x02, 0x00, 0x02, 0x01, 0x02, 0x03, 0x01, 0x03, 0x00, 0x02, 0x02,
-		0x03, 0x03, 0x01, 0x01, 0x02, 0x02, 0x00, 0x03, 0x02, 0x01, 0x01, 0x01, 0x03, 0x01, 0x00, 0x02,
-		0x01, 0x03, 0x03, 0x03, 0x02, 0x01, 0x03, 0x03, 0x03, 0x02, 0x03, 0x01, 0x00, 0x00, 0x03, 0x00,
-		0x01, 0x02, 0x00, 0x02, 0x03, 0x02, 0x02, 0x02, 0x00, 0x03, 0x02, 0x00, 0x01, 0x00, 0x01, 0x00,
-		0x00, 0x01, 0x01, 0x01, 0x01, 0x02, 0x02, 0x03, 0x02, 0x01, 0x00, 0x01, 0x03, 0x03, 0x03, 0x00,
-		0x03, 0x02, 0x02, 0x02, 0x03, 0x00, 0x00, 0x02, 0x02, 0x00, 0x00, 0x00, 0x02, 0x01, 0x01, 0x03,
-		0x01, 0x00, 0x01, 0x02, 0x00,
-	}})
-
+	r.holeStart = r.holeStartMessages(r.state.ActiveConnID)
+	for _, msg := range r.holeStart {
+		r.broadcast(ctx, msg)
+	}
+	r.startShotTimer(ctx, nextPlayer)
 	return nil
 }
 
+// holeStartMessages returns the messages that start a hole, with the given
+// player teeing off first.
+func (r *Room) holeStartMessages(activeConnID uint32) []gamepacket.ServerMessage {
+	wind := rand.Intn(8) + 1
+	return []gamepacket.ServerMessage{
+		&gamepacket.ServerRoomSetWeather{
+			Weather: 0,
+		},
+		&gamepacket.ServerRoomSetWind{
+			Wind:    uint8(wind),
+			Unknown: 0,
+			Heading: uint16(rand.Intn(256)),
+			Reset:   true,
+		},
+		&gamepacket.ServerRoomStartHole{
+			ConnID: activeConnID,
+		},
+		// TODO: These blobs are taken from an old packet dump. Not exactly sure what they are for.
+		&gamepacket.Server0151{Unknown: []byte{
+			0x0d, 0x00, 0x57, 0x5f, 0x42, 0x49, 0x47, 0x42, 0x4f, 0x4e, 0x47, 0x44, 0x41, 0x52, 0x49, 0x00,
+			0x03, 0x01, 0x03, 0x02, 0x03, 0x03, 0x02, 0x00, 0x02, 0x02, 0x02, 0x03, 0x01, 0x01, 0x00, 0x01,
+			0x00, 0x03, 0x02, 0x00, 0x00, 0x00, 0x02, 0x03, 0x03, 0x00, 0x01, 0x01, 0x03, 0x00, 0x02, 0x03,
+			0x01, 0x03, 0x03, 0x01, 0x02, 0x00, 0x03, 0x00, 0x02, 0x00, 0x00, 0x02, 0x00, 0x03, 0x03, 0x03,
+			0x02, 0x02, 0x02, 0x03, 0x01, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x02, 0x01, 0x01, 0x00, 0x03,
+			0x00, 0x01, 0x00, 0x03, 0x03, 0x03, 0x02, 0x00, 0x01, 0x01, 0x02, 0x03, 0x03, 0x01, 0x02, 0x00,
+			0x00, 0x02, 0x03, 0x02, 0x00, 0x00, 0x03, 0x02, 0x03, 0x00, 0x03, 0x00, 0x03, 0x02, 0x03, 0x02,
+			0x03, 0x00, 0x03,
+		}},
+		&gamepacket.Server0151{Unknown: []byte{
+			0x0d, 0x00, 0x52, 0x5f, 0x42, 0x49, 0x47, 0x42, 0x4f, 0x4e, 0x47, 0x44, 0x41, 0x52, 0x49, 0x01,
+			0x02, 0x00, 0x00, 0x01, 0x03, 0x01, 0x00, 0x01, 0x02, 0x02, 0x02, 0x03, 0x03, 0x02, 0x02, 0x01,
+			0x01, 0x03, 0x03, 0x00, 0x02, 0x02, 0x02, 0x03, 0x01, 0x02, 0x02, 0x03, 0x00, 0x00, 0x00, 0x02,
+			0x03, 0x03, 0x01, 0x02, 0x01, 0x03, 0x01, 0x00, 0x01, 0x00, 0x02, 0x00, 0x00, 0x00, 0x02, 0x03,
+			0x01, 0x00, 0x02, 0x02, 0x00, 0x02, 0x03, 0x00, 0x03, 0x03, 0x01, 0x03, 0x02, 0x01, 0x02, 0x03,
+			0x03, 0x03, 0x00, 0x00, 0x01, 0x00, 0x02, 0x00, 0x00, 0x01, 0x03, 0x00, 0x01, 0x02, 0x00, 0x00,
+			0x02, 0x02, 0x03, 0x00, 0x01, 0x02, 0x01, 0x02, 0x01, 0x03, 0x02, 0x01, 0x01, 0x03, 0x01, 0x02,
+			0x00, 0x02, 0x01,
+		}},
+		&gamepacket.Server0151{Unknown: []byte{
+			0x0f, 0x00, 0x43, 0x4c, 0x55, 0x42, 0x53, 0x45, 0x54, 0x5f, 0x4d, 0x49, 0x52, 0x41, 0x43, 0x4c,
+			0x45, 0x01, 0x01, 0x01, 0x02, 0x02, 0x00, 0x02, 0x01, 0x02, 0x03, 0x01, 0x03, 0x00, 0x02, 0x02,
+			0x03, 0x03, 0x01, 0x01, 0x02, 0x02, 0x00, 0x03, 0x02, 0x01, 0x01, 0x01, 0x03, 0x01, 0x00, 0x02,
+			0x01, 0x03, 0x03, 0x03, 0x02, 0x01, 0x03, 0x03, 0x03, 0x02, 0x03, 0x01, 0x00, 0x00, 0x03, 0x00,
+			0x01, 0x02, 0x00, 0x02, 0x03, 0x02, 0x02, 0x02, 0x00, 0x03, 0x02, 0x00, 0x01, 0x00, 0x01, 0x00,
+			0x00, 0x01, 0x01, 0x01, 0x01, 0x02, 0x02, 0x03, 0x02, 0x01, 0x00, 0x01, 0x03, 0x03, 0x03, 0x00,
+			0x03, 0x02, 0x02, 0x02, 0x03, 0x00, 0x00, 0x02, 0x02, 0x00, 0x00, 0x00, 0x02, 0x01, 0x01, 0x03,
+			0x01, 0x00, 0x01, 0x02, 0x00,
+		}},
+	}
+}
+
 func (r *Room) removePlayer(ctx context.Context, connID uint32) error {
 	if pair := r.players.GetPair(connID); pair != nil {
 		err := pair.Value.Conn.SendMessage(ctx, &gamepacket.ServerRoomLeave{
@@ -962,9 +1324,25 @@ func (r *Room) removePlayer(ctx context.Context, connID uint32) error {
 			},
 		})
 		r.players.Delete(connID)
-		if r.state.GamePhase == gamemodel.InGame {
+		r.stopShotTimer(&pair.Value)
+		r.stopReconnectTimer(&pair.Value)
+		if pair.Value.Disconnected {
+			// Their place in the game is gone, so they shouldn't be sent
+			// back to this room when they log in.
+			r.lobby.server.RemoveRejoin(int64(pair.Value.Entry.PlayerID), r)
+		}
+		if r.state.GamePhase == gamemodel.InGame && !pair.Value.Spectator {
+			// Keep what was played so far, but count it as a quit.
+			stats := pair.Value.Stats
+			stats.TotalScore = pair.Value.Score
+			stats.Quits = 1
+			if _, err := r.accounts.AddPlayerStats(ctx, int64(pair.Value.Entry.PlayerID), stats); err != nil {
+				r.log.Error().Err(err).Msg("failed saving statistics for quitting player")
+			}
 			r.broadcast(ctx, &gamepacket.ServerPlayerQuitGame{ConnID: connID})
-			if r.state.ActiveConnID == connID {
+			if r.isTourney() {
+				r.checkTourneyFinished(ctx)
+			} else if r.state.ActiveConnID == connID {
 				r.nextTurn(ctx)
 			}
 		}
@@ -987,16 +1365,20 @@ func (r *Room) removePlayer(ctx context.Context, connID uint32) error {
 }
 
 func (r *Room) broadcastPlayerList(ctx context.Context) error {
+	return r.broadcast(ctx, r.playerList())
+}
+
+func (r *Room) playerList() *gamepacket.ServerRoomCensus {
 	playerList := r.getRoomPlayerList()
 
-	return r.broadcast(ctx, &gamepacket.ServerRoomCensus{
+	return &gamepacket.ServerRoomCensus{
 		Type:    byte(gamepacket.ListSet),
 		Unknown: -1,
 		ListSet: &gamepacket.RoomCensusListSet{
 			PlayerCount: uint8(len(playerList)),
 			PlayerList:  playerList,
 		},
-	})
+	}
 }
 
 func (r *Room) roomStatus() *gamepacket.ServerRoomStatus {
@@ -1015,8 +1397,13 @@ func (r *Room) roomStatus() *gamepacket.ServerRoomStatus {
 	}
 }
 
+// currentHole returns the hole being played, or nil if no game is being
+// played.
 func (r *Room) currentHole() *gamemodel.RoomHole {
 	// Note: CurrentHole is 1-based.
+	if r.state.CurrentHole == 0 || int(r.state.CurrentHole) > len(r.state.Holes) {
+		return nil
+	}
 	return &r.state.Holes[r.state.CurrentHole-1]
 }
 
@@ -0,0 +1,425 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package room
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/pangbox/server/common"
+	"github.com/pangbox/server/common/hash"
+	"github.com/pangbox/server/database"
+	"github.com/pangbox/server/database/accounts"
+	gamemodel "github.com/pangbox/server/game/model"
+	gamepacket "github.com/pangbox/server/game/packet"
+	"github.com/pangbox/server/gameconfig"
+	_ "github.com/pangbox/server/migrations"
+	"github.com/pressly/goose/v3"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	orderedmap "github.com/wk8/go-ordered-map/v2"
+	_ "modernc.org/sqlite"
+)
+
+// testServer records what the room tells the game server.
+type testServer struct {
+	mu      sync.Mutex
+	rejoins map[int64]*Room
+	mail    []int64
+}
+
+func (s *testServer) NotifyMail(ctx context.Context, playerID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mail = append(s.mail, playerID)
+	return nil
+}
+
+func (s *testServer) notified() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int64(nil), s.mail...)
+}
+
+func (s *testServer) AddRejoin(playerID int64, room *Room) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rejoins[playerID] = room
+}
+
+func (s *testServer) RemoveRejoin(playerID int64, room *Room) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rejoins[playerID] == room {
+		delete(s.rejoins, playerID)
+	}
+}
+
+func (s *testServer) rejoin(playerID int64) *Room {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rejoins[playerID]
+}
+
+// testRoom is a room running against an in-memory database, with players
+// whose connections go nowhere.
+type testRoom struct {
+	*Room
+	t        *testing.T
+	ctx      context.Context
+	accounts *accounts.Service
+	server   *testServer
+	conns    uint32
+}
+
+func newTestRoom(t *testing.T, state gamemodel.RoomState) *testRoom {
+	t.Helper()
+
+	db, err := database.OpenDBWithDriver("sqlite", ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	// Each connection to :memory: is a separate database.
+	db.SetMaxOpenConns(1)
+
+	require.NoError(t, goose.Up(db, "."))
+
+	service := accounts.NewService(accounts.Options{
+		Logger:   zerolog.Nop(),
+		Database: db,
+		Hasher:   hash.Null{},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	if state.MaxUsers == 0 {
+		state.MaxUsers = 4
+	}
+	if state.NumHoles == 0 {
+		state.NumHoles = 3
+	}
+	server := &testServer{rejoins: make(map[int64]*Room)}
+	lobby := NewLobby(ctx, zerolog.Nop(), service, gameconfig.Default(), server)
+	room, err := lobby.NewRoom(ctx, state)
+	require.NoError(t, err)
+
+	return &testRoom{Room: room, t: t, ctx: ctx, accounts: service, server: server}
+}
+
+// newTestConn returns a connection for a player that discards everything
+// the room sends to it.
+func newTestConn(t *testing.T) *gamepacket.ServerConn {
+	server, client := net.Pipe()
+	t.Cleanup(func() {
+		server.Close()
+		client.Close()
+	})
+	go io.Copy(io.Discard, client)
+	return common.NewServerConn(server, zerolog.Nop(), gamepacket.ClientMessageTable, gamepacket.ServerMessageTable)
+}
+
+// newBareRoom returns a room that isn't running, for testing game rules
+// directly. Players are numbered by connection ID from 1.
+func newBareRoom(t *testing.T, state gamemodel.RoomState, players ...RoomPlayer) *Room {
+	r := &Room{
+		log:     zerolog.Nop(),
+		state:   state,
+		players: orderedmap.New[uint32, RoomPlayer](),
+	}
+	for i, player := range players {
+		player.Entry = &gamemodel.RoomPlayerEntry{ConnID: uint32(i + 1)}
+		player.Conn = newTestConn(t)
+		r.players.Set(player.Entry.ConnID, player)
+	}
+	return r
+}
+
+func (r *testRoom) newConn() *gamepacket.ServerConn {
+	return newTestConn(r.t)
+}
+
+// join registers a new player and puts them in the room. It returns the
+// player's connection ID.
+func (r *testRoom) join(nickname string) uint32 {
+	r.t.Helper()
+
+	player, err := r.accounts.Register(r.ctx, nickname, nickname)
+	require.NoError(r.t, err)
+
+	r.conns++
+	connID := r.conns
+	require.NoError(r.t, r.send(RoomPlayerJoin{
+		Entry: &gamemodel.RoomPlayerEntry{
+			ConnID:   connID,
+			PlayerID: uint32(player.PlayerID),
+			Nickname: nickname,
+		},
+		Conn:       r.newConn(),
+		UpdateFunc: func() {},
+	}))
+	return connID
+}
+
+// send sends an event to the room and waits for it to be handled.
+func (r *testRoom) send(event RoomEvent) error {
+	r.t.Helper()
+
+	promise, err := r.Send(r.ctx, event)
+	require.NoError(r.t, err)
+	_, err = promise.Wait(r.ctx)
+	return err
+}
+
+// disconnect tells the room that a player lost their connection, and
+// returns whether it kept their place in the game.
+func (r *testRoom) disconnect(connID uint32) bool {
+	r.t.Helper()
+
+	promise, err := r.Send(r.ctx, RoomPlayerDisconnect{ConnID: connID})
+	require.NoError(r.t, err)
+	kept, err := promise.Wait(r.ctx)
+	require.NoError(r.t, err)
+	return kept.(bool)
+}
+
+// player returns the room's state for a player. It must only be used while
+// the room is waiting for an event.
+func (r *testRoom) player(connID uint32) *RoomPlayer {
+	r.t.Helper()
+
+	pair := r.players.GetPair(connID)
+	require.NotNil(r.t, pair, "player %d not in room", connID)
+	return &pair.Value
+}
+
+// startGame starts a game and has every player finish loading into it.
+func (r *testRoom) startGame() {
+	r.t.Helper()
+
+	require.NoError(r.t, r.send(RoomStartGame{ConnID: r.state.OwnerConnID}))
+	for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
+		require.NoError(r.t, r.send(RoomGameReady{ConnID: pair.Key}))
+	}
+	require.Equal(r.t, gamemodel.InGame, r.state.GamePhase)
+}
+
+// playShot has the active player take a shot that everyone syncs.
+func (r *testRoom) playShot(players ...uint32) {
+	r.t.Helper()
+
+	sync := gamemodel.ShotSyncData{ActiveConnID: r.state.ActiveConnID}
+	for _, connID := range players {
+		require.NoError(r.t, r.send(RoomGameShotSync{ConnID: connID, Data: sync}))
+	}
+}
+
+func TestLateGameEventsAfterTimeUp(t *testing.T) {
+	r := newTestRoom(t, gamemodel.RoomState{GameTimerMS: 60 * 1000})
+	a := r.join("a")
+	b := r.join("b")
+	r.startGame()
+
+	require.NoError(t, r.send(RoomGameHoleInfo{ConnID: a, Par: 4}))
+	active := r.state.ActiveConnID
+	require.NoError(t, r.send(RoomGameShotSync{ConnID: a, Data: gamemodel.ShotSyncData{ActiveConnID: active}}))
+
+	// The game timer runs out mid-hole, while a shot is being synced.
+	require.NoError(t, r.send(RoomGameTimeUp{Game: r.gameNumber}))
+	assert.Equal(t, gamemodel.LobbyPhase, r.state.GamePhase)
+	assert.Zero(t, r.state.CurrentHole)
+	assert.Nil(t, r.currentHole())
+	assert.Nil(t, r.state.ShotSync)
+
+	// Whatever the clients were still sending about the game is dropped.
+	for _, event := range []RoomEvent{
+		RoomGameShotSync{ConnID: b, Data: gamemodel.ShotSyncData{ActiveConnID: active}},
+		RoomGameShotCommit{ConnID: active},
+		RoomGameTurnEnd{ConnID: a},
+		RoomGameTurnEnd{ConnID: b},
+		RoomGameHoleInfo{ConnID: b, Par: 3},
+		RoomGameHoleEnd{ConnID: active},
+		RoomGameReady{ConnID: a},
+		RoomShotTimeUp{ConnID: active, Turn: r.player(active).shotTurn},
+	} {
+		assert.NoError(t, r.send(event), "%T", event)
+	}
+	assert.Equal(t, gamemodel.LobbyPhase, r.state.GamePhase)
+	assert.Nil(t, r.state.ShotSync)
+	for _, connID := range []uint32{a, b} {
+		player := r.player(connID)
+		assert.Zero(t, player.Stroke)
+		assert.Zero(t, player.Stats)
+		assert.False(t, player.HoleEnd)
+	}
+
+	// The next game starts from a clean slate.
+	require.NoError(t, r.send(RoomStartGame{ConnID: a}))
+	assert.Equal(t, gamemodel.WaitingLoad, r.state.GamePhase)
+	require.NoError(t, r.send(RoomGameReady{ConnID: a}))
+	assert.Equal(t, gamemodel.WaitingLoad, r.state.GamePhase)
+	require.NoError(t, r.send(RoomGameReady{ConnID: b}))
+	assert.Equal(t, gamemodel.InGame, r.state.GamePhase)
+	assert.Equal(t, uint8(1), r.state.CurrentHole)
+}
+
+func TestShotTimeUp(t *testing.T) {
+	r := newTestRoom(t, gamemodel.RoomState{ShotTimerMS: 60 * 1000})
+	a := r.join("a")
+	b := r.join("b")
+	r.startGame()
+
+	active := r.state.ActiveConnID
+	turn := r.player(active).shotTurn
+	require.NoError(t, r.send(RoomShotTimeUp{ConnID: active, Turn: turn}))
+	assert.Equal(t, int8(1), r.player(active).Stroke)
+	assert.Equal(t, uint32(1), r.player(active).Stats.Timeouts)
+	assert.Greater(t, r.player(active).shotTurn, turn)
+
+	// The ball stayed where it was, but the other player gets the next turn.
+	other := a
+	if active == a {
+		other = b
+	}
+	assert.Equal(t, other, r.state.ActiveConnID)
+
+	// A timer for a turn that is already over does nothing.
+	require.NoError(t, r.send(RoomShotTimeUp{ConnID: active, Turn: turn}))
+	assert.Equal(t, int8(1), r.player(active).Stroke)
+	assert.Equal(t, other, r.state.ActiveConnID)
+
+	// Once the other player has taken their turn, it's back to the player
+	// who is furthest away.
+	r.playShot(a, b)
+	require.NoError(t, r.send(RoomGameTurnEnd{ConnID: a}))
+	require.NoError(t, r.send(RoomGameTurnEnd{ConnID: b}))
+	assert.Equal(t, active, r.state.ActiveConnID)
+}
+
+func TestShotTimeUpGivesUpHole(t *testing.T) {
+	r := newTestRoom(t, gamemodel.RoomState{ShotTimerMS: 60 * 1000})
+	a := r.join("a")
+	b := r.join("b")
+	r.startGame()
+	require.NoError(t, r.send(RoomGameHoleInfo{ConnID: a, Par: 4}))
+
+	// Neither player is taking their turns, so they take turns timing out.
+	for i := 0; i < maxShotTimeouts; i++ {
+		for _, connID := range []uint32{a, b} {
+			require.Equal(t, uint8(1), r.state.CurrentHole)
+			require.Equal(t, connID, r.state.ActiveConnID)
+			require.NoError(t, r.send(RoomShotTimeUp{ConnID: connID, Turn: r.player(connID).shotTurn}))
+		}
+	}
+
+	// Both of them gave up the hole, and the game moved on to the next.
+	assert.Equal(t, uint8(2), r.state.CurrentHole)
+	for _, connID := range []uint32{a, b} {
+		player := r.player(connID)
+		assert.Equal(t, int8(4+giveUpOverPar), player.LastTotal)
+		assert.Equal(t, int32(giveUpOverPar), player.Score)
+		assert.Equal(t, uint32(maxShotTimeouts), player.Stats.Timeouts)
+		assert.Equal(t, uint32(1), player.Stats.HoleUnfinished)
+		assert.Zero(t, player.Stroke)
+	}
+}
+
+func TestShotTimeUpStrokeLimit(t *testing.T) {
+	r := newTestRoom(t, gamemodel.RoomState{ShotTimerMS: 60 * 1000})
+	a := r.join("a")
+	b := r.join("b")
+	r.startGame()
+	require.NoError(t, r.send(RoomGameHoleInfo{ConnID: a, Par: 4}))
+
+	// a lets every other turn of theirs time out, so they never time out
+	// too many times in a row.
+	for r.player(a).Stroke < 4+giveUpOverPar-1 {
+		require.Equal(t, a, r.state.ActiveConnID)
+		require.NoError(t, r.send(RoomShotTimeUp{ConnID: a, Turn: r.player(a).shotTurn}))
+		require.False(t, r.player(a).HoleEnd)
+		for _, connID := range []uint32{b, a} {
+			require.Equal(t, connID, r.state.ActiveConnID)
+			r.playShot(a, b)
+			require.NoError(t, r.send(RoomGameTurnEnd{ConnID: a}))
+			require.NoError(t, r.send(RoomGameTurnEnd{ConnID: b}))
+		}
+	}
+
+	// The timeout that brings a to the stroke limit ends their hole.
+	require.Equal(t, a, r.state.ActiveConnID)
+	require.NoError(t, r.send(RoomShotTimeUp{ConnID: a, Turn: r.player(a).shotTurn}))
+	assert.True(t, r.player(a).HoleEnd)
+	assert.Equal(t, int8(4+giveUpOverPar), r.player(a).LastTotal)
+	assert.Equal(t, uint32(1), r.player(a).Stats.HoleUnfinished)
+	assert.Equal(t, b, r.state.ActiveConnID)
+}
+
+func TestSpectator(t *testing.T) {
+	r := newTestRoom(t, gamemodel.RoomState{})
+	a := r.join("a")
+	b := r.join("b")
+	require.NoError(t, r.send(RoomStartGame{ConnID: a}))
+
+	// A player who joins during the game watches it, and the game doesn't
+	// wait on them.
+	c := r.join("c")
+	assert.True(t, r.player(c).Spectator)
+	require.NoError(t, r.send(RoomGameReady{ConnID: a}))
+	require.NoError(t, r.send(RoomGameReady{ConnID: b}))
+	require.Equal(t, gamemodel.InGame, r.state.GamePhase)
+	assert.Len(t, r.gameInit().Full.Players, 2)
+
+	active := r.state.ActiveConnID
+	require.NoError(t, r.send(RoomGameShotSync{ConnID: c, Data: gamemodel.ShotSyncData{ActiveConnID: c}}))
+	assert.Nil(t, r.state.ShotSync)
+	r.playShot(a, b)
+	assert.Nil(t, r.state.ShotSync)
+	assert.Equal(t, int8(1), r.player(active).Stroke)
+	assert.Zero(t, r.player(c).Stroke)
+
+	for i := 0; i < 6; i++ {
+		assert.NotEqual(t, c, r.state.ActiveConnID)
+		require.NoError(t, r.send(RoomGameTurnEnd{ConnID: a}))
+		require.NoError(t, r.send(RoomGameTurnEnd{ConnID: b}))
+	}
+
+	// Once the game is over, they play in the next one.
+	require.NoError(t, r.send(RoomGameTimeUp{Game: r.gameNumber}))
+	assert.False(t, r.player(c).Spectator)
+	assert.Zero(t, r.player(c).Stats)
+}
+
+func TestDisconnectSpectator(t *testing.T) {
+	r := newTestRoom(t, gamemodel.RoomState{})
+	a := r.join("a")
+	r.join("b")
+	r.startGame()
+	c := r.join("c")
+	playerID := int64(r.player(c).Entry.PlayerID)
+
+	// Spectators have no place in the game to keep.
+	assert.False(t, r.disconnect(c))
+	assert.Nil(t, r.players.GetPair(c))
+	assert.Nil(t, r.server.rejoin(playerID))
+	assert.Equal(t, gamemodel.InGame, r.state.GamePhase)
+	assert.NotNil(t, r.players.GetPair(a))
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package room
+
+import (
+	"github.com/pangbox/server/pangya"
+)
+
+// addHoleStats merges the statistics reported by the client at the end of a
+// hole into the player's running totals for the current game. Values the
+// server can determine itself (strokes, holes, timeouts, hole-in-ones and
+// albatrosses) are not taken from the client.
+func (p *RoomPlayer) addHoleStats(hole pangya.PlayerStats, par uint8) {
+	p.Stats.TotalPutts += hole.TotalPutts
+	p.Stats.PangyaHits += hole.PangyaHits
+	p.Stats.OBs += hole.OBs
+	p.Stats.TotalDistance += hole.TotalDistance
+	p.Stats.HoleUnfinished += hole.HoleUnfinished
+	p.Stats.BunkersHit += hole.BunkersHit
+	p.Stats.FairwaysHit += hole.FairwaysHit
+	p.Stats.PuttIns += hole.PuttIns
+	if hole.LongestDrive > p.Stats.LongestDrive {
+		p.Stats.LongestDrive = hole.LongestDrive
+	}
+	if hole.LongestPutt > p.Stats.LongestPutt {
+		p.Stats.LongestPutt = hole.LongestPutt
+	}
+	if hole.LongestChip > p.Stats.LongestChip {
+		p.Stats.LongestChip = hole.LongestChip
+	}
+
+	p.Stats.TotalHoles++
+	switch {
+	case p.Stroke == 1:
+		p.Stats.TotalHIO++
+		if par == 4 {
+			// A hole-in-one on a par 4 is also an albatross.
+			p.Stats.TotalAlbatross++
+		}
+	case p.Stroke > 0 && int(p.Stroke) == int(par)-3:
+		p.Stats.TotalAlbatross++
+	}
+}
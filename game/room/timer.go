@@ -0,0 +1,139 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package room
+
+import (
+	"context"
+	"time"
+
+	gamemodel "github.com/pangbox/server/game/model"
+)
+
+// shotTimerGrace is added to the shot timer to allow for the time it takes
+// the client to hear that it's their turn and start its own countdown.
+const shotTimerGrace = 2 * time.Second
+
+// maxShotTimeouts is the number of turns in a row a player can let time out
+// before they give up the hole.
+const maxShotTimeouts = 3
+
+// startGameTimer starts the room's game time limit, if it has one. When the
+// time is up, the room is sent a RoomGameTimeUp event.
+func (r *Room) startGameTimer(ctx context.Context) {
+	r.stopGameTimer()
+	if r.state.GameTimerMS == 0 {
+		return
+	}
+	game, limit := r.gameNumber, time.Duration(r.state.GameTimerMS)*time.Millisecond
+	r.gameEnd = time.Now().Add(limit)
+	r.gameTimer = time.AfterFunc(limit, func() {
+		if _, err := r.Send(ctx, RoomGameTimeUp{Game: game}); err != nil {
+			r.log.Debug().Err(err).Msg("room closed before game timer expired")
+		}
+	})
+}
+
+func (r *Room) stopGameTimer() {
+	if r.gameTimer != nil {
+		r.gameTimer.Stop()
+		r.gameTimer = nil
+	}
+}
+
+// startShotTimer starts the shot timer for a player whose turn it now is.
+// If the player doesn't take their shot in time, the room is sent a
+// RoomShotTimeUp event.
+func (r *Room) startShotTimer(ctx context.Context, player *RoomPlayer) {
+	r.stopShotTimer(player)
+	if r.state.ShotTimerMS == 0 {
+		return
+	}
+	connID, turn := player.Entry.ConnID, player.shotTurn
+	player.shotTimer = time.AfterFunc(time.Duration(r.state.ShotTimerMS)*time.Millisecond+shotTimerGrace, func() {
+		if _, err := r.Send(ctx, RoomShotTimeUp{ConnID: connID, Turn: turn}); err != nil {
+			r.log.Debug().Err(err).Msg("room closed before shot timer expired")
+		}
+	})
+}
+
+// stopShotTimer stops the player's shot timer. A RoomShotTimeUp event that
+// was already sent for it is ignored.
+func (r *Room) stopShotTimer(player *RoomPlayer) {
+	if player.shotTimer != nil {
+		player.shotTimer.Stop()
+		player.shotTimer = nil
+	}
+	player.shotTurn++
+}
+
+// stopTimers stops the game timer and every player's shot timer.
+func (r *Room) stopTimers() {
+	r.stopGameTimer()
+	for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
+		r.stopShotTimer(&pair.Value)
+	}
+}
+
+func (r *Room) handleRoomShotTimeUp(ctx context.Context, event RoomShotTimeUp) error {
+	if r.state.GamePhase != gamemodel.InGame {
+		return nil
+	}
+	pair := r.players.GetPair(event.ConnID)
+	if pair == nil || pair.Value.shotTurn != event.Turn {
+		return nil
+	}
+	player := &pair.Value
+	player.shotTimer = nil
+	if player.HoleEnd || (!r.isTourney() && r.state.ActiveConnID != player.Entry.ConnID) {
+		return nil
+	}
+
+	// The turn is lost, and costs a stroke. The ball stays where it is, so
+	// the player goes after everyone else for their next turn. A player who
+	// keeps timing out gives up the hole.
+	player.Stroke++
+	player.Stats.TotalStrokes++
+	player.Stats.Timeouts++
+	player.timeouts++
+	player.passed = true
+	if hole := r.playerHole(player); hole != nil {
+		if player.timeouts >= maxShotTimeouts || player.Stroke >= int8(hole.Par)+giveUpOverPar {
+			r.giveUpHole(player)
+		}
+	}
+	if r.isTourney() {
+		return r.endTourneyTurn(ctx, player)
+	}
+	return r.endTurn(ctx)
+}
+
+func (r *Room) handleRoomGameTimeUp(ctx context.Context, event RoomGameTimeUp) error {
+	// The timer may have fired just as the game it was started for ended.
+	if event.Game != r.gameNumber || r.state.GamePhase == gamemodel.LobbyPhase {
+		return nil
+	}
+	r.gameTimer = nil
+
+	// Holes that players didn't get to play count as unfinished.
+	for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
+		if !pair.Value.GameEnd && !pair.Value.Spectator {
+			pair.Value.Stats.HoleUnfinished += uint32(len(r.state.Holes) - r.holesPlayed(&pair.Value))
+		}
+	}
+	return r.endGame(ctx)
+}
@@ -0,0 +1,210 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package room
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/pangbox/server/database/accounts"
+	gamemodel "github.com/pangbox/server/game/model"
+	gamepacket "github.com/pangbox/server/game/packet"
+	"golang.org/x/exp/slices"
+)
+
+// In a tournament, every player plays through the holes on their own, at
+// their own pace. Shots aren't synchronized between players; players are
+// ranked by how far they got and their score, and placed by that ranking
+// when the game ends.
+
+func (r *Room) isTourney() bool {
+	return r.state.RoomType == gamemodel.RoomTypeTourney
+}
+
+// playerHole returns the hole the player is playing, or nil if no game is
+// being played.
+func (r *Room) playerHole(player *RoomPlayer) *gamemodel.RoomHole {
+	if r.isTourney() {
+		// Note: Hole is 1-based.
+		if player.Hole == 0 || int(player.Hole) > len(r.state.Holes) {
+			return nil
+		}
+		return &r.state.Holes[player.Hole-1]
+	}
+	return r.currentHole()
+}
+
+// holesPlayed returns the number of holes the player has finished.
+func (r *Room) holesPlayed(player *RoomPlayer) int {
+	played := int(r.state.CurrentHole) - 1
+	if r.isTourney() {
+		played = int(player.Hole) - 1
+	}
+	if player.HoleEnd {
+		played++
+	}
+	return played
+}
+
+// broadcastShot announces part of a player's shot. In a tournament, only
+// the player taking the shot is told.
+func (r *Room) broadcastShot(ctx context.Context, connID uint32, msg gamepacket.ServerMessage) error {
+	if !r.isTourney() {
+		return r.broadcast(ctx, msg)
+	}
+	if pair := r.players.GetPair(connID); pair != nil {
+		return pair.Value.Conn.SendMessage(ctx, msg)
+	}
+	return nil
+}
+
+// startTourneyHole starts the player's next hole as soon as they are ready
+// for it, without waiting on anyone else.
+func (r *Room) startTourneyHole(ctx context.Context, player *RoomPlayer) error {
+	r.state.GamePhase = gamemodel.InGame
+	player.Distance = math.Inf(1)
+	for _, msg := range r.holeStartMessages(player.Entry.ConnID) {
+		if err := player.Conn.SendMessage(ctx, msg); err != nil {
+			return err
+		}
+	}
+	r.startShotTimer(ctx, player)
+	return nil
+}
+
+func (r *Room) handleTourneyShotSync(ctx context.Context, player *RoomPlayer, event RoomGameShotSync) error {
+	r.applyShot(player, event.Data)
+	return player.Conn.SendMessage(ctx, &gamepacket.ServerRoomShotSync{
+		Data: event.Data,
+	})
+}
+
+// endTourneyTurn ends the player's shot. The player keeps shooting until
+// they hole out, and then moves on to the next hole.
+func (r *Room) endTourneyTurn(ctx context.Context, player *RoomPlayer) error {
+	player.TurnEnd = false
+	player.StartShot = false
+	if err := player.Conn.SendMessage(ctx, &gamepacket.ServerRoomShotEnd{
+		ConnID: player.Entry.ConnID,
+	}); err != nil {
+		return err
+	}
+	if !player.HoleEnd {
+		r.startShotTimer(ctx, player)
+		return player.Conn.SendMessage(ctx, &gamepacket.ServerRoomActiveUserAnnounce{
+			ConnID: player.Entry.ConnID,
+		})
+	}
+
+	if int(player.Hole) < len(r.state.Holes) {
+		player.Hole++
+		player.HoleEnd = false
+		return player.Conn.SendMessage(ctx, &gamepacket.ServerRoomFinishHole{})
+	}
+
+	player.GameEnd = true
+	if err := player.Conn.SendMessage(ctx, &gamepacket.ServerRoomPlayerFinished{}); err != nil {
+		return err
+	}
+	return r.checkTourneyFinished(ctx)
+}
+
+// checkTourneyFinished ends the game once every player has finished.
+func (r *Room) checkTourneyFinished(ctx context.Context) error {
+	for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
+		if !pair.Value.GameEnd && !pair.Value.Spectator {
+			return nil
+		}
+	}
+	return r.endGame(ctx)
+}
+
+// tourneyStanding is a player's position in a tournament.
+type tourneyStanding struct {
+	ConnID      uint32
+	Place       uint8
+	HolesPlayed uint8
+	Score       int32
+}
+
+// tourneyStandings ranks the players by the number of holes they have
+// finished, and then by score.
+func (r *Room) tourneyStandings() []tourneyStanding {
+	standings := make([]tourneyStanding, 0, r.players.Len())
+	for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
+		if pair.Value.Spectator {
+			continue
+		}
+		standings = append(standings, tourneyStanding{
+			ConnID:      pair.Value.Entry.ConnID,
+			HolesPlayed: uint8(r.holesPlayed(&pair.Value)),
+			Score:       pair.Value.Score,
+		})
+	}
+	slices.SortStableFunc(standings, func(a, b tourneyStanding) bool {
+		if a.HolesPlayed != b.HolesPlayed {
+			return a.HolesPlayed > b.HolesPlayed
+		}
+		return a.Score < b.Score
+	})
+	for i := range standings {
+		if i > 0 && standings[i-1].HolesPlayed == standings[i].HolesPlayed && standings[i-1].Score == standings[i].Score {
+			standings[i].Place = standings[i-1].Place
+		} else {
+			standings[i].Place = uint8(i + 1)
+		}
+	}
+	return standings
+}
+
+// tourneyPlaces returns the final placement of each player by connection ID.
+func (r *Room) tourneyPlaces() map[uint32]uint8 {
+	places := make(map[uint32]uint8, r.players.Len())
+	for _, standing := range r.tourneyStandings() {
+		places[standing.ConnID] = standing.Place
+	}
+	return places
+}
+
+// mailTourneyPrize sends the player the prize for their place, if any.
+func (r *Room) mailTourneyPrize(ctx context.Context, player *RoomPlayer, place uint8) {
+	prize, ok := r.lobby.configProvider.GetTourneyPrize(int(place))
+	if !ok {
+		return
+	}
+	mail := accounts.NewMail{
+		SenderNickname:    "@Pangbox",
+		RecipientPlayerID: int64(player.Entry.PlayerID),
+		Body:              fmt.Sprintf("Congratulations on placing #%d in the tournament!", place),
+		Pang:              int64(prize.Pang),
+	}
+	if prize.ItemTypeID != 0 {
+		mail.Items = []accounts.MailItem{{
+			ItemTypeID: int64(prize.ItemTypeID),
+			Quantity:   int64(prize.Quantity),
+		}}
+	}
+	if _, err := r.accounts.SendMail(ctx, mail); err != nil {
+		r.log.Error().Err(err).Uint8("place", place).Msg("failed mailing tournament prize")
+		return
+	}
+	if err := r.lobby.server.NotifyMail(ctx, mail.RecipientPlayerID); err != nil {
+		r.log.Error().Err(err).Msg("failed notifying player of tournament prize")
+	}
+}
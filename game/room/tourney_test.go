@@ -0,0 +1,138 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package room
+
+import (
+	"testing"
+
+	gamemodel "github.com/pangbox/server/game/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTourneyStandings(t *testing.T) {
+	tests := []struct {
+		name      string
+		players   []RoomPlayer
+		standings []tourneyStanding
+	}{
+		{
+			name: "ranked by score",
+			players: []RoomPlayer{
+				{Hole: 3, HoleEnd: true, Score: 2},
+				{Hole: 3, HoleEnd: true, Score: -1},
+				{Hole: 3, HoleEnd: true, Score: 0},
+			},
+			standings: []tourneyStanding{
+				{ConnID: 2, HolesPlayed: 3, Score: -1, Place: 1},
+				{ConnID: 3, HolesPlayed: 3, Score: 0, Place: 2},
+				{ConnID: 1, HolesPlayed: 3, Score: 2, Place: 3},
+			},
+		},
+		{
+			name: "more holes played ranks first",
+			players: []RoomPlayer{
+				{Hole: 2, HoleEnd: false, Score: -3},
+				{Hole: 2, HoleEnd: true, Score: 1},
+			},
+			standings: []tourneyStanding{
+				{ConnID: 2, HolesPlayed: 2, Score: 1, Place: 1},
+				{ConnID: 1, HolesPlayed: 1, Score: -3, Place: 2},
+			},
+		},
+		{
+			name: "ties share a place and the next place is skipped",
+			players: []RoomPlayer{
+				{Hole: 3, HoleEnd: true, Score: 0},
+				{Hole: 3, HoleEnd: true, Score: -2},
+				{Hole: 3, HoleEnd: true, Score: -2},
+				{Hole: 3, HoleEnd: true, Score: 1},
+			},
+			standings: []tourneyStanding{
+				{ConnID: 2, HolesPlayed: 3, Score: -2, Place: 1},
+				{ConnID: 3, HolesPlayed: 3, Score: -2, Place: 1},
+				{ConnID: 1, HolesPlayed: 3, Score: 0, Place: 3},
+				{ConnID: 4, HolesPlayed: 3, Score: 1, Place: 4},
+			},
+		},
+		{
+			name: "same score on fewer holes is not a tie",
+			players: []RoomPlayer{
+				{Hole: 3, HoleEnd: false, Score: 0},
+				{Hole: 3, HoleEnd: true, Score: 0},
+			},
+			standings: []tourneyStanding{
+				{ConnID: 2, HolesPlayed: 3, Score: 0, Place: 1},
+				{ConnID: 1, HolesPlayed: 2, Score: 0, Place: 2},
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			r := newBareRoom(t, gamemodel.RoomState{
+				RoomType: gamemodel.RoomTypeTourney,
+				Holes:    make([]gamemodel.RoomHole, 3),
+			}, test.players...)
+			assert.Equal(t, test.standings, r.tourneyStandings())
+		})
+	}
+}
+
+func TestTourneyPrizes(t *testing.T) {
+	r := newTestRoom(t, gamemodel.RoomState{RoomType: gamemodel.RoomTypeTourney})
+	scores := map[uint32]int32{}
+	for nickname, score := range map[string]int32{"a": -2, "b": -2, "c": 0, "d": 1} {
+		scores[r.join(nickname)] = score
+	}
+	r.startGame()
+
+	// Everyone finishes the last hole, with two players tied for first.
+	for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
+		pair.Value.Hole = r.state.NumHoles
+		pair.Value.HoleEnd = true
+		pair.Value.Score = scores[pair.Key]
+	}
+	require.NoError(t, r.send(RoomGameTimeUp{Game: r.gameNumber}))
+
+	var notified []int64
+	for pair := r.players.Oldest(); pair != nil; pair = pair.Next() {
+		playerID := int64(pair.Value.Entry.PlayerID)
+		inbox, _, err := r.accounts.GetInbox(r.ctx, playerID, 1, 10)
+		require.NoError(t, err)
+
+		// Both players tied for first get the first place prize, the next
+		// player gets the third place prize, and last place gets nothing.
+		var pang, quantity int64
+		switch scores[pair.Key] {
+		case -2:
+			pang, quantity = 10000, 5
+		case 0:
+			pang, quantity = 3000, 1
+		default:
+			assert.Empty(t, inbox)
+			continue
+		}
+		require.Len(t, inbox, 1)
+		assert.Equal(t, pang, inbox[0].Pang)
+		require.Len(t, inbox[0].Attachments, 1)
+		assert.Equal(t, quantity, inbox[0].Attachments[0].Quantity)
+		notified = append(notified, playerID)
+	}
+	assert.ElementsMatch(t, notified, r.server.notified())
+}
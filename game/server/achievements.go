@@ -0,0 +1,119 @@
+// Copyright (C) 2023, John Chadwick <john@jchw.io>, JMC47
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2023 John Chadwick, JMC47
+// SPDX-License-Identifier: ISC
+
+package gameserver
+
+import (
+	"context"
+	"fmt"
+
+	gamepacket "github.com/pangbox/server/game/packet"
+	"github.com/pangbox/server/game/room"
+	"github.com/pangbox/server/gameconfig"
+)
+
+func (c *Conn) sendAchievementProgress(ctx context.Context) error {
+	counters, err := c.s.accountsService.GetAchievementCounters(ctx, c.session.PlayerID)
+	if err != nil {
+		return fmt.Errorf("getting achievement counters: %w", err)
+	}
+	msg := &gamepacket.ServerAchievementProgress{
+		Remaining:    uint32(len(counters)),
+		Count:        uint32(len(counters)),
+		Achievements: make([]gamepacket.AchievementProgress, len(counters)),
+	}
+	for i, counter := range counters {
+		msg.Achievements[i] = gamepacket.AchievementProgress{
+			StatusID:   uint32(counter.CounterTypeID),
+			StatusSlot: uint32(i + 1),
+			Value:      uint32(counter.Value),
+		}
+	}
+	return c.SendMessage(ctx, msg)
+}
+
+// sendAchievementStatus sends the status of every configured achievement for
+// the given player. Each stage reports the counter value towards its goal and,
+// once completed, when it was completed.
+func (c *Conn) sendAchievementStatus(ctx context.Context, playerID int64) error {
+	counters, err := c.s.accountsService.GetAchievementCounters(ctx, playerID)
+	if err != nil {
+		return fmt.Errorf("getting achievement counters: %w", err)
+	}
+	completions, err := c.s.accountsService.GetAchievementCompletions(ctx, playerID)
+	if err != nil {
+		return fmt.Errorf("getting achievement completions: %w", err)
+	}
+	values := make(map[uint32]uint32, len(counters))
+	for _, counter := range counters {
+		values[uint32(counter.CounterTypeID)] = uint32(counter.Value)
+	}
+	completedAt := make(map[uint32]uint32, len(completions))
+	for _, completion := range completions {
+		completedAt[uint32(completion.StageTypeID)] = uint32(completion.CompletedAt)
+	}
+
+	achievements := c.s.configProvider.GetAchievements()
+	msg := &gamepacket.ServerAchievementStatusResponse{
+		Remaining: uint32(len(achievements)),
+		Count:     uint32(len(achievements)),
+		Groups:    make([]gamepacket.AchievementGroup, len(achievements)),
+	}
+	for i, achievement := range achievements {
+		group := gamepacket.AchievementGroup{
+			GroupID:      achievement.TypeID,
+			ID:           uint32(i + 1),
+			Count:        uint32(len(achievement.Stages)),
+			Achievements: make([]gamepacket.Achievement, len(achievement.Stages)),
+		}
+		for j, stage := range achievement.Stages {
+			value := values[achievement.CounterTypeID]
+			if value > stage.Goal {
+				value = stage.Goal
+			}
+			group.Achievements[j] = gamepacket.Achievement{
+				ID:        stage.TypeID,
+				Value:     value,
+				Timestamp: completedAt[stage.TypeID],
+			}
+		}
+		msg.Groups[i] = group
+	}
+	if err := c.SendMessage(ctx, msg); err != nil {
+		return err
+	}
+	return c.SendMessage(ctx, &gamepacket.ServerAchievementUnknownResponse{})
+}
+
+// recordAchievementEvent advances the player's achievements for event and
+// sends the updated progress, along with any rewards that were granted.
+func (c *Conn) recordAchievementEvent(ctx context.Context, event gameconfig.AchievementEvent, amount uint32) error {
+	completed, err := room.RecordAchievementEvent(ctx, c.s.accountsService, c.s.configProvider, c.session.PlayerID, event, amount)
+	if err != nil {
+		return fmt.Errorf("recording achievement event: %w", err)
+	}
+	if err := c.sendAchievementProgress(ctx); err != nil {
+		return err
+	}
+
+	grantedItems, grantedPang := false, false
+	for _, stage := range completed {
+		grantedItems = grantedItems || stage.ItemTypeID != 0
+		grantedPang = grantedPang || stage.Pang != 0
+	}
+	return c.sendRewardUpdates(ctx, grantedItems, grantedPang)
+}
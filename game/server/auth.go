@@ -19,11 +19,14 @@ package gameserver
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/bufbuild/connect-go"
 	"github.com/pangbox/server/common"
+	"github.com/pangbox/server/database/accounts"
 	gamepacket "github.com/pangbox/server/game/packet"
+	"github.com/pangbox/server/gen/dbmodels"
 	"github.com/pangbox/server/gen/proto/go/topologypb"
 	"github.com/pangbox/server/pangya"
 )
@@ -73,6 +76,10 @@ func (c *Conn) handleAuth(ctx context.Context) error {
 		return fmt.Errorf("sending player statistics to client: %w", err)
 	}
 
+	if err := c.sendInboxNotify(ctx); err != nil {
+		return fmt.Errorf("sending inbox notification: %w", err)
+	}
+
 	return nil
 }
 
@@ -111,6 +118,20 @@ func (c *Conn) fetchPlayer(ctx context.Context) error {
 		// TODO: error handling
 		return err
 	}
+	c.stats, err = c.s.accountsService.GetPlayerStats(ctx, c.session.PlayerID)
+	if err != nil {
+		return err
+	}
+	c.seasonHistory, err = c.s.accountsService.GetSeasonHistory(ctx, c.session.PlayerID)
+	if err != nil {
+		return err
+	}
+	c.guild, err = c.s.accountsService.GetPlayerGuild(ctx, c.session.PlayerID)
+	if errors.Is(err, accounts.ErrNotInGuild) {
+		c.guild = dbmodels.GetPlayerGuildRow{}
+	} else if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -161,13 +182,6 @@ func (c *Conn) sendCharacterData(ctx context.Context) error {
 	})
 }
 
-func (c *Conn) sendAchievementProgress(ctx context.Context) error {
-	return c.SendMessage(ctx, &gamepacket.ServerAchievementProgress{
-		Remaining: 0,
-		Count:     0,
-	})
-}
-
 func (c *Conn) sendInventory(ctx context.Context) error {
 	inventory, err := c.s.accountsService.GetPlayerInventory(ctx, c.session.PlayerID)
 	if err != nil {
@@ -175,20 +189,22 @@ func (c *Conn) sendInventory(ctx context.Context) error {
 	}
 
 	for i, l := 0, 50; i < len(inventory); {
-		if l > len(inventory) {
-			l = len(inventory)
+		if l > len(inventory)-i {
+			l = len(inventory) - i
 		}
 		inventoryPkt := &gamepacket.ServerPlayerInventory{
 			Remaining: uint16((len(inventory) - i) - l),
 			Count:     uint16(l),
 			Inventory: make([]gamepacket.InventoryItem, l),
 		}
-		for n := i + l; i < n; i++ {
-			inventoryPkt.Inventory[i] = gamepacket.InventoryItem{
-				ItemID:     uint32(inventory[i].ItemID),
-				ItemTypeID: uint32(inventory[i].ItemTypeID),
+		for j := range inventoryPkt.Inventory {
+			item := inventory[i]
+			i++
+			inventoryPkt.Inventory[j] = gamepacket.InventoryItem{
+				ItemID:     uint32(item.ItemID),
+				ItemTypeID: uint32(item.ItemTypeID),
 				Unknown:    -1,
-				Quantity:   uint32(inventory[i].Quantity.Int64),
+				Quantity:   uint32(item.Quantity.Int64),
 				Unknown5: [156]byte{
 					0x02, 0x30, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -202,6 +218,11 @@ func (c *Conn) sendInventory(ctx context.Context) error {
 					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 				},
 			}
+			if item.ExpiresAt.Valid {
+				inventoryPkt.Inventory[j].Flags |= gamepacket.InventoryFlagTimeLimited
+				inventoryPkt.Inventory[j].RentalDateStart = uint32(item.PurchasedAt.Int64)
+				inventoryPkt.Inventory[j].RentalDateEnd = uint32(item.ExpiresAt.Int64)
+			}
 		}
 		if err := c.SendMessage(ctx, inventoryPkt); err != nil {
 			return err
@@ -251,3 +272,24 @@ func (c *Conn) sendPlayerStatistics(ctx context.Context) error {
 		Stats:   c.getPlayerStats(),
 	})
 }
+
+// sendRewardUpdates refreshes the client after rewards were granted to the
+// player outside of a purchase.
+func (c *Conn) sendRewardUpdates(ctx context.Context, grantedItems, grantedPang bool) error {
+	if grantedItems {
+		if err := c.sendInventory(ctx); err != nil {
+			return err
+		}
+	}
+	if grantedPang {
+		if err := c.fetchPlayer(ctx); err != nil {
+			return err
+		}
+		if err := c.SendMessage(ctx, &gamepacket.ServerPangBalanceData{
+			PangsRemaining: uint64(c.player.Pang),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
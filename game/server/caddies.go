@@ -0,0 +1,90 @@
+// Copyright (C) 2023, John Chadwick <john@jchw.io>, JMC47
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2023 John Chadwick, JMC47
+// SPDX-License-Identifier: ISC
+
+package gameserver
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/pangbox/server/database/accounts"
+	gamepacket "github.com/pangbox/server/game/packet"
+)
+
+func (c *Conn) setCaddie(ctx context.Context, caddieID uint32) error {
+	log := c.Log()
+
+	status := gamepacket.EquipmentUpdateOK
+	err := c.s.accountsService.SetCaddie(ctx, c.player.PlayerID, int64(caddieID), time.Now())
+	if errors.Is(err, accounts.ErrCaddieNotOwned) {
+		log.Warn().Uint32("caddie", caddieID).Msg("rejected equipping caddie not owned by player")
+		status = gamepacket.EquipmentUpdateFailed
+	} else if err != nil {
+		return err
+	}
+	if err := c.fetchPlayer(ctx); err != nil {
+		return err
+	}
+	return c.SendMessage(ctx, &gamepacket.ServerPlayerEquipmentUpdated{
+		Status: status,
+		Type:   uint8(gamepacket.UpdatedCaddie),
+		Caddie: &gamepacket.CaddieUpdated{
+			CaddieID: uint32(c.player.CaddieID.Int64),
+		},
+	})
+}
+
+func (c *Conn) setMascot(ctx context.Context, mascotID uint32) error {
+	log := c.Log()
+
+	status := gamepacket.EquipmentUpdateOK
+	err := c.s.accountsService.SetMascot(ctx, c.player.PlayerID, int64(mascotID), time.Now())
+	if errors.Is(err, accounts.ErrMascotNotOwned) {
+		log.Warn().Uint32("mascot", mascotID).Msg("rejected equipping mascot not owned by player")
+		status = gamepacket.EquipmentUpdateFailed
+	} else if err != nil {
+		return err
+	}
+	if err := c.fetchPlayer(ctx); err != nil {
+		return err
+	}
+	return c.SendMessage(ctx, &gamepacket.ServerPlayerEquipmentUpdated{
+		Status: status,
+		Type:   uint8(gamepacket.UpdatedMascot),
+		Mascot: &gamepacket.MascotUpdated{
+			MascotID: uint32(c.player.MascotID.Int64),
+		},
+	})
+}
+
+// setMascotText changes the message shown by one of the player's mascots.
+// Refused changes return the accounts service's mascot errors.
+//
+// TODO: the client's mascot message request and response aren't known yet,
+// so nothing calls this until they are identified.
+func (c *Conn) setMascotText(ctx context.Context, mascotID uint32, text string) error {
+	if err := c.s.accountsService.SetMascotText(ctx, c.player.PlayerID, int64(mascotID), text, time.Now()); err != nil {
+		return err
+	}
+	if mascotID != uint32(c.player.MascotID.Int64) {
+		return nil
+	}
+	// The equipped mascot's text is shown to others, so refresh the player.
+	c.triggerUpdate()
+	return nil
+}
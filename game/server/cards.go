@@ -0,0 +1,112 @@
+// Copyright (C) 2023, John Chadwick <john@jchw.io>, JMC47
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2023 John Chadwick, JMC47
+// SPDX-License-Identifier: ISC
+
+package gameserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/pangbox/server/database/accounts"
+	"github.com/pangbox/server/gameconfig"
+)
+
+// cardSlotKinds maps each kind of card to the slots it can be equipped to.
+var cardSlotKinds = map[gameconfig.CardKind]accounts.CardSlotKind{
+	gameconfig.CardKindCharacter: accounts.CardSlotCharacter,
+	gameconfig.CardKindCaddie:    accounts.CardSlotCaddie,
+	gameconfig.CardKindNPC:       accounts.CardSlotNPC,
+}
+
+type cardPack struct {
+	gameconfig.CardPack
+	cards *WeightedRand
+}
+
+func newCardPack(config gameconfig.CardPack) *cardPack {
+	pack := &cardPack{
+		CardPack: config,
+		cards:    NewWeightedRand(),
+	}
+	for _, card := range config.Cards {
+		pack.cards.Add(card.TypeID, card.Weight)
+	}
+	return pack
+}
+
+// draw picks the cards found in one pack.
+func (p *cardPack) draw() []uint32 {
+	cards := make([]uint32, p.NumCards)
+	for i := range cards {
+		cards[i] = p.cards.Choose()
+	}
+	return cards
+}
+
+// errCardPackUnavailable is returned when a card pack isn't configured or
+// has no cards that can be drawn.
+var errCardPackUnavailable = errors.New("card pack is not available")
+
+// openCardPack uses up one of the player's card packs and gives them the
+// cards drawn from it.
+//
+// TODO: the client's card pack packets aren't known yet, so nothing calls
+// this until they are identified.
+func (c *Conn) openCardPack(ctx context.Context, packTypeID uint32) ([]uint32, error) {
+	pack, ok := c.s.cardPacks[packTypeID]
+	if !ok || pack.NumCards == 0 || pack.cards.TotalWeight() <= 0 {
+		return nil, errCardPackUnavailable
+	}
+
+	cards := pack.draw()
+	cardTypeIDs := make([]int64, len(cards))
+	for i, card := range cards {
+		cardTypeIDs[i] = int64(card)
+	}
+	if _, err := c.s.accountsService.OpenCardPack(ctx, c.session.PlayerID, int64(packTypeID), cardTypeIDs); err != nil {
+		return nil, fmt.Errorf("opening card pack: %w", err)
+	}
+	return cards, c.sendInventory(ctx)
+}
+
+// equipCard puts a card from the inventory into a card slot.
+//
+// TODO: the client's card equip and unequip packets aren't known yet, so
+// nothing calls equipCard or unequipCard until they are identified.
+func (c *Conn) equipCard(ctx context.Context, slot accounts.CardSlot, cardTypeID uint32) error {
+	card, ok := c.s.configProvider.GetCard(cardTypeID)
+	if !ok {
+		return fmt.Errorf("card %08x is not configured", cardTypeID)
+	}
+	if kind, ok := cardSlotKinds[card.Kind]; !ok || kind != slot.Kind {
+		return accounts.ErrCardSlotInvalid
+	}
+
+	if err := c.s.accountsService.EquipCard(ctx, c.session.PlayerID, slot, int64(cardTypeID)); err != nil {
+		return fmt.Errorf("equipping card: %w", err)
+	}
+	return c.sendInventory(ctx)
+}
+
+// unequipCard takes a card out of a card slot.
+func (c *Conn) unequipCard(ctx context.Context, slot accounts.CardSlot) error {
+	if err := c.s.accountsService.UnequipCard(ctx, c.session.PlayerID, slot); err != nil {
+		return fmt.Errorf("unequipping card: %w", err)
+	}
+	return c.sendInventory(ctx)
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2023, John Chadwick <john@jchw.io>, JMC47
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2023 John Chadwick, JMC47
+// SPDX-License-Identifier: ISC
+
+package gameserver
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/pangbox/server/database/accounts"
+	gamepacket "github.com/pangbox/server/game/packet"
+	"github.com/pangbox/server/pangya"
+)
+
+// characterPart returns the character and slot of a part from its IFF entry.
+func (s *Server) characterPart(typeID uint32) (accounts.PartInfo, bool) {
+	part, ok := s.pangyaIFF.PartMap[typeID]
+	if !ok || !part.Active {
+		return accounts.PartInfo{}, false
+	}
+	return accounts.PartInfo{Character: part.Character(), Slot: part.Slot()}, true
+}
+
+// characterDefaults returns the default part type IDs of a character type.
+func (s *Server) characterDefaults(charTypeID uint32) [24]uint32 {
+	return s.configProvider.GetCharacterDefaults(uint8(charTypeID)).DefaultPartTypeIDs
+}
+
+func (c *Conn) setCharacterParts(ctx context.Context, data *pangya.PlayerCharacterData) error {
+	log := c.Log()
+
+	status := gamepacket.EquipmentUpdateOK
+	if c.s.pangyaIFF == nil || c.s.pangyaIFF.PartMap == nil {
+		log.Error().Uint32("character", data.ID).Msg("no part iff loaded to check character parts against")
+		status = gamepacket.EquipmentUpdateFailed
+	} else {
+		var charTypeID uint32
+		for _, character := range c.characters {
+			if character.ID == data.ID {
+				charTypeID = character.CharTypeID
+			}
+		}
+		err := c.s.accountsService.SetCharacterParts(ctx, c.player.PlayerID, *data, c.s.characterDefaults(charTypeID), c.s.characterPart, time.Now())
+		switch {
+		case err == nil:
+		case errors.Is(err, accounts.ErrCharacterNotFound),
+			errors.Is(err, accounts.ErrCharacterPartNotOwned),
+			errors.Is(err, accounts.ErrCharacterPartInvalid),
+			errors.Is(err, accounts.ErrCharacterAuxPartInvalid),
+			errors.Is(err, accounts.ErrCharacterCutInInvalid):
+			log.Warn().Err(err).Uint32("character", data.ID).Msg("rejected character parts, possible cheating")
+			status = gamepacket.EquipmentUpdateFailed
+		default:
+			return err
+		}
+	}
+
+	if err := c.fetchPlayer(ctx); err != nil {
+		return err
+	}
+	if err := c.fetchCharacters(ctx); err != nil {
+		return err
+	}
+	// On failure, send the character as it was so the client reverts.
+	character := c.currentCharacter
+	for i := range c.characters {
+		if c.characters[i].ID == data.ID {
+			character = &c.characters[i]
+		}
+	}
+	return c.SendMessage(ctx, &gamepacket.ServerPlayerEquipmentUpdated{
+		Status:    status,
+		Type:      uint8(gamepacket.UpdatedCharParts),
+		CharParts: character,
+	})
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2023, John Chadwick <john@jchw.io>, JMC47
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2023 John Chadwick, JMC47
+// SPDX-License-Identifier: ISC
+
+package gameserver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pangbox/server/database/accounts"
+	gamepacket "github.com/pangbox/server/game/packet"
+	"github.com/pangbox/server/pangya"
+)
+
+// defaultClubStats are the stats shown for club sets that aren't in the IFF.
+var defaultClubStats = [accounts.NumClubStats]uint16{8, 9, 8, 3, 3}
+
+// clubBaseStats returns the stats of a club set before any upgrades.
+func (s *Server) clubBaseStats(typeID uint32) [accounts.NumClubStats]uint16 {
+	if s.pangyaIFF != nil {
+		if data, ok := s.pangyaIFF.ClubSetMap[typeID]; ok {
+			return data.Stats
+		}
+	}
+	return defaultClubStats
+}
+
+// clubMaxLevel returns how many times a stat of a club set can be upgraded.
+// Without the club set in the IFF, only the configured upgrade costs limit
+// it.
+func (s *Server) clubMaxLevel(typeID uint32, stat accounts.ClubStat) int64 {
+	maxLevel := int64(len(s.configProvider.GetClubUpgrades().UpgradeCosts))
+	if s.pangyaIFF != nil {
+		if data, ok := s.pangyaIFF.ClubSetMap[typeID]; ok {
+			if n := int64(data.MaxUpgrades(int(stat))); n < maxLevel {
+				maxLevel = n
+			}
+		}
+	}
+	return maxLevel
+}
+
+// clubStats returns the stats of a club set with its upgrades applied.
+func (s *Server) clubStats(typeID uint32, levels accounts.ClubStats) pangya.ClubStats {
+	stats := pangya.ClubStats{UpgradeStats: s.clubBaseStats(typeID)}
+	for i, level := range levels {
+		stats.UpgradeStats[i] += uint16(level)
+	}
+	return stats
+}
+
+// upgradeClub raises a stat of one of the player's club sets by one level,
+// or lowers it if downgrade is set.
+//
+// TODO: the client's club upgrade request isn't known yet, so nothing calls
+// this until it is identified.
+func (c *Conn) upgradeClub(ctx context.Context, itemID uint32, stat accounts.ClubStat, downgrade bool) error {
+	club, err := c.s.accountsService.GetClub(ctx, c.session.PlayerID, int64(itemID))
+	if err != nil {
+		return fmt.Errorf("upgrading club: %w", err)
+	}
+
+	config := c.s.configProvider.GetClubUpgrades()
+	costs := config.UpgradeCosts
+	if downgrade {
+		costs = config.DowngradeCosts
+	}
+	upgrade := accounts.ClubUpgrade{
+		ItemID:     club.ItemID,
+		ItemTypeID: club.ItemTypeID,
+		Stat:       stat,
+		Downgrade:  downgrade,
+		MaxLevel:   c.s.clubMaxLevel(uint32(club.ItemTypeID), stat),
+		Costs:      make([]int64, len(costs)),
+	}
+	for i, cost := range costs {
+		upgrade.Costs[i] = int64(cost)
+	}
+	club, pang, err := c.s.accountsService.UpgradeClub(ctx, c.session.PlayerID, upgrade)
+	if err != nil {
+		return fmt.Errorf("upgrading club: %w", err)
+	}
+	c.player.Pang = pang
+
+	if c.player.ClubID.Int64 == club.ItemID {
+		// Refresh the equipped club's stats.
+		c.triggerUpdate()
+	}
+	return c.SendMessage(ctx, &gamepacket.ServerPangBalanceData{
+		PangsRemaining: uint64(pang),
+	})
+}
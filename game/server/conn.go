@@ -21,14 +21,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math/rand"
 	"time"
 
-	"github.com/pangbox/server/common"
 	"github.com/pangbox/server/database/accounts"
 	gamemodel "github.com/pangbox/server/game/model"
 	gamepacket "github.com/pangbox/server/game/packet"
 	"github.com/pangbox/server/game/room"
+	"github.com/pangbox/server/gameconfig"
 	"github.com/pangbox/server/gen/dbmodels"
 	"github.com/pangbox/server/pangya"
 )
@@ -38,11 +37,15 @@ type Conn struct {
 	*gamepacket.ServerConn
 	s *Server
 
-	connID       uint32
-	session      dbmodels.Session
-	player       dbmodels.GetPlayerRow
-	characters   []pangya.PlayerCharacterData
-	updatePlayer chan struct{}
+	connID        uint32
+	session       dbmodels.Session
+	player        dbmodels.GetPlayerRow
+	stats         dbmodels.PlayerStat
+	seasonHistory pangya.PlayerSeasonHistory
+	guild         dbmodels.GetPlayerGuildRow
+	characters    []pangya.PlayerCharacterData
+	updatePlayer  chan struct{}
+	lockerOpen    bool
 
 	currentCharacter *pangya.PlayerCharacterData
 
@@ -64,29 +67,16 @@ func (c *Conn) getLobbyPlayer() gamemodel.LobbyPlayer {
 		RoomNumber:       c.currentRoom.Number(),
 		Nickname:         c.player.Nickname.String,
 		Rank:             byte(c.player.Rank),
-		GuildEmblemImage: "guildmark",       // TODO
+		GuildEmblemImage: c.guild.EmblemImage,
 		GlobalID:         c.player.Username, // TODO
 	}
 }
 
 func (c *Conn) getRoomPlayer() *gamemodel.RoomPlayerEntry {
-	return &gamemodel.RoomPlayerEntry{
-		ConnID:           c.connID,
-		Nickname:         c.player.Nickname.String,
-		Rank:             uint8(c.player.Rank),
-		GuildName:        "",
-		CharTypeID:       c.currentCharacter.CharTypeID,
-		StatusFlags:      0,
-		GuildEmblemImage: "guildmark", // TODO
-		PlayerID:         uint32(c.player.PlayerID),
-		BackgroundTypeID: uint32(c.player.BackgroundTypeID.Int64),
-		FrameTypeID:      uint32(c.player.FrameTypeID.Int64),
-		StickerTypeID:    uint32(c.player.StickerTypeID.Int64),
-		SlotTypeID:       uint32(c.player.SlotTypeID.Int64),
-		CutInTypeID:      uint32(c.player.CutInTypeID.Int64),
-		TitleTypeID:      uint32(c.player.TitleTypeID.Int64),
-		CharacterData:    c.getPlayerEquippedCharacter(),
-	}
+	entry := roomPlayerFromDB(&c.player, &c.guild, c.connID)
+	entry.CharTypeID = c.currentCharacter.CharTypeID
+	entry.CharacterData = c.getPlayerEquippedCharacter()
+	return &entry
 }
 
 func (c *Conn) leaveRoom(ctx context.Context) error {
@@ -106,6 +96,53 @@ func (c *Conn) leaveRoom(ctx context.Context) error {
 	return nil
 }
 
+// disconnectRoom takes the player out of their room when their connection
+// is lost. If they were in a game, the room may keep their place so that
+// they can rejoin it when they log back in.
+func (c *Conn) disconnectRoom(ctx context.Context) error {
+	if c.currentRoom == nil {
+		return nil
+	}
+	promise, err := c.currentRoom.Send(ctx, room.RoomPlayerDisconnect{
+		ConnID: c.connID,
+	})
+	if err != nil {
+		return err
+	}
+	// If the room keeps the player's place, it tells the server so itself.
+	if _, err := promise.Wait(ctx); err != nil {
+		return err
+	}
+	c.currentRoom = nil
+	return nil
+}
+
+// rejoinRoom puts the player back into a game that was kept going for them
+// after they lost their connection, if there is one.
+func (c *Conn) rejoinRoom(ctx context.Context) error {
+	rejoin := c.s.takeRejoin(c.session.PlayerID)
+	if rejoin == nil {
+		return nil
+	}
+	promise, err := rejoin.Send(ctx, room.RoomPlayerReconnect{
+		PlayerID:   uint32(c.session.PlayerID),
+		Conn:       c.ServerConn,
+		UpdateFunc: c.triggerUpdate,
+	})
+	if err != nil {
+		return err
+	}
+	connID, err := promise.Wait(ctx)
+	if err != nil {
+		return err
+	}
+	// The room knows the player by the connection ID they had when they
+	// joined it.
+	c.connID = connID.(uint32)
+	c.currentRoom = rejoin
+	return nil
+}
+
 func (c *Conn) leaveMultiplayerLobby(ctx context.Context) error {
 	if c.currentLobby != nil {
 		promise, err := c.currentLobby.Send(ctx, room.LobbyPlayerLeave{
@@ -132,8 +169,11 @@ func (c *Conn) Handle(ctx context.Context) error {
 		return err
 	}
 
+	c.s.addConn(c)
+
 	defer func() {
-		c.leaveRoom(ctx)
+		c.s.removeConn(c)
+		c.disconnectRoom(ctx)
 		c.leaveMultiplayerLobby(ctx)
 	}()
 
@@ -145,10 +185,15 @@ func (c *Conn) Handle(ctx context.Context) error {
 
 		select {
 		case <-c.updatePlayer:
-			c.player, err = c.s.accountsService.GetPlayer(ctx, c.player.PlayerID)
-			if err != nil {
+			if err := c.fetchPlayer(ctx); err != nil {
 				return fmt.Errorf("updating player data: %w", err)
 			}
+			if err := c.fetchCharacters(ctx); err != nil {
+				return fmt.Errorf("updating character data: %w", err)
+			}
+			if err := c.sendAchievementProgress(ctx); err != nil {
+				return fmt.Errorf("updating achievement progress: %w", err)
+			}
 			if c.currentLobby != nil {
 				c.currentLobby.Send(ctx, room.LobbyPlayerUpdate{
 					Entry: c.getLobbyPlayer(),
@@ -185,6 +230,10 @@ func (c *Conn) Handle(ctx context.Context) error {
 			log.Debug().Msg("todo: online status")
 		case *gamepacket.ClientGetPlayerData:
 			player, err := c.s.accountsService.GetPlayer(ctx, int64(t.UserID))
+			var stats dbmodels.PlayerStat
+			if err == nil {
+				stats, err = c.s.accountsService.GetPlayerStats(ctx, int64(t.UserID))
+			}
 			if err != nil {
 				c.SendMessage(ctx, &gamepacket.ServerPlayerDataResponse{
 					Status:  2,
@@ -194,11 +243,18 @@ func (c *Conn) Handle(ctx context.Context) error {
 				break
 			}
 			if t.Request == 5 {
+				info := playerInfoFromDB(&player, 0) // TODO: set conn id somehow
+				if guild, err := c.s.accountsService.GetPlayerGuild(ctx, int64(t.UserID)); err == nil {
+					info.GuildName = guild.Name
+					info.GuildEmblemImage = guild.EmblemImage
+				} else if !errors.Is(err, accounts.ErrNotInGuild) {
+					log.Error().Err(err).Msg("failed to get player guild")
+				}
 				c.SendMessage(ctx, &gamepacket.ServerPlayerInfoResponse{
 					Request: t.Request,
 					UserID:  t.UserID,
 					RoomID:  0xFFFF,
-					Info:    playerInfoFromDB(&player, 0), // TODO: set conn id somehow
+					Info:    info,
 				})
 				c.SendMessage(ctx, &gamepacket.ServerPlayerCharacterResponse{
 					UserID:    t.UserID,
@@ -213,7 +269,7 @@ func (c *Conn) Handle(ctx context.Context) error {
 			c.SendMessage(ctx, &gamepacket.ServerPlayerStatisticsResponse{
 				Request: t.Request,
 				UserID:  t.UserID,
-				Stats:   playerStatsFromDB(&player),
+				Stats:   playerStatsFromDB(&player, &stats),
 			})
 			// TODO: Missing a lot of responses.
 			c.SendMessage(ctx, &gamepacket.ServerPlayerDataResponse{
@@ -383,44 +439,36 @@ func (c *Conn) Handle(ctx context.Context) error {
 				}
 			}
 		case *gamepacket.ClientRequestInboxList:
-			// TODO: need new sql message table
-			msg := &gamepacket.ServerInboxList{
-				PageNum:     t.PageNum,
-				NumPages:    1,
-				NumMessages: 1,
-				Messages: []gamepacket.InboxMessage{
-					{ID: 0x1, SenderNickname: "@Pangbox"},
-				},
+			if err := c.sendInbox(ctx, t.PageNum); err != nil {
+				return err
 			}
-			c.DebugMsg(msg)
-			c.SendMessage(ctx, msg)
 		case *gamepacket.ClientRequestInboxMessage:
-			c.SendMessage(ctx, &gamepacket.ServerMailMessage{
-				Message: gamepacket.MailMessage{
-					ID:             0x1,
-					SenderNickname: common.ToPString("@Pangbox"),
-					DateTime:       common.ToPString("2023-06-03 01:21:00"),
-					Message:        common.ToPString("Welcome to the first Pangbox server release! Not much works yet..."),
-				},
-			})
+			if err := c.sendMail(ctx, t.MessageID); err != nil {
+				return err
+			}
 		case *gamepacket.ClientJoinChannel:
 			c.SendMessage(ctx, &gamepacket.Server004E{Unknown: []byte{0x01}})
 			c.SendMessage(ctx, &gamepacket.Server01F6{Unknown: []byte{0x00, 0x00, 0x00, 0x00}})
-			c.SendMessage(ctx, &gamepacket.ServerLoginBonusStatus{Unknown: []byte{0x0, 0x0, 0x0, 0x0, 0x1, 0x4, 0x0, 0x0, 0x18, 0x3, 0x0, 0x0, 0x0, 0x27, 0x0, 0x0, 0x18, 0x3, 0x0, 0x0, 0x0, 0x5, 0x0, 0x0, 0x0}})
+			if err := c.sendLoginBonusStatus(ctx); err != nil {
+				return err
+			}
 		case *gamepacket.ClientRequestDailyReward:
-			c.SendMessage(ctx, &gamepacket.ServerMoneyUpdate{
-				Type: uint16(gamepacket.MoneyUpdateRewardUnknown),
-				RewardUnknown: &gamepacket.UpdateRewardUnknownData{
-					Unknown: 1,
-				},
-			})
+			if err := c.claimLoginBonus(ctx); err != nil {
+				return err
+			}
 		case *gamepacket.ClientRequestPlayerHistory:
-			c.SendMessage(ctx, &gamepacket.ServerPlayerHistory{})
+			if err := c.sendPlayerHistory(ctx); err != nil {
+				return err
+			}
 		case *gamepacket.ClientMultiplayerJoin:
 			if c.currentLobby != nil {
 				break
 			}
 			log.Debug().Msg("join lobby")
+			// Rejoin first, since it may change the connection ID.
+			if err := c.rejoinRoom(ctx); err != nil {
+				log.Debug().Err(err).Msg("could not rejoin game")
+			}
 			c.currentLobby = c.s.lobby
 			c.currentLobby.Send(ctx, room.LobbyPlayerJoin{
 				Entry: c.getLobbyPlayer(),
@@ -456,11 +504,12 @@ func (c *Conn) Handle(ctx context.Context) error {
 				break
 			}
 			c.currentRoom.Send(ctx, room.RoomGameHoleInfo{
-				Par:  t.Par,
-				TeeX: t.TeeX,
-				TeeZ: t.TeeZ,
-				PinX: t.PinX,
-				PinZ: t.PinZ,
+				ConnID: c.connID,
+				Par:    t.Par,
+				TeeX:   t.TeeX,
+				TeeZ:   t.TeeZ,
+				PinX:   t.PinX,
+				PinZ:   t.PinZ,
 			})
 		case *gamepacket.ClientRoomLeave:
 			if err := c.leaveRoom(ctx); err != nil {
@@ -496,117 +545,17 @@ func (c *Conn) Handle(ctx context.Context) error {
 			})
 
 		case *gamepacket.ClientAchievementStatusRequest:
-			c.SendMessage(ctx, &gamepacket.ServerAchievementStatusResponse{
-				// TODO: Actually support achievements.  This is the bare minimum not to hang/crash the game.
-				Remaining: 1,
-				Count:     1,
-				Groups: []gamepacket.AchievementGroup{
-					{
-						GroupID: 0x4c80002d,
-						ID:      248388034,
-						Count:   5,
-						Achievements: []gamepacket.Achievement{
-							{
-								ID:        0x7480087c,
-								Value:     1,
-								Timestamp: uint32(time.Date(2018, 11, 3, 0, 25, 10, 0, time.UTC).Unix()),
-							},
-							{
-								ID:        0x7480087d,
-								Value:     3,
-								Timestamp: uint32(time.Date(2018, 11, 7, 2, 5, 26, 0, time.UTC).Unix()),
-							},
-							{
-								ID:        0x7480087e,
-								Value:     5,
-								Timestamp: uint32(time.Date(2018, 11, 7, 3, 3, 45, 0, time.UTC).Unix()),
-							},
-							{
-								ID:        0x7480087f,
-								Value:     7,
-								Timestamp: uint32(time.Date(2018, 11, 8, 23, 27, 19, 0, time.UTC).Unix()),
-							},
-							{
-								ID:        0x74800880,
-								Value:     10,
-								Timestamp: uint32(time.Date(2019, 8, 25, 19, 39, 45, 0, time.UTC).Unix()),
-							},
-						},
-					},
-				},
-			})
-			c.SendMessage(ctx, &gamepacket.ServerAchievementUnknownResponse{
-				UnknownA: [4]byte{0x00, 0x00, 0x00, 0x00},
-			})
-		case *gamepacket.ClientBigPapelPlay:
-			c.SendMessage(ctx, &gamepacket.ServerBlackPapelResponse{
-				RemainingTurns: 50, // Displays as remaining turns in the box.
-				UnknownA:       -1,
-			})
-			// TODO: make Pang interaction transactional
-			// TODO: make items show up in inventory
-			// TODO: make sure not to subtract pang if a ticket is used.
-			// TODO: Fix pang able to go negative.
-			c.player.Pang, err = c.s.accountsService.AddPang(ctx, c.player.PlayerID, -10000)
-			if err != nil {
+			if err := c.sendAchievementStatus(ctx, int64(t.UserID)); err != nil {
 				return err
 			}
-			packet := &gamepacket.ServerBigPapelWinnings{}
-			const BigPapelItems = 10 // Unknown if this is actually how the number of items are actually chosen.
-			itemQuantities := make(map[uint32]int)
-			for i := 0; i < BigPapelItems; i++ {
-				typeID := c.s.papelShop.Choose()
-				rarity := c.s.papelRarity[typeID]
-				if rarity == 2 {
-					itemQuantities[typeID] = 1 // Technically should be possible to get multiple of the same rare item here.
-				} else {
-					itemQuantities[typeID] += 1 + rand.Intn(2)
-				}
-			}
-			for typeID, quantity := range itemQuantities {
-				item := gamepacket.ServerBlackPapelItems{}
-				item.ItemTypeID = typeID
-				item.Quantity = uint32(quantity)
-				packet.Items = append(packet.Items, item)
+		case *gamepacket.ClientBigPapelPlay:
+			if err := c.playBigPapel(ctx); err != nil {
+				return err
 			}
-			packet.PangsRemaining = uint64(c.player.Pang)     // This should be calculated by player data
-			packet.CookiesRemaining = uint64(c.player.Points) // Cookies remaining even if the action doesn't cost cookies
-			c.SendMessage(ctx, packet)
 		case *gamepacket.ClientBlackPapelPlay:
-			// TODO: make Pang interaction transactional.
-			// TODO: make items show up in inventory.
-			// TODO: make sure not to subtract pang if a ticket is used.
-			c.player.Pang, err = c.s.accountsService.AddPang(ctx, c.player.PlayerID, -500)
-			if err != nil {
+			if err := c.playBlackPapel(ctx); err != nil {
 				return err
 			}
-			packet := &gamepacket.ServerBlackPapelWinnings{}
-			drawnItemsSet := make(map[uint32]struct{})
-			for i := rand.Intn(4) + 1; i > 0; {
-				item := gamepacket.ServerBlackPapelItems{}
-				var typeID uint32
-				for {
-					typeID = c.s.papelShop.Choose()
-					if _, ok := drawnItemsSet[typeID]; !ok {
-						drawnItemsSet[typeID] = struct{}{}
-						break
-					}
-				}
-				item.ItemTypeID = typeID
-				item.DolfiniBallColor = uint32(rand.Intn(3))
-				item.Rarity = c.s.papelRarity[typeID]
-				if item.Rarity == 2 {
-					item.Quantity = 1
-				} else {
-					item.Quantity = uint32(rand.Intn(3) + 1)
-				}
-				packet.UniqueItemsWon += 1
-				packet.Items = append(packet.Items, item)
-				i--
-			}
-			packet.PangsRemaining = uint64(c.player.Pang)
-			packet.CookiesRemaining = uint64(c.player.Points)
-			c.SendMessage(ctx, packet)
 		case *gamepacket.ClientRoomUserEquipmentChange:
 			// TODO
 		case *gamepacket.ClientTutorialStart:
@@ -655,35 +604,21 @@ func (c *Conn) Handle(ctx context.Context) error {
 				Unknown: [6]byte{0x00, 0x01, 0x03, 0x00, 0x00, 0x00},
 			})
 		case *gamepacket.ClientEnterMyRoom:
-			if t.UserID != t.RoomUserID {
-				return errors.New("entering another user's myroom is not implemented yet")
+			if err := c.enterMyRoom(ctx, t.RoomUserID); err != nil {
+				return err
 			}
-			c.SendMessage(ctx, &gamepacket.ServerMyRoomEntered{
-				Unknown:  1,
-				UserID:   t.UserID,
-				Unknown2: 1,
-			})
 		case *gamepacket.ClientRequestInventory:
-			if t.UserID != uint32(c.session.PlayerID) {
-				return errors.New("wrong user ID specified in packet")
+			if err := c.sendMyRoom(ctx, t.UserID); err != nil {
+				return err
 			}
-			c.SendMessage(ctx, &gamepacket.ServerMyRoomLayout{
-				Unknown:        1,
-				FurnitureCount: 0,
-			})
-			c.SendMessage(ctx, &gamepacket.ServerPlayerInfo{
-				Player: *c.getRoomPlayer(),
-			})
 		case *gamepacket.ClientLockerCombinationAttempt:
-			// TODO
-			c.SendMessage(ctx, &gamepacket.ServerLockerCombinationResponse{
-				Status: 0,
-			})
+			if err := c.openLocker(ctx, t.Combination.Value); err != nil {
+				return err
+			}
 		case *gamepacket.ClientLockerInventoryRequest:
-			// This has something to do with the combination/password system.
-			c.SendMessage(ctx, &gamepacket.ServerLockerInventoryResponse{
-				Status: 76,
-			})
+			if err := c.sendLockerInventory(ctx); err != nil {
+				return err
+			}
 		case *gamepacket.Client00C1:
 			// Seems to happen when entering my room.
 			log.Debug().Msg("todo: 00C1 (my room?)")
@@ -693,38 +628,17 @@ func (c *Conn) Handle(ctx context.Context) error {
 		case *gamepacket.ClientEquipmentUpdate:
 			switch {
 			case t.CharParts != nil:
-				if err := c.s.accountsService.SetCharacterParts(
-					ctx,
-					c.player.PlayerID,
-					*t.CharParts,
-				); err != nil {
-					return err
-				}
-				if err := c.fetchPlayer(ctx); err != nil {
-					return err
-				}
-				if err := c.SendMessage(ctx, &gamepacket.ServerPlayerEquipmentUpdated{
-					Status:    0x04,
-					Type:      uint8(gamepacket.UpdatedCharParts),
-					CharParts: c.currentCharacter,
-				}); err != nil {
+				if err := c.setCharacterParts(ctx, t.CharParts); err != nil {
 					return err
 				}
 
 			case t.Caddie != nil:
-				if err := c.s.accountsService.SetCaddie(ctx, c.player.PlayerID, int64(t.Caddie.CaddieID)); err != nil {
-					return err
-				}
-				if err := c.fetchPlayer(ctx); err != nil {
+				if err := c.setCaddie(ctx, t.Caddie.CaddieID); err != nil {
 					return err
 				}
-				if err := c.SendMessage(ctx, &gamepacket.ServerPlayerEquipmentUpdated{
-					Status: 0x04,
-					Type:   uint8(gamepacket.UpdatedCaddie),
-					Caddie: &gamepacket.CaddieUpdated{
-						CaddieID: t.Caddie.CaddieID,
-					},
-				}); err != nil {
+
+			case t.Mascot != nil:
+				if err := c.setMascot(ctx, t.Mascot.MascotID); err != nil {
 					return err
 				}
 
@@ -733,7 +647,7 @@ func (c *Conn) Handle(ctx context.Context) error {
 					return err
 				}
 				if err := c.SendMessage(ctx, &gamepacket.ServerPlayerEquipmentUpdated{
-					Status: 0x04,
+					Status: gamepacket.EquipmentUpdateOK,
 					Type:   uint8(gamepacket.UpdatedConsumables),
 					Consumables: &gamepacket.ConsumablesUpdated{
 						ItemTypeID: c.getPlayerEquippedConsumables(),
@@ -748,7 +662,7 @@ func (c *Conn) Handle(ctx context.Context) error {
 					log.Error().Err(err).Msg("attempt to set comet failed")
 				}
 				if err := c.SendMessage(ctx, &gamepacket.ServerPlayerEquipmentUpdated{
-					Status: 0x04,
+					Status: gamepacket.EquipmentUpdateOK,
 					Type:   uint8(gamepacket.UpdatedComet),
 					Comet: &gamepacket.CometUpdated{
 						ItemID:     uint32(cometID),
@@ -773,7 +687,7 @@ func (c *Conn) Handle(ctx context.Context) error {
 					return err
 				}
 				if err := c.SendMessage(ctx, &gamepacket.ServerPlayerEquipmentUpdated{
-					Status: 0x04,
+					Status: gamepacket.EquipmentUpdateOK,
 					Type:   uint8(gamepacket.UpdatedDecoration),
 					Decoration: &gamepacket.DecorationUpdated{
 						BackgroundTypeID: uint32(c.player.BackgroundTypeID.Int64),
@@ -796,7 +710,7 @@ func (c *Conn) Handle(ctx context.Context) error {
 				}
 				c.refreshCurrentCharacter()
 				if err := c.SendMessage(ctx, &gamepacket.ServerPlayerEquipmentUpdated{
-					Status:    0x04,
+					Status:    gamepacket.EquipmentUpdateOK,
 					Type:      uint8(gamepacket.UpdatedCharParts),
 					CharParts: c.currentCharacter,
 				}); err != nil {
@@ -820,24 +734,51 @@ func (c *Conn) Handle(ctx context.Context) error {
 		case *gamepacket.ClientBuyItem:
 			pangTotal := int64(0)
 			pointTotal := int64(0)
+			status := gamepacket.PurchaseOK
+			now := time.Now()
 			for _, item := range t.Items {
-				// TODO: validate with iff
+				if status, err = c.s.checkPurchase(item, now); err != nil {
+					log.Warn().
+						Err(err).
+						Int64("player", c.player.PlayerID).
+						Msg("rejected shop purchase, possible cheating")
+					break
+				}
 				pangTotal += int64(item.ItemCostPang)
 				pointTotal += int64(item.ItemCostPoint)
 			}
-			if pangTotal > c.player.Pang || pointTotal > c.player.Points {
+			if status == gamepacket.PurchaseOK && (pangTotal > c.player.Pang || pointTotal > c.player.Points) {
+				status = gamepacket.PurchaseInsufficientFunds
+			}
+			if status != gamepacket.PurchaseOK {
 				c.SendMessage(ctx, &gamepacket.ServerPurchaseItemResponse{
-					Status: 1,
+					Status: status,
+					Pang:   uint64(c.player.Pang),
+					Points: uint64(c.player.Points),
 				})
 				continue
 			}
-			newCurrency := dbmodels.SetPlayerCurrencyRow{}
-			for _, item := range t.Items {
-				newCurrency, err = c.s.accountsService.PurchaseItem(ctx, c.session.PlayerID, int64(item.ItemCostPang), int64(item.ItemCostPoint), int64(item.ItemTypeID), int64(item.Quantity))
-				if err != nil {
-					return fmt.Errorf("purchasing item %v: %w", item.ItemTypeID, err)
+			newCurrency, err := c.purchaseItems(ctx, t.Items)
+			if errors.Is(err, accounts.ErrInsufficientFunds) || errors.Is(err, accounts.ErrRareItemSoldOut) {
+				// Balance or stock changed underneath us; nothing was bought.
+				status := gamepacket.PurchaseInsufficientFunds
+				if errors.Is(err, accounts.ErrRareItemSoldOut) {
+					status = gamepacket.PurchaseItemUnavailable
+				}
+				if err := c.fetchPlayer(ctx); err != nil {
+					return err
 				}
+				c.SendMessage(ctx, &gamepacket.ServerPurchaseItemResponse{
+					Status: status,
+					Pang:   uint64(c.player.Pang),
+					Points: uint64(c.player.Points),
+				})
+				continue
+			} else if err != nil {
+				return fmt.Errorf("purchasing items: %w", err)
 			}
+			c.player.Pang = newCurrency.Pang
+			c.player.Points = newCurrency.Points
 			if err := c.SendMessage(ctx, &gamepacket.ServerPangBalanceData{
 				PangsRemaining: uint64(newCurrency.Pang),
 				PangsSpent:     uint64(pangTotal),
@@ -850,7 +791,7 @@ func (c *Conn) Handle(ctx context.Context) error {
 				return err
 			}
 			if err := c.SendMessage(ctx, &gamepacket.ServerPurchaseItemResponse{
-				Status: 0,
+				Status: gamepacket.PurchaseOK,
 				Pang:   uint64(newCurrency.Pang),
 				Points: uint64(newCurrency.Points),
 			}); err != nil {
@@ -859,23 +800,23 @@ func (c *Conn) Handle(ctx context.Context) error {
 			c.sendInventory(ctx)
 			c.fetchCharacters(ctx)
 			c.sendCharacterData(ctx)
+			if err := c.recordAchievementEvent(ctx, gameconfig.AchievementEventItemPurchased, uint32(len(t.Items))); err != nil {
+				log.Error().Err(err).Msg("failed recording item purchase achievements")
+			}
 		case *gamepacket.Client004F:
 			// ignore
 		case *gamepacket.ClientQuestStatusRequest:
-			// TODO
-			c.SendMessage(ctx, &gamepacket.ServerQuestStatus{
-				Status: 1,
-			})
+			if err := c.sendQuestStatus(ctx); err != nil {
+				return err
+			}
 		case *gamepacket.ClientGuildListRequest:
-			// TODO
-			c.SendMessage(ctx, &gamepacket.ServerGuildListPage{
-				Status: 1,
-			})
+			if err := c.sendGuildList(ctx, t.Page); err != nil {
+				return err
+			}
 		case *gamepacket.ClientScratchyMenuOpen:
-			// TODO
-			c.SendMessage(ctx, &gamepacket.ServerScratchyMenuResponse{
-				Status: 1,
-			})
+			if err := c.openScratchyMenu(ctx); err != nil {
+				return err
+			}
 		default:
 			return fmt.Errorf("unexpected message: %T", t)
 		}
@@ -0,0 +1,74 @@
+// Copyright (C) 2023, John Chadwick <john@jchw.io>, JMC47
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2023 John Chadwick, JMC47
+// SPDX-License-Identifier: ISC
+
+package gameserver
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	expireItemsInterval  = time.Minute
+	expireItemsBatchSize = 100
+)
+
+// expireItemsTask periodically removes time-limited items that have expired
+// and refreshes the inventory of affected players that are online.
+func (s *Server) expireItemsTask(ctx context.Context) {
+	ticker := time.NewTicker(expireItemsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		affected := make(map[int64]struct{})
+		for {
+			items, err := s.accountsService.RemoveExpiredItems(ctx, time.Now(), expireItemsBatchSize, s.characterDefaults)
+			if err != nil {
+				s.log.Error().Err(err).Msg("error removing expired items")
+				break
+			}
+			for _, item := range items {
+				s.log.Debug().
+					Int64("player", item.PlayerID).
+					Int64("item", item.ItemID).
+					Int64("type", item.ItemTypeID).
+					Msg("item expired")
+				affected[item.PlayerID] = struct{}{}
+			}
+			if len(items) < expireItemsBatchSize {
+				break
+			}
+		}
+
+		for playerID := range affected {
+			conn := s.getConn(playerID)
+			if conn == nil {
+				continue
+			}
+			if err := conn.sendInventory(ctx); err != nil {
+				s.log.Error().Err(err).Msg("error sending inventory after item expiry")
+			}
+			conn.triggerUpdate()
+		}
+	}
+}
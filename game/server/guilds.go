@@ -0,0 +1,60 @@
+// Copyright (C) 2023, John Chadwick <john@jchw.io>, JMC47
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2023 John Chadwick, JMC47
+// SPDX-License-Identifier: ISC
+
+package gameserver
+
+import (
+	"context"
+	"fmt"
+
+	gamepacket "github.com/pangbox/server/game/packet"
+	"github.com/pangbox/server/pangya"
+)
+
+const guildListPageSize = 10
+
+func (c *Conn) sendGuildList(ctx context.Context, page uint32) error {
+	guilds, numPages, err := c.s.accountsService.GetGuildList(ctx, int64(page), guildListPageSize)
+	if err != nil {
+		return fmt.Errorf("getting guild list: %w", err)
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	msg := &gamepacket.ServerGuildListPage{
+		Page:       page,
+		NumPages:   uint32(numPages),
+		GuildCount: uint16(len(guilds)),
+		Guilds:     make([]pangya.GuildData, 0, len(guilds)),
+	}
+	for _, guild := range guilds {
+		data := pangya.GuildData{
+			GuildID:          uint32(guild.GuildID),
+			GuildName:        truncate(guild.Name, 17),
+			Pang:             uint32(guild.Pang),
+			Point:            uint32(guild.Points),
+			NumMembers:       uint32(guild.NumMembers),
+			LeaderUserID:     uint32(guild.LeaderPlayerID),
+			LeaderNickname:   guild.LeaderNickname.String,
+			GuildEmblemImage: guild.EmblemImage,
+		}
+		copy(data.Description[:len(data.Description)-1], guild.Description)
+		msg.Guilds = append(msg.Guilds, data)
+	}
+	return c.SendMessage(ctx, msg)
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2023, John Chadwick <john@jchw.io>, JMC47
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2023 John Chadwick, JMC47
+// SPDX-License-Identifier: ISC
+
+package gameserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pangbox/server/database/accounts"
+	gamepacket "github.com/pangbox/server/game/packet"
+)
+
+// errLockerClosed is returned by locker operations when the player hasn't
+// entered their combination yet.
+var errLockerClosed = errors.New("locker is not open")
+
+// openLocker checks the combination the player entered. The first
+// combination a player enters becomes their locker combination.
+func (c *Conn) openLocker(ctx context.Context, combination string) error {
+	log := c.Log()
+
+	now := time.Now()
+	set, err := c.s.accountsService.HasLockerPIN(ctx, c.session.PlayerID)
+	if err != nil {
+		return err
+	}
+	if !set {
+		err = c.s.accountsService.SetLockerPIN(ctx, c.session.PlayerID, "", combination, now)
+	} else {
+		err = c.s.accountsService.OpenLocker(ctx, c.session.PlayerID, combination, now)
+	}
+	if errors.Is(err, accounts.ErrLockerPINIncorrect) || errors.Is(err, accounts.ErrLockerLocked) {
+		// TODO: the status the client expects for a wrong combination isn't
+		// known, so rejected combinations go unanswered until it is.
+		log.Debug().Err(err).Msg("rejected locker combination")
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("opening locker: %w", err)
+	}
+	c.lockerOpen = true
+	return c.SendMessage(ctx, &gamepacket.ServerLockerCombinationResponse{
+		Status: 0,
+	})
+}
+
+// changeLockerCombination changes the player's locker combination.
+//
+// TODO: the client's combination change packet isn't known yet, so nothing
+// calls this until it is identified.
+func (c *Conn) changeLockerCombination(ctx context.Context, oldCombination, newCombination string) error {
+	if err := c.s.accountsService.SetLockerPIN(ctx, c.session.PlayerID, oldCombination, newCombination, time.Now()); err != nil {
+		return fmt.Errorf("changing locker combination: %w", err)
+	}
+	return nil
+}
+
+// sendLockerInventory answers a request for the locker's contents.
+//
+// TODO: the layout of the locker contents isn't known yet, so this always
+// sends the status that has something to do with the combination/password
+// system.
+func (c *Conn) sendLockerInventory(ctx context.Context) error {
+	return c.SendMessage(ctx, &gamepacket.ServerLockerInventoryResponse{
+		Status: 76,
+	})
+}
+
+// putLockerItem moves an inventory item into the locker and returns the
+// stored item.
+//
+// TODO: the client's locker packets aren't known yet, so nothing calls this
+// until they are identified.
+func (c *Conn) putLockerItem(ctx context.Context, itemID uint32) (int64, error) {
+	if !c.lockerOpen {
+		return 0, errLockerClosed
+	}
+
+	lockerItem, err := c.s.accountsService.PutLockerItem(ctx, c.session.PlayerID, int64(itemID), c.s.characterDefaults)
+	if err != nil {
+		return 0, fmt.Errorf("putting item in locker: %w", err)
+	}
+
+	// The item may have been equipped.
+	c.triggerUpdate()
+	return lockerItem.ItemID, c.sendInventory(ctx)
+}
+
+// takeLockerItem moves an item out of the locker and back into the
+// inventory.
+//
+// TODO: the client's locker packets aren't known yet, so nothing calls this
+// until they are identified.
+func (c *Conn) takeLockerItem(ctx context.Context, lockerItemID uint32) error {
+	if !c.lockerOpen {
+		return errLockerClosed
+	}
+
+	if err := c.s.accountsService.TakeLockerItem(ctx, c.session.PlayerID, int64(lockerItemID)); err != nil {
+		return fmt.Errorf("taking item from locker: %w", err)
+	}
+	return c.sendInventory(ctx)
+}
+
+// transferLockerPang deposits Pang into the locker, or withdraws it.
+//
+// TODO: the client's locker packets aren't known yet, so nothing calls this
+// until they are identified.
+func (c *Conn) transferLockerPang(ctx context.Context, withdraw bool, amount uint64) error {
+	if !c.lockerOpen {
+		return errLockerClosed
+	}
+
+	delta := int64(amount)
+	if withdraw {
+		delta = -delta
+	}
+	pang, _, err := c.s.accountsService.MoveLockerPang(ctx, c.session.PlayerID, delta)
+	if err != nil {
+		return fmt.Errorf("moving locker pang: %w", err)
+	}
+	c.player.Pang = pang
+	return c.SendMessage(ctx, &gamepacket.ServerPangBalanceData{
+		PangsRemaining: uint64(pang),
+	})
+}
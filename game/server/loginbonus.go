@@ -0,0 +1,97 @@
+// Copyright (C) 2023, John Chadwick <john@jchw.io>, JMC47
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2023 John Chadwick, JMC47
+// SPDX-License-Identifier: ISC
+
+package gameserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pangbox/server/database/accounts"
+	gamepacket "github.com/pangbox/server/game/packet"
+	"github.com/pangbox/server/gameconfig"
+)
+
+func loginRewardsFromConfig(calendar gameconfig.LoginBonusCalendar) []accounts.LoginReward {
+	rewards := make([]accounts.LoginReward, 0, len(calendar.Rewards))
+	for _, reward := range calendar.Rewards {
+		rewards = append(rewards, accounts.LoginReward{
+			ItemTypeID: int64(reward.ItemTypeID),
+			Quantity:   int64(reward.Quantity),
+			Pang:       int64(reward.Pang),
+		})
+	}
+	return rewards
+}
+
+// loginRewardDisplay returns the item type and quantity to show for a login
+// reward. Pang-only rewards are shown as the Pang item.
+func loginRewardDisplay(reward gameconfig.LoginBonusReward) (uint32, uint32) {
+	if reward.ItemTypeID != 0 {
+		return reward.ItemTypeID, reward.Quantity
+	}
+	return pangItemTypeID, uint32(reward.Pang)
+}
+
+func (c *Conn) sendLoginBonusStatus(ctx context.Context) error {
+	now := time.Now()
+	calendar, ok := c.s.configProvider.GetLoginBonusCalendar(now)
+	if !ok {
+		return c.SendMessage(ctx, &gamepacket.ServerLoginBonusStatus{Status: 1})
+	}
+
+	status, err := c.s.accountsService.GetLoginBonusStatus(ctx, c.session.PlayerID, calendar.Name, now)
+	if err != nil {
+		return fmt.Errorf("getting login bonus status: %w", err)
+	}
+
+	day := status.Streak
+	if status.ClaimedToday {
+		day--
+	}
+	numRewards := int64(len(calendar.Rewards))
+	msg := &gamepacket.ServerLoginBonusStatus{
+		ClaimedToday: status.ClaimedToday,
+		Streak:       uint32(status.Streak),
+	}
+	msg.ItemTypeID, msg.Quantity = loginRewardDisplay(calendar.Rewards[day%numRewards])
+	msg.NextItemTypeID, msg.NextQuantity = loginRewardDisplay(calendar.Rewards[(day+1)%numRewards])
+	return c.SendMessage(ctx, msg)
+}
+
+func (c *Conn) claimLoginBonus(ctx context.Context) error {
+	now := time.Now()
+	calendar, ok := c.s.configProvider.GetLoginBonusCalendar(now)
+	if !ok {
+		return c.sendLoginBonusStatus(ctx)
+	}
+
+	_, reward, err := c.s.accountsService.ClaimLoginBonus(ctx, c.session.PlayerID, calendar.Name, loginRewardsFromConfig(calendar), now)
+	if errors.Is(err, accounts.ErrLoginBonusClaimed) {
+		return c.sendLoginBonusStatus(ctx)
+	} else if err != nil {
+		return fmt.Errorf("claiming login bonus: %w", err)
+	}
+
+	if err := c.sendRewardUpdates(ctx, reward.ItemTypeID != 0, reward.Pang != 0); err != nil {
+		return err
+	}
+
+	return c.sendLoginBonusStatus(ctx)
+}
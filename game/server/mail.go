@@ -0,0 +1,150 @@
+// Copyright (C) 2023, John Chadwick <john@jchw.io>, JMC47
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2023 John Chadwick, JMC47
+// SPDX-License-Identifier: ISC
+
+package gameserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pangbox/server/common"
+	"github.com/pangbox/server/database/accounts"
+	gamepacket "github.com/pangbox/server/game/packet"
+)
+
+const (
+	inboxPageSize = 20
+
+	// pangItemTypeID is the item type the client uses to display Pang
+	// attached to a mail.
+	pangItemTypeID = 0x1A000010
+
+	mailDateTimeFormat = "2006-01-02 15:04:05"
+)
+
+func mailAttachments(mail accounts.InboxMail) []gamepacket.MessageAttachment {
+	attachments := make([]gamepacket.MessageAttachment, 0, len(mail.Attachments)+1)
+	for _, attachment := range mail.Attachments {
+		quantity := attachment.Quantity
+		if quantity == 0 {
+			quantity = 1
+		}
+		attachments = append(attachments, gamepacket.MessageAttachment{
+			ID:           uint32(attachment.AttachmentID),
+			ItemID:       uint32(attachment.ItemTypeID),
+			ItemQuantity: uint32(quantity),
+		})
+	}
+	if mail.Pang != 0 {
+		attachments = append(attachments, gamepacket.MessageAttachment{
+			ItemID:       pangItemTypeID,
+			ItemQuantity: uint32(mail.Pang),
+		})
+	}
+	return attachments
+}
+
+// truncate cuts a string so that it fits in a fixed-size, NUL-terminated
+// field of n bytes.
+func truncate(s string, n int) string {
+	if len(s) >= n {
+		return s[:n-1]
+	}
+	return s
+}
+
+func (c *Conn) sendInbox(ctx context.Context, page uint32) error {
+	mails, numPages, err := c.s.accountsService.GetInbox(ctx, c.session.PlayerID, int64(page), inboxPageSize)
+	if err != nil {
+		return fmt.Errorf("getting inbox: %w", err)
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	msg := &gamepacket.ServerInboxList{
+		PageNum:  page,
+		NumPages: uint32(numPages),
+		Messages: make([]gamepacket.InboxMessage, 0, len(mails)),
+	}
+	for _, mail := range mails {
+		msg.Messages = append(msg.Messages, gamepacket.InboxMessage{
+			ID:             uint32(mail.MailID),
+			SenderNickname: truncate(mail.SenderNickname, 30),
+			Message:        truncate(mail.Body, 80),
+			Attachments:    mailAttachments(mail),
+		})
+	}
+	return c.SendMessage(ctx, msg)
+}
+
+func (c *Conn) sendMail(ctx context.Context, mailID uint32) error {
+	mail, err := c.s.accountsService.ReadMail(ctx, c.session.PlayerID, int64(mailID))
+	if errors.Is(err, accounts.ErrMailNotFound) {
+		return c.SendMessage(ctx, &gamepacket.ServerMailMessage{Status: 1})
+	} else if err != nil {
+		return fmt.Errorf("reading mail: %w", err)
+	}
+
+	if err := c.SendMessage(ctx, &gamepacket.ServerMailMessage{
+		Message: gamepacket.MailMessage{
+			ID:             uint32(mail.MailID),
+			SenderNickname: common.ToPString(mail.SenderNickname),
+			DateTime:       common.ToPString(time.Unix(mail.CreatedAt, 0).Format(mailDateTimeFormat)),
+			Message:        common.ToPString(mail.Body),
+			Attachments:    mailAttachments(mail),
+		},
+	}); err != nil {
+		return err
+	}
+
+	if !mail.Claimed && (len(mail.Attachments) > 0 || mail.Pang != 0) {
+		if err := c.fetchPlayer(ctx); err != nil {
+			return err
+		}
+		if err := c.sendInventory(ctx); err != nil {
+			return err
+		}
+		if err := c.SendMessage(ctx, &gamepacket.ServerPangBalanceData{
+			PangsRemaining: uint64(c.player.Pang),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sendInboxNotify tells the player that they have unread mail.
+//
+// TODO: the layout of ServerInboxNotify isn't known yet, so nothing is sent
+// and players only see new mail when they next open their inbox.
+func (c *Conn) sendInboxNotify(ctx context.Context) error {
+	return nil
+}
+
+// NotifyMail tells a player that they have new mail, if they are connected to
+// this server.
+func (s *Server) NotifyMail(ctx context.Context, playerID int64) error {
+	conn := s.getConn(playerID)
+	if conn == nil {
+		return nil
+	}
+	return conn.sendInboxNotify(ctx)
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2023, John Chadwick <john@jchw.io>, JMC47
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2023 John Chadwick, JMC47
+// SPDX-License-Identifier: ISC
+
+package gameserver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/pangbox/server/database/accounts"
+	gamepacket "github.com/pangbox/server/game/packet"
+)
+
+// enterMyRoom enters the My Room of the given player, which may be the
+// player's own room or another player's.
+func (c *Conn) enterMyRoom(ctx context.Context, ownerID uint32) error {
+	if _, err := c.s.accountsService.GetPlayer(ctx, int64(ownerID)); errors.Is(err, sql.ErrNoRows) {
+		log := c.Log()
+		log.Warn().Uint32("owner", ownerID).Msg("tried to enter my room of unknown player")
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("getting my room owner: %w", err)
+	}
+	return c.SendMessage(ctx, &gamepacket.ServerMyRoomEntered{
+		Unknown:  1,
+		UserID:   ownerID,
+		Unknown2: 1,
+	})
+}
+
+// sendMyRoom sends the furniture layout and owner of a My Room.
+func (c *Conn) sendMyRoom(ctx context.Context, ownerID uint32) error {
+	if err := c.sendMyRoomLayout(ctx, int64(ownerID)); err != nil {
+		return err
+	}
+
+	if int64(ownerID) == c.session.PlayerID {
+		return c.SendMessage(ctx, &gamepacket.ServerPlayerInfo{
+			Player: *c.getRoomPlayer(),
+		})
+	}
+
+	owner, err := c.s.accountsService.GetPlayer(ctx, int64(ownerID))
+	if err != nil {
+		return fmt.Errorf("getting my room owner: %w", err)
+	}
+	guild, err := c.s.accountsService.GetPlayerGuild(ctx, int64(ownerID))
+	if err != nil && !errors.Is(err, accounts.ErrNotInGuild) {
+		return fmt.Errorf("getting my room owner guild: %w", err)
+	}
+	// TODO: set conn id if the owner is online.
+	return c.SendMessage(ctx, &gamepacket.ServerPlayerInfo{
+		Player: roomPlayerFromDB(&owner, &guild, 0),
+	})
+}
+
+// sendMyRoomLayout sends the furniture layout of a My Room.
+//
+// TODO: where FurnitureItem holds the position of the furniture isn't known
+// yet, so the saved layout isn't sent until it is identified.
+func (c *Conn) sendMyRoomLayout(ctx context.Context, ownerID int64) error {
+	return c.SendMessage(ctx, &gamepacket.ServerMyRoomLayout{
+		Unknown:        1,
+		FurnitureCount: 0,
+	})
+}
+
+// updateMyRoomLayout saves the furniture layout of the player's own My Room.
+//
+// TODO: the client's layout update packet isn't known yet, so nothing calls
+// this until it is identified.
+func (c *Conn) updateMyRoomLayout(ctx context.Context, layout []accounts.FurniturePlacement) error {
+	err := c.s.accountsService.SetMyRoomLayout(ctx, c.session.PlayerID, layout)
+	if errors.Is(err, accounts.ErrFurnitureNotOwned) {
+		log := c.Log()
+		log.Warn().
+			Err(err).
+			Int64("player", c.player.PlayerID).
+			Msg("rejected my room layout, possible cheating")
+		return err
+	} else if err != nil {
+		return fmt.Errorf("saving my room layout: %w", err)
+	}
+	return nil
+}
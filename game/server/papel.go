@@ -0,0 +1,260 @@
+// Copyright (C) 2023, John Chadwick <john@jchw.io>, JMC47
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2023 John Chadwick, JMC47
+// SPDX-License-Identifier: ISC
+
+package gameserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/pangbox/server/database/accounts"
+	gamepacket "github.com/pangbox/server/game/packet"
+	"github.com/pangbox/server/gameconfig"
+)
+
+// papelPool is a Papel Shop pool prepared for drawing items.
+type papelPool struct {
+	gameconfig.PapelPool
+	items  *WeightedRand
+	rare   *WeightedRand
+	rarity map[uint32]uint32
+}
+
+func newPapelPool(config gameconfig.PapelPool) *papelPool {
+	pool := &papelPool{
+		PapelPool: config,
+		items:     NewWeightedRand(),
+		rarity:    make(map[uint32]uint32),
+	}
+	for _, item := range config.Items {
+		pool.items.Add(item.TypeID, item.Weight)
+		pool.rarity[item.TypeID] = item.Rarity
+		if config.PityPlays > 0 && item.Rarity >= config.PityRarity {
+			if pool.rare == nil {
+				pool.rare = NewWeightedRand()
+			}
+			pool.rare.Add(item.TypeID, item.Weight)
+		}
+	}
+	return pool
+}
+
+func (p *papelPool) isRare(typeID uint32) bool {
+	return p.PityPlays > 0 && p.rarity[typeID] >= p.PityRarity
+}
+
+// pityDue returns true if the next play is guaranteed a rare item, given the
+// number of plays since the player last won one.
+func (p *papelPool) pityDue(plays int64) bool {
+	return p.PityPlays > 0 && plays+1 >= int64(p.PityPlays)
+}
+
+func randRange(rng *rand.Rand, min, max uint32) uint32 {
+	if max <= min {
+		return min
+	}
+	return min + uint32(rng.Intn(int(max-min+1)))
+}
+
+// draw draws the items won from one play. If guaranteeRare is set, the last
+// draw is taken from the rare items unless one was already won. With
+// UniqueItems, items already drawn are left out of later draws, and drawing
+// stops early if there is nothing left to draw. All randomness comes from
+// rng.
+func (p *papelPool) draw(rng *rand.Rand, guaranteeRare bool) (items []gamepacket.ServerBlackPapelItems, rare bool) {
+	n := int(randRange(rng, uint32(p.MinDraws), uint32(p.MaxDraws)))
+	if n < 1 {
+		n = 1
+	}
+	itemIndex := make(map[uint32]int)
+	drawn := func(typeID uint32) bool {
+		_, ok := itemIndex[typeID]
+		return ok
+	}
+	for i := 0; i < n; i++ {
+		choices := p.items
+		if guaranteeRare && !rare && p.rare != nil && i == n-1 {
+			choices = p.rare
+		}
+		if p.UniqueItems {
+			choices = choices.Without(drawn)
+		}
+		if choices.TotalWeight() <= 0 {
+			break
+		}
+		typeID := choices.ChooseRand(rng)
+		index, ok := itemIndex[typeID]
+		if !ok {
+			index = len(items)
+			itemIndex[typeID] = index
+			items = append(items, gamepacket.ServerBlackPapelItems{
+				ItemTypeID:       typeID,
+				DolfiniBallColor: uint32(rng.Intn(3)),
+				Rarity:           p.rarity[typeID],
+			})
+		}
+		rule := p.QuantityRule(p.rarity[typeID])
+		if rule.Permanent {
+			items[index].Quantity++
+		} else {
+			items[index].Quantity += randRange(rng, rule.MinQuantity, rule.MaxQuantity)
+		}
+		rare = rare || p.isRare(typeID)
+	}
+	return items, rare
+}
+
+// inventoryItems converts the items won from a play into the items to add to
+// the player's inventory.
+func (p *papelPool) inventoryItems(items []gamepacket.ServerBlackPapelItems) []accounts.PapelItem {
+	result := make([]accounts.PapelItem, 0, len(items))
+	for _, item := range items {
+		if p.QuantityRule(item.Rarity).Permanent {
+			for i := uint32(0); i < item.Quantity; i++ {
+				result = append(result, accounts.PapelItem{ItemTypeID: int64(item.ItemTypeID)})
+			}
+		} else {
+			result = append(result, accounts.PapelItem{
+				ItemTypeID: int64(item.ItemTypeID),
+				Quantity:   int64(item.Quantity),
+			})
+		}
+	}
+	return result
+}
+
+// papelOutcome is the outcome of a successful Papel Shop play.
+type papelOutcome struct {
+	Cost   int64
+	Items  []gamepacket.ServerBlackPapelItems
+	Result accounts.PapelResult
+}
+
+// playPapel draws items from the pool currently active for machine, pays for
+// the play and delivers the items won. If no pool is active or the player
+// can't pay, ok is false and nothing is given.
+func (c *Conn) playPapel(ctx context.Context, machine gameconfig.PapelMachine) (outcome papelOutcome, ok bool, err error) {
+	config, ok := c.s.configProvider.GetPapelPool(machine, time.Now())
+	if !ok {
+		return papelOutcome{}, false, nil
+	}
+	pool, ok := c.s.papelPools[config.Name]
+	if !ok {
+		return papelOutcome{}, false, nil
+	}
+
+	guaranteeRare := false
+	if pool.PityPlays > 0 {
+		plays, err := c.s.accountsService.GetPapelPity(ctx, c.session.PlayerID, pool.Name)
+		if err != nil {
+			return papelOutcome{}, false, err
+		}
+		guaranteeRare = pool.pityDue(plays)
+	}
+
+	items, rare := pool.draw(rand.New(rand.NewSource(time.Now().UnixNano())), guaranteeRare)
+	outcome = papelOutcome{
+		Cost:  int64(pool.Cost),
+		Items: items,
+	}
+	outcome.Result, err = c.s.accountsService.PlayPapel(ctx, c.session.PlayerID, accounts.PapelPlay{
+		TicketTypeID: int64(pool.TicketTypeID),
+		PangCost:     outcome.Cost,
+		Items:        pool.inventoryItems(items),
+		Pool:         pool.Name,
+		Rare:         rare,
+	})
+	if errors.Is(err, accounts.ErrInsufficientFunds) {
+		return papelOutcome{}, false, nil
+	} else if err != nil {
+		return papelOutcome{}, false, fmt.Errorf("playing papel shop: %w", err)
+	}
+	c.player.Pang = outcome.Result.Pang
+	c.player.Points = outcome.Result.Points
+	return outcome, true, nil
+}
+
+// sendPapelBalances re-sends the inventory and balances after a Papel Shop
+// play.
+func (c *Conn) sendPapelBalances(ctx context.Context, outcome papelOutcome) error {
+	if err := c.sendInventory(ctx); err != nil {
+		return err
+	}
+	balance := &gamepacket.ServerPangBalanceData{PangsRemaining: uint64(outcome.Result.Pang)}
+	if outcome.Result.TicketItemID == 0 {
+		balance.PangsSpent = uint64(outcome.Cost)
+	}
+	return c.SendMessage(ctx, balance)
+}
+
+func (c *Conn) playBlackPapel(ctx context.Context) error {
+	outcome, ok, err := c.playPapel(ctx, gameconfig.PapelMachineBlack)
+	if err != nil {
+		return err
+	} else if !ok {
+		return c.SendMessage(ctx, &gamepacket.ServerBlackPapelWinnings{
+			Status:           1,
+			PangsRemaining:   uint64(c.player.Pang),
+			CookiesRemaining: uint64(c.player.Points),
+		})
+	}
+
+	if err := c.SendMessage(ctx, &gamepacket.ServerBlackPapelWinnings{
+		BlackPapelInvTicketSlot: uint32(outcome.Result.TicketItemID),
+		UniqueItemsWon:          uint32(len(outcome.Items)),
+		Items:                   outcome.Items,
+		PangsRemaining:          uint64(outcome.Result.Pang),
+		CookiesRemaining:        uint64(outcome.Result.Points),
+	}); err != nil {
+		return err
+	}
+	return c.sendPapelBalances(ctx, outcome)
+}
+
+func (c *Conn) playBigPapel(ctx context.Context) error {
+	if err := c.SendMessage(ctx, &gamepacket.ServerBlackPapelResponse{
+		RemainingTurns: 50, // Displays as remaining turns in the box.
+		UnknownA:       -1,
+	}); err != nil {
+		return err
+	}
+
+	outcome, ok, err := c.playPapel(ctx, gameconfig.PapelMachineBig)
+	if err != nil {
+		return err
+	} else if !ok {
+		return c.SendMessage(ctx, &gamepacket.ServerBigPapelWinnings{
+			Status:           1,
+			PangsRemaining:   uint64(c.player.Pang),
+			CookiesRemaining: uint64(c.player.Points),
+		})
+	}
+
+	if err := c.SendMessage(ctx, &gamepacket.ServerBigPapelWinnings{
+		BlackPapelInvTicketSlot: uint32(outcome.Result.TicketItemID),
+		UniqueItemsWon:          uint32(len(outcome.Items)),
+		Items:                   outcome.Items,
+		PangsRemaining:          uint64(outcome.Result.Pang),
+		CookiesRemaining:        uint64(outcome.Result.Points),
+	}); err != nil {
+		return err
+	}
+	return c.sendPapelBalances(ctx, outcome)
+}
@@ -0,0 +1,194 @@
+// Copyright (C) 2023, John Chadwick <john@jchw.io>, JMC47
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2023 John Chadwick, JMC47
+// SPDX-License-Identifier: ISC
+
+package gameserver
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/pangbox/server/database/accounts"
+	gamepacket "github.com/pangbox/server/game/packet"
+	"github.com/pangbox/server/gameconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const papelTestSeeds = 200
+
+func TestPapelDrawUnique(t *testing.T) {
+	pool := newPapelPool(gameconfig.PapelPool{
+		MinDraws:    3,
+		MaxDraws:    3,
+		UniqueItems: true,
+		Items: []gameconfig.ItemProbability{
+			{TypeID: 1, Weight: 1000},
+			{TypeID: 2, Weight: 1},
+			{TypeID: 3, Weight: 1},
+		},
+	})
+	for seed := int64(0); seed < papelTestSeeds; seed++ {
+		items, _ := pool.draw(rand.New(rand.NewSource(seed)), false)
+		require.Len(t, items, 3)
+		seen := make(map[uint32]bool)
+		for _, item := range items {
+			assert.False(t, seen[item.ItemTypeID], "item %d drawn twice", item.ItemTypeID)
+			seen[item.ItemTypeID] = true
+			assert.Equal(t, uint32(1), item.Quantity)
+		}
+	}
+}
+
+func TestPapelDrawUniqueRunsOut(t *testing.T) {
+	pool := newPapelPool(gameconfig.PapelPool{
+		MinDraws:    5,
+		MaxDraws:    5,
+		UniqueItems: true,
+		Items: []gameconfig.ItemProbability{
+			{TypeID: 1, Weight: 1},
+			{TypeID: 2, Weight: 1},
+			{TypeID: 3, Weight: 0},
+		},
+	})
+	items, _ := pool.draw(rand.New(rand.NewSource(1)), false)
+	require.Len(t, items, 2)
+	assert.ElementsMatch(t, []uint32{1, 2}, []uint32{items[0].ItemTypeID, items[1].ItemTypeID})
+}
+
+func TestPapelDrawRepeats(t *testing.T) {
+	pool := newPapelPool(gameconfig.PapelPool{
+		MinDraws: 4,
+		MaxDraws: 4,
+		Items:    []gameconfig.ItemProbability{{TypeID: 1, Weight: 1}},
+	})
+	items, _ := pool.draw(rand.New(rand.NewSource(1)), false)
+	require.Len(t, items, 1)
+	assert.Equal(t, uint32(4), items[0].Quantity)
+}
+
+func TestPapelDrawCount(t *testing.T) {
+	pool := newPapelPool(gameconfig.PapelPool{
+		MinDraws: 2,
+		MaxDraws: 4,
+		QuantityRules: []gameconfig.PapelQuantityRule{
+			{Rarity: 0, Permanent: true},
+		},
+		Items: []gameconfig.ItemProbability{
+			{TypeID: 1, Weight: 1},
+			{TypeID: 2, Weight: 1},
+		},
+	})
+	counts := make(map[uint32]bool)
+	for seed := int64(0); seed < papelTestSeeds; seed++ {
+		items, _ := pool.draw(rand.New(rand.NewSource(seed)), false)
+		total := uint32(0)
+		for _, item := range items {
+			total += item.Quantity
+		}
+		assert.GreaterOrEqual(t, total, uint32(2))
+		assert.LessOrEqual(t, total, uint32(4))
+		counts[total] = true
+	}
+	assert.Len(t, counts, 3, "every draw count should come up")
+}
+
+func TestPapelDrawQuantity(t *testing.T) {
+	pool := newPapelPool(gameconfig.PapelPool{
+		MinDraws: 1,
+		MaxDraws: 1,
+		QuantityRules: []gameconfig.PapelQuantityRule{
+			{Rarity: 0, MinQuantity: 5, MaxQuantity: 10},
+			{Rarity: 1, Permanent: true},
+		},
+		Items: []gameconfig.ItemProbability{
+			{TypeID: 1, Weight: 1, Rarity: 0},
+			{TypeID: 2, Weight: 1, Rarity: 1},
+			{TypeID: 3, Weight: 1, Rarity: 2},
+		},
+	})
+	quantities := make(map[uint32]bool)
+	for seed := int64(0); seed < papelTestSeeds; seed++ {
+		items, _ := pool.draw(rand.New(rand.NewSource(seed)), false)
+		require.Len(t, items, 1)
+		item := items[0]
+		switch item.ItemTypeID {
+		case 1:
+			assert.GreaterOrEqual(t, item.Quantity, uint32(5))
+			assert.LessOrEqual(t, item.Quantity, uint32(10))
+			quantities[item.Quantity] = true
+		case 2, 3:
+			// Permanent items and items without a rule come one at a time.
+			assert.Equal(t, uint32(1), item.Quantity)
+		}
+	}
+	assert.True(t, quantities[5], "minimum quantity should come up")
+	assert.True(t, quantities[10], "maximum quantity should come up")
+}
+
+func TestPapelDrawPity(t *testing.T) {
+	pool := newPapelPool(gameconfig.PapelPool{
+		MinDraws:   3,
+		MaxDraws:   3,
+		PityRarity: 2,
+		PityPlays:  10,
+		Items: []gameconfig.ItemProbability{
+			{TypeID: 1, Weight: 1_000_000, Rarity: 0},
+			{TypeID: 2, Weight: 1, Rarity: 2},
+		},
+	})
+
+	rareWins := 0
+	for seed := int64(0); seed < papelTestSeeds; seed++ {
+		if _, rare := pool.draw(rand.New(rand.NewSource(seed)), false); rare {
+			rareWins++
+		}
+	}
+	assert.Less(t, rareWins, papelTestSeeds/10, "rare items should be rare without pity")
+
+	for seed := int64(0); seed < papelTestSeeds; seed++ {
+		items, rare := pool.draw(rand.New(rand.NewSource(seed)), true)
+		assert.True(t, rare)
+		require.NotEmpty(t, items)
+		assert.Equal(t, uint32(2), items[len(items)-1].ItemTypeID)
+	}
+}
+
+func TestPapelPityDue(t *testing.T) {
+	pool := newPapelPool(gameconfig.PapelPool{PityPlays: 3})
+	assert.False(t, pool.pityDue(0))
+	assert.False(t, pool.pityDue(1))
+	assert.True(t, pool.pityDue(2), "third play since the last rare item")
+	assert.True(t, pool.pityDue(5))
+
+	noPity := newPapelPool(gameconfig.PapelPool{})
+	assert.False(t, noPity.pityDue(100))
+}
+
+func TestPapelInventoryItems(t *testing.T) {
+	pool := newPapelPool(gameconfig.PapelPool{
+		QuantityRules: []gameconfig.PapelQuantityRule{{Rarity: 1, Permanent: true}},
+	})
+	items := pool.inventoryItems([]gamepacket.ServerBlackPapelItems{
+		{ItemTypeID: 1, Quantity: 7, Rarity: 0},
+		{ItemTypeID: 2, Quantity: 2, Rarity: 1},
+	})
+	assert.Equal(t, []accounts.PapelItem{
+		{ItemTypeID: 1, Quantity: 7},
+		{ItemTypeID: 2},
+		{ItemTypeID: 2},
+	}, items)
+}
@@ -18,6 +18,8 @@
 package gameserver
 
 import (
+	"github.com/pangbox/server/database/accounts"
+	gamemodel "github.com/pangbox/server/game/model"
 	"github.com/pangbox/server/gen/dbmodels"
 	"github.com/pangbox/server/pangya"
 )
@@ -32,11 +34,34 @@ func playerInfoFromDB(player *dbmodels.GetPlayerRow, connID uint32) pangya.Playe
 	}
 }
 
-func playerStatsFromDB(player *dbmodels.GetPlayerRow) pangya.PlayerStats {
+func playerStatsFromDB(player *dbmodels.GetPlayerRow, stats *dbmodels.PlayerStat) pangya.PlayerStats {
 	return pangya.PlayerStats{
-		Pang:    uint64(player.Pang),
-		Rank:    byte(player.Rank),
-		TotalXP: uint32(player.Exp),
+		TotalStrokes:   uint32(stats.TotalStrokes),
+		TotalPutts:     uint32(stats.TotalPutts),
+		LongestDrive:   float32(stats.LongestDrive),
+		PangyaHits:     uint32(stats.PangyaHits),
+		Timeouts:       uint32(stats.Timeouts),
+		OBs:            uint32(stats.Obs),
+		TotalDistance:  uint32(stats.TotalDistance),
+		TotalHoles:     uint32(stats.TotalHoles),
+		HoleUnfinished: uint32(stats.HoleUnfinished),
+		TotalHIO:       uint32(stats.TotalHio),
+		BunkersHit:     uint16(stats.BunkersHit),
+		FairwaysHit:    uint32(stats.FairwaysHit),
+		TotalAlbatross: uint32(stats.TotalAlbatross),
+		PuttIns:        uint32(stats.PuttIns),
+		LongestPutt:    float32(stats.LongestPutt),
+		LongestChip:    float32(stats.LongestChip),
+		TotalXP:        uint32(player.Exp),
+		Rank:           byte(player.Rank),
+		Pang:           uint64(player.Pang),
+		TotalScore:     int32(stats.TotalScore),
+		BestPangTotal:  uint64(stats.BestPang),
+		GamesPlayed:    uint32(stats.GamesPlayed),
+		Quits:          uint32(stats.Quits),
+		TeamHole:       uint32(stats.TeamHole),
+		TeamWin:        uint32(stats.TeamWin),
+		TeamGame:       uint32(stats.TeamGame),
 		// TODO
 	}
 }
@@ -56,19 +81,45 @@ func playerEquippedConsumablesFromDB(player *dbmodels.GetPlayerRow) [10]uint32 {
 	}
 }
 
-func playerEquippedClubSetFromDB(player *dbmodels.GetPlayerRow) pangya.PlayerClubData {
+func playerClubLevelsFromDB(player *dbmodels.GetPlayerRow) accounts.ClubStats {
+	return accounts.ClubStats{
+		player.ClubPower.Int64,
+		player.ClubControl.Int64,
+		player.ClubAccuracy.Int64,
+		player.ClubSpin.Int64,
+		player.ClubCurve.Int64,
+	}
+}
+
+func playerEquippedClubSetFromDB(player *dbmodels.GetPlayerRow, stats pangya.ClubStats) pangya.PlayerClubData {
 	return pangya.PlayerClubData{
 		Item: pangya.PlayerItem{
 			ID:     uint32(player.ClubID.Int64),
 			TypeID: uint32(player.ClubTypeID.Int64),
 		},
-		// TODO: stats/enchantments are not implemented.
-		Stats: pangya.ClubStats{
-			UpgradeStats: [5]uint16{8, 9, 8, 3, 3},
+		Stats: stats,
+	}
+}
+
+func playerEquippedCaddieFromDB(player *dbmodels.GetPlayerRow) pangya.PlayerCaddieData {
+	return pangya.PlayerCaddieData{
+		Item: pangya.PlayerItem{
+			ID:     uint32(player.CaddieID.Int64),
+			TypeID: uint32(player.CaddieTypeID.Int64),
 		},
 	}
 }
 
+func playerEquippedMascotFromDB(player *dbmodels.GetPlayerRow) pangya.PlayerMascotData {
+	return pangya.PlayerMascotData{
+		Item: pangya.PlayerItem{
+			ID:     uint32(player.MascotID.Int64),
+			TypeID: uint32(player.MascotTypeID.Int64),
+		},
+		Text: player.MascotText.String,
+	}
+}
+
 func playerEquippedItemsFromDB(player *dbmodels.GetPlayerRow) pangya.PlayerEquipment {
 	comet := player.BallTypeID
 	if comet == 0 {
@@ -94,7 +145,7 @@ func playerEquippedItemsFromDB(player *dbmodels.GetPlayerRow) pangya.PlayerEquip
 		SlotTypeID:       uint32(player.SlotTypeID.Int64),
 		CutInTypeID:      uint32(player.CutInTypeID.Int64),
 		TitleTypeID:      uint32(player.TitleTypeID.Int64),
-		MascotID:         uint32(player.MascotTypeID),
+		MascotID:         uint32(player.MascotID.Int64),
 		PosterID: [2]uint32{
 			uint32(player.Poster0TypeID.Int64),
 			uint32(player.Poster1TypeID.Int64),
@@ -135,22 +186,51 @@ func playerEquippedCharacterFromDB(player *dbmodels.GetPlayerRow) pangya.PlayerC
 	}
 }
 
-func playerDataFromDB(player *dbmodels.GetPlayerRow, connID uint32) pangya.PlayerData {
+func (s *Server) playerDataFromDB(player *dbmodels.GetPlayerRow, stats *dbmodels.PlayerStat, connID uint32) pangya.PlayerData {
+	clubStats := s.clubStats(uint32(player.ClubTypeID.Int64), playerClubLevelsFromDB(player))
 	return pangya.PlayerData{
 		UserInfo:          playerInfoFromDB(player, connID),
-		PlayerStats:       playerStatsFromDB(player),
+		PlayerStats:       playerStatsFromDB(player, stats),
 		EquippedItems:     playerEquippedItemsFromDB(player),
 		EquippedCharacter: playerEquippedCharacterFromDB(player),
-		EquippedClub:      playerEquippedClubSetFromDB(player),
+		EquippedCaddie:    playerEquippedCaddieFromDB(player),
+		EquippedClub:      playerEquippedClubSetFromDB(player, clubStats),
+		EquippedMascot:    playerEquippedMascotFromDB(player),
+	}
+}
+
+func roomPlayerFromDB(player *dbmodels.GetPlayerRow, guild *dbmodels.GetPlayerGuildRow, connID uint32) gamemodel.RoomPlayerEntry {
+	character := playerEquippedCharacterFromDB(player)
+	return gamemodel.RoomPlayerEntry{
+		ConnID:           connID,
+		Nickname:         player.Nickname.String,
+		Rank:             uint8(player.Rank),
+		GuildName:        guild.Name,
+		CharTypeID:       character.CharTypeID,
+		StatusFlags:      0,
+		GuildID:          uint32(guild.GuildID),
+		GuildEmblemImage: guild.EmblemImage,
+		PlayerID:         uint32(player.PlayerID),
+		BackgroundTypeID: uint32(player.BackgroundTypeID.Int64),
+		FrameTypeID:      uint32(player.FrameTypeID.Int64),
+		StickerTypeID:    uint32(player.StickerTypeID.Int64),
+		SlotTypeID:       uint32(player.SlotTypeID.Int64),
+		CutInTypeID:      uint32(player.CutInTypeID.Int64),
+		TitleTypeID:      uint32(player.TitleTypeID.Int64),
+		MascotTypeID:     uint32(player.MascotTypeID.Int64),
+		CharacterData:    character,
 	}
 }
 
 func (c *Conn) getPlayerInfo() pangya.PlayerInfo {
-	return playerInfoFromDB(&c.player, c.connID)
+	info := playerInfoFromDB(&c.player, c.connID)
+	info.GuildName = c.guild.Name
+	info.GuildEmblemImage = c.guild.EmblemImage
+	return info
 }
 
 func (c *Conn) getPlayerStats() pangya.PlayerStats {
-	return playerStatsFromDB(&c.player)
+	return playerStatsFromDB(&c.player, &c.stats)
 }
 
 func (c *Conn) getPlayerEquippedConsumables() [10]uint32 {
@@ -165,8 +245,17 @@ func (c *Conn) getPlayerEquippedCharacter() pangya.PlayerCharacterData {
 	return *c.currentCharacter
 }
 
+func (c *Conn) getPlayerEquippedCaddie() pangya.PlayerCaddieData {
+	return playerEquippedCaddieFromDB(&c.player)
+}
+
+func (c *Conn) getPlayerEquippedMascot() pangya.PlayerMascotData {
+	return playerEquippedMascotFromDB(&c.player)
+}
+
 func (c *Conn) getPlayerEquippedClubSet() pangya.PlayerClubData {
-	return playerEquippedClubSetFromDB(&c.player)
+	stats := c.s.clubStats(uint32(c.player.ClubTypeID.Int64), playerClubLevelsFromDB(&c.player))
+	return playerEquippedClubSetFromDB(&c.player, stats)
 }
 
 func (c *Conn) getPlayerData() pangya.PlayerData {
@@ -175,6 +264,9 @@ func (c *Conn) getPlayerData() pangya.PlayerData {
 		PlayerStats:       c.getPlayerStats(),
 		EquippedItems:     c.getPlayerEquippedItems(),
 		EquippedCharacter: c.getPlayerEquippedCharacter(),
+		SeasonHistory:     c.seasonHistory,
+		EquippedCaddie:    c.getPlayerEquippedCaddie(),
 		EquippedClub:      c.getPlayerEquippedClubSet(),
+		EquippedMascot:    c.getPlayerEquippedMascot(),
 	}
 }
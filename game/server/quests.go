@@ -0,0 +1,64 @@
+// Copyright (C) 2023, John Chadwick <john@jchw.io>, JMC47
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2023 John Chadwick, JMC47
+// SPDX-License-Identifier: ISC
+
+package gameserver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gamepacket "github.com/pangbox/server/game/packet"
+)
+
+// sendQuestStatus sends today's quests. The status slot of each quest holds
+// the player's progress towards its goal.
+func (c *Conn) sendQuestStatus(ctx context.Context) error {
+	now := time.Now().UTC()
+	quests := c.s.configProvider.GetDailyQuests(now)
+	if len(quests) == 0 {
+		return c.SendMessage(ctx, &gamepacket.ServerQuestStatus{Status: 1})
+	}
+
+	rows, err := c.s.accountsService.GetDailyQuestProgress(ctx, c.session.PlayerID, now)
+	if err != nil {
+		return fmt.Errorf("getting quest progress: %w", err)
+	}
+	progress := make(map[uint32]uint32, len(rows))
+	for _, row := range rows {
+		progress[uint32(row.QuestTypeID)] = uint32(row.Progress)
+	}
+
+	start := now.Truncate(24 * time.Hour)
+	msg := &gamepacket.ServerQuestStatus{
+		QuestStartedUnixTime: uint32(start.Unix()),
+		QuestExpiryUnixTime:  uint32(start.AddDate(0, 0, 1).Unix()),
+		QuestCount:           uint32(len(quests)),
+		QuestTypeID:          make([]uint32, len(quests)),
+		QuestSlotCount:       uint32(len(quests)),
+		QuestStatusSlot:      make([]uint32, len(quests)),
+	}
+	for i, quest := range quests {
+		value := progress[quest.TypeID]
+		if value > quest.Goal {
+			value = quest.Goal
+		}
+		msg.QuestTypeID[i] = quest.TypeID
+		msg.QuestStatusSlot[i] = value
+	}
+	return c.SendMessage(ctx, msg)
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2023, John Chadwick <john@jchw.io>, JMC47
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2023 John Chadwick, JMC47
+// SPDX-License-Identifier: ISC
+
+package gameserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/pangbox/server/database/accounts"
+	gamepacket "github.com/pangbox/server/game/packet"
+	"github.com/pangbox/server/gameconfig"
+)
+
+// errScratchyUnavailable is returned when Scratchy cards can't be played
+// because no card or prizes are configured.
+var errScratchyUnavailable = errors.New("scratchy is not available")
+
+// scratchyAvailable returns true if Scratchy cards can be played.
+func (s *Server) scratchyAvailable() bool {
+	return s.configProvider.GetScratchyCardTypeID() != 0 && len(s.configProvider.GetScratchyPrizes()) > 0
+}
+
+func (c *Conn) openScratchyMenu(ctx context.Context) error {
+	// TODO: the status that opens the menu isn't known yet, so the menu is
+	// always answered as unavailable.
+	return c.SendMessage(ctx, &gamepacket.ServerScratchyMenuResponse{
+		Status: 1,
+	})
+}
+
+// playScratchy uses up one of the player's Scratchy cards and gives them a
+// prize drawn from the configured prize table.
+//
+// TODO: the client's Scratchy play and result packets aren't known yet, so
+// nothing calls this until they are identified.
+func (c *Conn) playScratchy(ctx context.Context) (gameconfig.ScratchyPrize, error) {
+	if !c.s.scratchyAvailable() {
+		return gameconfig.ScratchyPrize{}, errScratchyUnavailable
+	}
+
+	prize := c.s.configProvider.GetScratchyPrizes()[c.s.scratchyPrizes.Choose()]
+	_, err := c.s.accountsService.PlayScratchy(
+		ctx,
+		c.session.PlayerID,
+		int64(c.s.configProvider.GetScratchyCardTypeID()),
+		accounts.ScratchyPrize{
+			ItemTypeID: int64(prize.ItemTypeID),
+			Quantity:   int64(prize.Quantity),
+		},
+	)
+	if err != nil {
+		return gameconfig.ScratchyPrize{}, fmt.Errorf("playing scratchy: %w", err)
+	}
+	return prize, c.sendInventory(ctx)
+}
@@ -20,6 +20,7 @@ package gameserver
 import (
 	"context"
 	"net"
+	"sync"
 
 	"github.com/pangbox/server/common"
 	"github.com/pangbox/server/database/accounts"
@@ -53,17 +54,31 @@ type Server struct {
 	channelName     string
 	configProvider  gameconfig.Provider
 	lobby           *room.Lobby
-	papelShop       *WeightedRand
-	papelRarity     map[uint32]uint32
+	papelPools      map[string]*papelPool
+	scratchyPrizes  *WeightedRand
+	cardPacks       map[uint32]*cardPack
+
+	connsMu sync.Mutex
+	conns   map[int64]*Conn
+
+	// rejoins holds the rooms that are keeping a game going for players
+	// who lost their connection, by player ID.
+	rejoins map[int64]*room.Room
 }
 
 // New creates a new instance of the game server.
 func New(opts Options) *Server {
-	papelShop := NewWeightedRand()
-	papelRarity := make(map[uint32]uint32)
-	for _, item := range opts.ConfigProvider.GetPapelShopOdds() {
-		papelShop.Add(item.TypeID, item.Weight)
-		papelRarity[item.TypeID] = uint32(item.Rarity)
+	papelPools := make(map[string]*papelPool)
+	for _, pool := range opts.ConfigProvider.GetPapelPools() {
+		papelPools[pool.Name] = newPapelPool(pool)
+	}
+	scratchyPrizes := NewWeightedRand()
+	for i, prize := range opts.ConfigProvider.GetScratchyPrizes() {
+		scratchyPrizes.Add(uint32(i), prize.Weight)
+	}
+	cardPacks := make(map[uint32]*cardPack)
+	for _, pack := range opts.ConfigProvider.GetCardPacks() {
+		cardPacks[pack.TypeID] = newCardPack(pack)
 	}
 	return &Server{
 		log:             opts.Logger.With().Str("server", "game").Logger(),
@@ -74,14 +89,18 @@ func New(opts Options) *Server {
 		serverID:        opts.ServerID,
 		channelName:     opts.ChannelName,
 		configProvider:  opts.ConfigProvider,
-		papelShop:       papelShop,
-		papelRarity:     papelRarity,
+		papelPools:      papelPools,
+		scratchyPrizes:  scratchyPrizes,
+		cardPacks:       cardPacks,
+		conns:           make(map[int64]*Conn),
+		rejoins:         make(map[int64]*room.Room),
 	}
 }
 
 // Listen listens for connections on a given address and blocks indefinitely.
 func (s *Server) Listen(ctx context.Context, addr string) error {
-	s.lobby = room.NewLobby(ctx, s.log, s.accountsService, s.configProvider)
+	s.lobby = room.NewLobby(ctx, s.log, s.accountsService, s.configProvider, s)
+	go s.expireItemsTask(ctx)
 	return s.baseServer.Listen(s.log, addr, func(log zerolog.Logger, socket net.Conn) error {
 		conn := Conn{
 			ServerConn: common.NewServerConn(
@@ -101,3 +120,54 @@ func (s *Server) Shutdown(shutdownCtx context.Context) error {
 	// TODO: Need to shut down connection threads.
 	return s.baseServer.Close()
 }
+
+// addConn registers an authenticated connection.
+func (s *Server) addConn(c *Conn) {
+	s.connsMu.Lock()
+	defer s.connsMu.Unlock()
+	s.conns[c.session.PlayerID] = c
+}
+
+// removeConn unregisters a connection, if it is still registered.
+func (s *Server) removeConn(c *Conn) {
+	s.connsMu.Lock()
+	defer s.connsMu.Unlock()
+	if s.conns[c.session.PlayerID] == c {
+		delete(s.conns, c.session.PlayerID)
+	}
+}
+
+// AddRejoin records that a room is keeping a player's place in a game.
+func (s *Server) AddRejoin(playerID int64, r *room.Room) {
+	s.connsMu.Lock()
+	defer s.connsMu.Unlock()
+	s.rejoins[playerID] = r
+}
+
+// RemoveRejoin forgets that a room was keeping a player's place in a game,
+// once the room gave up on them or the game ended.
+func (s *Server) RemoveRejoin(playerID int64, r *room.Room) {
+	s.connsMu.Lock()
+	defer s.connsMu.Unlock()
+	if s.rejoins[playerID] == r {
+		delete(s.rejoins, playerID)
+	}
+}
+
+// takeRejoin returns the room keeping a player's place in a game, if any,
+// and forgets it.
+func (s *Server) takeRejoin(playerID int64) *room.Room {
+	s.connsMu.Lock()
+	defer s.connsMu.Unlock()
+	r := s.rejoins[playerID]
+	delete(s.rejoins, playerID)
+	return r
+}
+
+// getConn returns the connection for a player, or nil if they are not
+// connected to this server.
+func (s *Server) getConn(playerID int64) *Conn {
+	s.connsMu.Lock()
+	defer s.connsMu.Unlock()
+	return s.conns[playerID]
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2023, John Chadwick <john@jchw.io>, JMC47
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2023 John Chadwick, JMC47
+// SPDX-License-Identifier: ISC
+
+package gameserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pangbox/server/database/accounts"
+	gamepacket "github.com/pangbox/server/game/packet"
+	"github.com/pangbox/server/gen/dbmodels"
+)
+
+// checkPurchase validates a shop purchase request against the IFF archive.
+// When the purchase is rejected, the status to send to the client is returned
+// along with an error describing why.
+func (s *Server) checkPurchase(item gamepacket.PurchaseItem, now time.Time) (gamepacket.PurchaseStatus, error) {
+	if s.pangyaIFF == nil {
+		return gamepacket.PurchaseItemUnavailable, errors.New("no iff loaded to check purchase against")
+	}
+
+	data, ok := s.pangyaIFF.ItemMap[item.ItemTypeID]
+	if !ok {
+		return gamepacket.PurchaseItemUnavailable, fmt.Errorf("item %08x not in iff", item.ItemTypeID)
+	}
+	if !data.Active {
+		return gamepacket.PurchaseItemUnavailable, fmt.Errorf("item %08x is not active", item.ItemTypeID)
+	}
+	if !data.Buyable() {
+		return gamepacket.PurchaseItemUnavailable, fmt.Errorf(
+			"item %08x is not for sale (shop flag %02x, money flag %02x)",
+			item.ItemTypeID, data.ShopFlag, data.MoneyFlag)
+	}
+	if !data.OnSale(now) {
+		return gamepacket.PurchaseItemUnavailable, fmt.Errorf("item %08x is outside of its sale window", item.ItemTypeID)
+	}
+
+	if item.Quantity != data.ShopQuantity() {
+		return gamepacket.PurchaseItemUnavailable, fmt.Errorf(
+			"item %08x quantity mismatch: client sent %d, expected %d",
+			item.ItemTypeID, item.Quantity, data.ShopQuantity())
+	}
+
+	var pang, points uint32
+	if data.IsCash() {
+		points = data.ShopPrice()
+	} else {
+		pang = data.ShopPrice()
+	}
+	if item.ItemCostPang != pang || item.ItemCostPoint != points {
+		return gamepacket.PurchasePriceMismatch, fmt.Errorf(
+			"item %08x price mismatch: client sent %d pang/%d points, expected %d pang/%d points",
+			item.ItemTypeID, item.ItemCostPang, item.ItemCostPoint, pang, points)
+	}
+
+	return gamepacket.PurchaseOK, nil
+}
+
+// itemDuration returns how long an item lasts once bought, or zero if it is
+// permanent or the IFF is not available.
+func (s *Server) itemDuration(typeID uint32) time.Duration {
+	if s.pangyaIFF == nil {
+		return 0
+	}
+	if data, ok := s.pangyaIFF.ItemMap[typeID]; ok {
+		return data.RentalDuration()
+	}
+	return 0
+}
+
+// rareShopStock returns how many of a Rare Shop item can be sold, or zero if
+// it is not limited. ok is false if the item is not sold in the Rare Shop.
+func (s *Server) rareShopStock(typeID uint32) (stock uint32, ok bool) {
+	if s.pangyaIFF == nil {
+		return 0, false
+	}
+	data, found := s.pangyaIFF.ItemMap[typeID]
+	if !found || !data.IsRare() {
+		return 0, false
+	}
+	return s.configProvider.GetRareShopStock(typeID), true
+}
+
+// purchaseItems buys a cart of items that have passed checkPurchase, all or
+// nothing. Rare Shop items are taken out of the Rare Shop's stock.
+func (c *Conn) purchaseItems(ctx context.Context, items []gamepacket.PurchaseItem) (dbmodels.SetPlayerCurrencyRow, error) {
+	purchases := make([]accounts.ItemPurchase, len(items))
+	for i, item := range items {
+		purchases[i] = accounts.ItemPurchase{
+			PangTotal:  int64(item.ItemCostPang),
+			PointTotal: int64(item.ItemCostPoint),
+			ItemTypeID: int64(item.ItemTypeID),
+			Quantity:   int64(item.Quantity),
+			Duration:   c.s.itemDuration(item.ItemTypeID),
+		}
+		if stock, ok := c.s.rareShopStock(item.ItemTypeID); ok {
+			purchases[i].Rare = true
+			purchases[i].StockLimit = int64(stock)
+		}
+	}
+	return c.s.accountsService.PurchaseItems(ctx, c.session.PlayerID, purchases)
+}
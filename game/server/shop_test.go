@@ -0,0 +1,166 @@
+// Copyright (C) 2023, John Chadwick <john@jchw.io>, JMC47
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2023 John Chadwick, JMC47
+// SPDX-License-Identifier: ISC
+
+package gameserver
+
+import (
+	"testing"
+	"time"
+
+	gamepacket "github.com/pangbox/server/game/packet"
+	"github.com/pangbox/server/pangya"
+	"github.com/pangbox/server/pangya/iff"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPurchase(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	s := &Server{pangyaIFF: &iff.Archive{ItemMap: map[uint32]*iff.Item{
+		0x01: {Active: true, Price: 1000},
+		0x02: {Active: true, Price: 1000, DiscountPrice: 800},
+		0x03: {Active: true, Price: 500, ShopFlag: iff.ShopFlagCash},
+		0x04: {Active: true, Price: 100, Quantity: 10},
+		0x05: {Active: false, Price: 1000},
+		0x06: {Active: true, Price: 1000, ShopFlag: iff.ShopFlagDisplayOnly},
+		0x07: {Active: true, Price: 1000, ShopFlag: iff.ShopFlagGiftOnly},
+		0x08: {Active: true, Price: 1000, MoneyFlag: iff.MoneyFlagNew},
+		0x09: {Active: true, Price: 1000, MoneyFlag: iff.MoneyFlagHot + 1},
+		0x0A: {Active: true, Price: 1000, EndTime: pangya.NewSystemTime(now)},
+		0x0B: {Active: true, Price: 1000, StartTime: pangya.NewSystemTime(now.Add(time.Hour))},
+	}}}
+
+	tests := []struct {
+		name   string
+		item   gamepacket.PurchaseItem
+		status gamepacket.PurchaseStatus
+	}{
+		{
+			name:   "pang item",
+			item:   gamepacket.PurchaseItem{ItemTypeID: 0x01, Quantity: 1, ItemCostPang: 1000},
+			status: gamepacket.PurchaseOK,
+		},
+		{
+			name:   "discounted item",
+			item:   gamepacket.PurchaseItem{ItemTypeID: 0x02, Quantity: 1, ItemCostPang: 800},
+			status: gamepacket.PurchaseOK,
+		},
+		{
+			name:   "cash item",
+			item:   gamepacket.PurchaseItem{ItemTypeID: 0x03, Quantity: 1, ItemCostPoint: 500},
+			status: gamepacket.PurchaseOK,
+		},
+		{
+			name:   "bundle",
+			item:   gamepacket.PurchaseItem{ItemTypeID: 0x04, Quantity: 10, ItemCostPang: 100},
+			status: gamepacket.PurchaseOK,
+		},
+		{
+			name:   "badge doesn't affect sale",
+			item:   gamepacket.PurchaseItem{ItemTypeID: 0x08, Quantity: 1, ItemCostPang: 1000},
+			status: gamepacket.PurchaseOK,
+		},
+		{
+			name:   "unknown item",
+			item:   gamepacket.PurchaseItem{ItemTypeID: 0xFF, Quantity: 1, ItemCostPang: 1000},
+			status: gamepacket.PurchaseItemUnavailable,
+		},
+		{
+			name:   "inactive item",
+			item:   gamepacket.PurchaseItem{ItemTypeID: 0x05, Quantity: 1, ItemCostPang: 1000},
+			status: gamepacket.PurchaseItemUnavailable,
+		},
+		{
+			name:   "display only",
+			item:   gamepacket.PurchaseItem{ItemTypeID: 0x06, Quantity: 1, ItemCostPang: 1000},
+			status: gamepacket.PurchaseItemUnavailable,
+		},
+		{
+			name:   "gift only",
+			item:   gamepacket.PurchaseItem{ItemTypeID: 0x07, Quantity: 1, ItemCostPang: 1000},
+			status: gamepacket.PurchaseItemUnavailable,
+		},
+		{
+			name:   "not listed in shop",
+			item:   gamepacket.PurchaseItem{ItemTypeID: 0x09, Quantity: 1, ItemCostPang: 1000},
+			status: gamepacket.PurchaseItemUnavailable,
+		},
+		{
+			name:   "sale ended",
+			item:   gamepacket.PurchaseItem{ItemTypeID: 0x0A, Quantity: 1, ItemCostPang: 1000},
+			status: gamepacket.PurchaseItemUnavailable,
+		},
+		{
+			name:   "sale not started",
+			item:   gamepacket.PurchaseItem{ItemTypeID: 0x0B, Quantity: 1, ItemCostPang: 1000},
+			status: gamepacket.PurchaseItemUnavailable,
+		},
+		{
+			name:   "bundle split up",
+			item:   gamepacket.PurchaseItem{ItemTypeID: 0x04, Quantity: 1, ItemCostPang: 10},
+			status: gamepacket.PurchaseItemUnavailable,
+		},
+		{
+			name:   "no quantity",
+			item:   gamepacket.PurchaseItem{ItemTypeID: 0x01, Quantity: 0, ItemCostPang: 1000},
+			status: gamepacket.PurchaseItemUnavailable,
+		},
+		{
+			name:   "underpaid",
+			item:   gamepacket.PurchaseItem{ItemTypeID: 0x01, Quantity: 1, ItemCostPang: 1},
+			status: gamepacket.PurchasePriceMismatch,
+		},
+		{
+			name:   "full price for discounted item",
+			item:   gamepacket.PurchaseItem{ItemTypeID: 0x02, Quantity: 1, ItemCostPang: 1000},
+			status: gamepacket.PurchasePriceMismatch,
+		},
+		{
+			name:   "pang item paid in points",
+			item:   gamepacket.PurchaseItem{ItemTypeID: 0x01, Quantity: 1, ItemCostPoint: 1000},
+			status: gamepacket.PurchasePriceMismatch,
+		},
+		{
+			name:   "cash item paid in pang",
+			item:   gamepacket.PurchaseItem{ItemTypeID: 0x03, Quantity: 1, ItemCostPang: 500},
+			status: gamepacket.PurchasePriceMismatch,
+		},
+		{
+			name:   "paid in both currencies",
+			item:   gamepacket.PurchaseItem{ItemTypeID: 0x03, Quantity: 1, ItemCostPang: 500, ItemCostPoint: 500},
+			status: gamepacket.PurchasePriceMismatch,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			status, err := s.checkPurchase(test.item, now)
+			assert.Equal(t, test.status, status)
+			if test.status == gamepacket.PurchaseOK {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckPurchaseWithoutIFF(t *testing.T) {
+	s := &Server{}
+	status, err := s.checkPurchase(gamepacket.PurchaseItem{ItemTypeID: 0x01, Quantity: 1, ItemCostPang: 1000}, time.Now())
+	assert.Equal(t, gamepacket.PurchaseItemUnavailable, status)
+	assert.Error(t, err)
+}
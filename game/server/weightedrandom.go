@@ -58,8 +58,17 @@ func (w *WeightedRand) Add(value uint32, weight int64) error {
 }
 
 func (w *WeightedRand) Choose() uint32 {
+	return w.choose(rand.Int63n)
+}
+
+// ChooseRand is like Choose, but draws from r instead of the global source.
+func (w *WeightedRand) ChooseRand(r *rand.Rand) uint32 {
+	return w.choose(r.Int63n)
+}
+
+func (w *WeightedRand) choose(int63n func(n int64) int64) uint32 {
 	// Generate a random number in the range [0, totalWeight)
-	r := rand.Int63n(w.totalWeight)
+	r := int63n(w.totalWeight)
 
 	// Use binary search to find the index where our random number fits in
 	index := sort.Search(len(w.cumulativeWeights), func(i int) bool { return w.cumulativeWeights[i] > r })
@@ -67,3 +76,24 @@ func (w *WeightedRand) Choose() uint32 {
 	// Return the corresponding value
 	return w.values[index]
 }
+
+// TotalWeight returns the sum of the weights of the values. Choose can only
+// be called when it is positive.
+func (w *WeightedRand) TotalWeight() int64 {
+	return w.totalWeight
+}
+
+// Without returns a copy of w that leaves out the values for which exclude
+// returns true.
+func (w *WeightedRand) Without(exclude func(value uint32) bool) *WeightedRand {
+	result := NewWeightedRand()
+	var previous int64
+	for i, value := range w.values {
+		weight := w.cumulativeWeights[i] - previous
+		previous = w.cumulativeWeights[i]
+		if !exclude(value) {
+			result.Add(value, weight)
+		}
+	}
+	return result
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2023, John Chadwick <john@jchw.io>, JMC47
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2023 John Chadwick, JMC47
+// SPDX-License-Identifier: ISC
+
+package gameserver
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeightedRandWithout(t *testing.T) {
+	w := NewWeightedRand()
+	w.Add(1, 10)
+	w.Add(2, 20)
+	w.Add(3, 30)
+
+	rest := w.Without(func(value uint32) bool { return value == 2 })
+	assert.Equal(t, int64(40), rest.TotalWeight())
+	assert.Equal(t, int64(60), w.TotalWeight(), "original is left alone")
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		assert.NotEqual(t, uint32(2), rest.ChooseRand(rng))
+	}
+
+	none := w.Without(func(uint32) bool { return true })
+	assert.Zero(t, none.TotalWeight())
+}
+
+func TestWeightedRandChooseRand(t *testing.T) {
+	w := NewWeightedRand()
+	w.Add(1, 1)
+	w.Add(2, 0)
+	w.Add(3, 1)
+
+	a := rand.New(rand.NewSource(42))
+	b := rand.New(rand.NewSource(42))
+	for i := 0; i < 100; i++ {
+		chosen := w.ChooseRand(a)
+		assert.Equal(t, chosen, w.ChooseRand(b), "same seed, same choices")
+		assert.NotEqual(t, uint32(2), chosen, "zero weight is never chosen")
+	}
+}
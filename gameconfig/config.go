@@ -24,7 +24,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/rand"
 	"os"
+	"time"
 )
 
 //go:embed default.json
@@ -44,7 +46,19 @@ type Provider interface {
 	GetDefaultClubSetTypeID() uint32
 	GetDefaultPang() uint64
 	GetCourseBonus(course uint8, numPlayers, numHoles int) uint64
-	GetPapelShopOdds() []ItemProbability
+	GetPapelPools() []PapelPool
+	GetPapelPool(machine PapelMachine, t time.Time) (PapelPool, bool)
+	GetScratchyCardTypeID() uint32
+	GetScratchyPrizes() []ScratchyPrize
+	GetRareShopStock(itemTypeID uint32) uint32
+	GetCard(typeID uint32) (Card, bool)
+	GetCardPacks() []CardPack
+	GetClubUpgrades() ClubUpgrades
+	GetLoginBonusCalendar(t time.Time) (LoginBonusCalendar, bool)
+	GetSeason(t time.Time) uint8
+	GetAchievements() []Achievement
+	GetDailyQuests(t time.Time) []Quest
+	GetTourneyPrize(place int) (TourneyPrize, bool)
 }
 
 type CharacterDefaults struct {
@@ -58,12 +72,348 @@ type CourseBonusRate struct {
 	BonusRate  int
 }
 
+// LoginBonusReward is the reward for a single day of a login bonus calendar.
+// A non-zero Quantity adds to a consumable stack.
+type LoginBonusReward struct {
+	ItemTypeID uint32 `json:"ItemTypeID"`
+	Quantity   uint32 `json:"Quantity"`
+	Pang       uint64 `json:"Pang"`
+}
+
+// LoginBonusCalendar is a sequence of daily login rewards. Rewards repeat once
+// the end of the calendar is reached. A zero StartTime or EndTime leaves that
+// side of the calendar unbounded.
+type LoginBonusCalendar struct {
+	Name      string             `json:"Name"`
+	StartTime time.Time          `json:"StartTime"`
+	EndTime   time.Time          `json:"EndTime"`
+	Rewards   []LoginBonusReward `json:"Rewards"`
+}
+
+// Active returns true if the calendar is running at t.
+func (c LoginBonusCalendar) Active(t time.Time) bool {
+	if !c.StartTime.IsZero() && t.Before(c.StartTime) {
+		return false
+	}
+	if !c.EndTime.IsZero() && !t.Before(c.EndTime) {
+		return false
+	}
+	return len(c.Rewards) > 0
+}
+
+// Season marks the point in time a new season of course records begins.
+type Season struct {
+	Season    uint8     `json:"Season"`
+	StartTime time.Time `json:"StartTime"`
+}
+
+// AchievementEvent is a game event that can advance achievement counters.
+type AchievementEvent string
+
+const (
+	AchievementEventHoleInOne     AchievementEvent = "HoleInOne"
+	AchievementEventChipIn        AchievementEvent = "ChipIn"
+	AchievementEventGamePlayed    AchievementEvent = "GamePlayed"
+	AchievementEventItemPurchased AchievementEvent = "ItemPurchased"
+)
+
+// AchievementStage is one step of an achievement, completed when the
+// achievement's counter reaches Goal. A non-zero Quantity adds to a consumable
+// stack.
+type AchievementStage struct {
+	TypeID     uint32 `json:"TypeID"`
+	Goal       uint32 `json:"Goal"`
+	ItemTypeID uint32 `json:"ItemTypeID"`
+	Quantity   uint32 `json:"Quantity"`
+	Pang       uint64 `json:"Pang"`
+}
+
+// Achievement is a set of stages that track a counter. The counter is
+// advanced each time Event happens.
+type Achievement struct {
+	TypeID        uint32             `json:"TypeID"`
+	CounterTypeID uint32             `json:"CounterTypeID"`
+	Event         AchievementEvent   `json:"Event"`
+	Stages        []AchievementStage `json:"Stages"`
+}
+
+// QuestObjective is what a quest counts towards its goal.
+type QuestObjective string
+
+const (
+	QuestObjectiveHoles      QuestObjective = "Holes"
+	QuestObjectivePangyaHits QuestObjective = "PangyaHits"
+	QuestObjectiveGames      QuestObjective = "Games"
+	QuestObjectiveHoleInOne  QuestObjective = "HoleInOne"
+)
+
+// Quest is a daily quest. If Course is set, only progress made on that course
+// counts. A non-zero Quantity adds to a consumable stack.
+type Quest struct {
+	TypeID     uint32         `json:"TypeID"`
+	Objective  QuestObjective `json:"Objective"`
+	Course     *uint8         `json:"Course"`
+	Goal       uint32         `json:"Goal"`
+	ItemTypeID uint32         `json:"ItemTypeID"`
+	Quantity   uint32         `json:"Quantity"`
+	Pang       uint64         `json:"Pang"`
+}
+
+// Matches returns true if progress on objective on course counts towards q.
+func (q Quest) Matches(objective QuestObjective, course uint8) bool {
+	return q.Objective == objective && (q.Course == nil || *q.Course == course)
+}
+
+// TourneyPrize is mailed to players who finish a tournament in Place, which
+// starts from 1. A non-zero Quantity adds to a consumable stack.
+type TourneyPrize struct {
+	Place      int    `json:"Place"`
+	ItemTypeID uint32 `json:"ItemTypeID"`
+	Quantity   uint32 `json:"Quantity"`
+	Pang       uint64 `json:"Pang"`
+}
+
+// PapelMachine is a kind of Papel Shop machine.
+type PapelMachine string
+
+const (
+	PapelMachineBlack PapelMachine = "Black"
+	PapelMachineBig   PapelMachine = "Big"
+)
+
+// PapelQuantityRule sets how many of an item of a given rarity are won each
+// time it is drawn. Permanent items are given as one permanent item per draw
+// instead of a consumable stack.
+type PapelQuantityRule struct {
+	Rarity      uint32 `json:"Rarity"`
+	MinQuantity uint32 `json:"MinQuantity"`
+	MaxQuantity uint32 `json:"MaxQuantity"`
+	Permanent   bool   `json:"Permanent"`
+}
+
+// PapelPool is a set of odds for a Papel Shop machine. Each play draws between
+// MinDraws and MaxDraws items; with UniqueItems, the same item is never drawn
+// twice in one play. If PityPlays is set, a play that would otherwise be the
+// PityPlays-th in a row without an item of at least PityRarity is guaranteed
+// one. A zero StartTime or EndTime leaves that side of the pool unbounded.
+type PapelPool struct {
+	Name          string              `json:"Name"`
+	Machine       PapelMachine        `json:"Machine"`
+	StartTime     time.Time           `json:"StartTime"`
+	EndTime       time.Time           `json:"EndTime"`
+	Cost          uint64              `json:"Cost"`
+	TicketTypeID  uint32              `json:"TicketTypeID"`
+	MinDraws      int                 `json:"MinDraws"`
+	MaxDraws      int                 `json:"MaxDraws"`
+	UniqueItems   bool                `json:"UniqueItems"`
+	QuantityRules []PapelQuantityRule `json:"QuantityRules"`
+	PityRarity    uint32              `json:"PityRarity"`
+	PityPlays     uint32              `json:"PityPlays"`
+	Items         []ItemProbability   `json:"Items"`
+}
+
+// Active returns true if the pool is running at t.
+func (p PapelPool) Active(t time.Time) bool {
+	if !p.StartTime.IsZero() && t.Before(p.StartTime) {
+		return false
+	}
+	if !p.EndTime.IsZero() && !t.Before(p.EndTime) {
+		return false
+	}
+	return len(p.Items) > 0
+}
+
+// QuantityRule returns the quantity rule for items of the given rarity. Items
+// without a rule are won one at a time.
+func (p PapelPool) QuantityRule(rarity uint32) PapelQuantityRule {
+	for _, rule := range p.QuantityRules {
+		if rule.Rarity == rarity {
+			return rule
+		}
+	}
+	return PapelQuantityRule{Rarity: rarity, MinQuantity: 1, MaxQuantity: 1}
+}
+
+// validate checks that every play of the pool can draw its items: weights
+// can't be negative, there must be something to draw, and with UniqueItems
+// there must be enough different items for the most draws a play can make.
+func (p PapelPool) validate() error {
+	var total, rareTotal int64
+	drawable := 0
+	for _, item := range p.Items {
+		if item.Weight < 0 {
+			return fmt.Errorf("papel pool %q: item %08x has a negative weight", p.Name, item.TypeID)
+		}
+		if item.Weight > 0 {
+			drawable++
+		}
+		total += item.Weight
+		if item.Rarity >= p.PityRarity {
+			rareTotal += item.Weight
+		}
+	}
+	if len(p.Items) == 0 {
+		return nil
+	}
+	if total == 0 {
+		return fmt.Errorf("papel pool %q: every item has a weight of zero", p.Name)
+	}
+	if p.UniqueItems && p.MaxDraws > drawable {
+		return fmt.Errorf("papel pool %q: draws up to %d unique items, but only %d items can be drawn", p.Name, p.MaxDraws, drawable)
+	}
+	if p.PityPlays > 0 && rareTotal == 0 {
+		return fmt.Errorf("papel pool %q: no item of pity rarity %d can be drawn", p.Name, p.PityRarity)
+	}
+	return nil
+}
+
+// legacyPapelPools returns the pools used by manifests that only set the old
+// global PapelShopOdds.
+func legacyPapelPools(odds []ItemProbability, ticketTypeID uint32) []PapelPool {
+	if len(odds) == 0 {
+		return nil
+	}
+	return []PapelPool{
+		{
+			Name:         "black",
+			Machine:      PapelMachineBlack,
+			Cost:         500,
+			TicketTypeID: ticketTypeID,
+			MinDraws:     1,
+			MaxDraws:     4,
+			UniqueItems:  true,
+			QuantityRules: []PapelQuantityRule{
+				{Rarity: 0, MinQuantity: 1, MaxQuantity: 3},
+				{Rarity: 1, MinQuantity: 1, MaxQuantity: 3},
+				{Rarity: 2, MinQuantity: 1, MaxQuantity: 1, Permanent: true},
+			},
+			Items: odds,
+		},
+		{
+			Name:         "big",
+			Machine:      PapelMachineBig,
+			Cost:         10000,
+			TicketTypeID: ticketTypeID,
+			MinDraws:     10,
+			MaxDraws:     10,
+			QuantityRules: []PapelQuantityRule{
+				{Rarity: 0, MinQuantity: 1, MaxQuantity: 2},
+				{Rarity: 1, MinQuantity: 1, MaxQuantity: 2},
+				{Rarity: 2, MinQuantity: 1, MaxQuantity: 1, Permanent: true},
+			},
+			Items: odds,
+		},
+	}
+}
+
+// ScratchyPrize is a prize that can be won from a Scratchy card. A non-zero
+// Quantity adds to a consumable stack.
+type ScratchyPrize struct {
+	ItemTypeID uint32 `json:"ItemTypeID"`
+	Quantity   uint32 `json:"Quantity"`
+	Weight     int64  `json:"Weight"`
+	Rarity     uint32 `json:"Rarity"`
+}
+
+// validateScratchyPrizes returns an error if no prize can be drawn from a
+// non-empty list of Scratchy prizes.
+func validateScratchyPrizes(prizes []ScratchyPrize) error {
+	var total int64
+	for _, prize := range prizes {
+		if prize.Weight < 0 {
+			return fmt.Errorf("scratchy prize %08x has a negative weight", prize.ItemTypeID)
+		}
+		total += prize.Weight
+	}
+	if len(prizes) > 0 && total == 0 {
+		return errors.New("no scratchy prize can be drawn")
+	}
+	return nil
+}
+
+// RareShopStock limits how many of a Rare Shop item can be sold across all
+// players.
+type RareShopStock struct {
+	ItemTypeID uint32 `json:"ItemTypeID"`
+	Stock      uint32 `json:"Stock"`
+}
+
+// CardKind is the kind of slot a card is equipped to.
+type CardKind string
+
+const (
+	CardKindCharacter CardKind = "Character"
+	CardKindCaddie    CardKind = "Caddie"
+	CardKindNPC       CardKind = "NPC"
+)
+
+// Card describes a card's effects while it is equipped. PangRate and ExpRate
+// are percentages added to the Pang and EXP earned at the end of a game.
+type Card struct {
+	TypeID   uint32   `json:"TypeID"`
+	Kind     CardKind `json:"Kind"`
+	PangRate uint32   `json:"PangRate"`
+	ExpRate  uint32   `json:"ExpRate"`
+}
+
+// CardPack is an item that is opened for NumCards cards, each drawn from
+// Cards.
+type CardPack struct {
+	TypeID   uint32            `json:"TypeID"`
+	NumCards int               `json:"NumCards"`
+	Cards    []ItemProbability `json:"Cards"`
+}
+
+// validate returns an error if cards can't be drawn from the pack.
+func (p CardPack) validate() error {
+	var total int64
+	for _, card := range p.Cards {
+		if card.Weight < 0 {
+			return fmt.Errorf("card pack %08x: card %08x has a negative weight", p.TypeID, card.TypeID)
+		}
+		total += card.Weight
+	}
+	if p.NumCards < 0 {
+		return fmt.Errorf("card pack %08x: negative number of cards", p.TypeID)
+	}
+	if p.NumCards > 0 && total == 0 {
+		return fmt.Errorf("card pack %08x: no card can be drawn", p.TypeID)
+	}
+	return nil
+}
+
+// ClubUpgrades sets the Pang cost of upgrading club set stats. Raising a stat
+// from level n to n+1 costs UpgradeCosts[n], and lowering it from n+1 back to
+// n costs DowngradeCosts[n]. Stats can't be raised past the number of
+// upgrade costs, nor past the club set's own limits.
+type ClubUpgrades struct {
+	UpgradeCosts   []uint64 `json:"UpgradeCosts"`
+	DowngradeCosts []uint64 `json:"DowngradeCosts"`
+}
+
 type Manifest struct {
-	CharacterDefaults    []CharacterDefaults `json:"CharacterDefaults"`
-	DefaultClubSetTypeID uint32              `json:"DefaultClubSetTypeID"`
-	DefaultPang          uint64              `json:"DefaultPang"`
-	CourseBonusRate      []CourseBonusRate   `json:"CourseBonusRate"`
-	PapelShopOdds        []ItemProbability   `json:"PapelShopOdds"`
+	CharacterDefaults    []CharacterDefaults  `json:"CharacterDefaults"`
+	DefaultClubSetTypeID uint32               `json:"DefaultClubSetTypeID"`
+	DefaultPang          uint64               `json:"DefaultPang"`
+	CourseBonusRate      []CourseBonusRate    `json:"CourseBonusRate"`
+	PapelPools           []PapelPool          `json:"PapelPools"`
+	ScratchyCardTypeID   uint32               `json:"ScratchyCardTypeID"`
+	ScratchyPrizes       []ScratchyPrize      `json:"ScratchyPrizes"`
+	RareShopStock        []RareShopStock      `json:"RareShopStock"`
+	Cards                []Card               `json:"Cards"`
+	CardPacks            []CardPack           `json:"CardPacks"`
+	ClubUpgrades         ClubUpgrades         `json:"ClubUpgrades"`
+	LoginBonusCalendars  []LoginBonusCalendar `json:"LoginBonusCalendars"`
+	Seasons              []Season             `json:"Seasons"`
+	Achievements         []Achievement        `json:"Achievements"`
+	DailyQuestCount      int                  `json:"DailyQuestCount"`
+	DailyQuestPool       []Quest              `json:"DailyQuestPool"`
+	TourneyPrizes        []TourneyPrize       `json:"TourneyPrizes"`
+
+	// Deprecated: PapelShopOdds and PapelTicketTypeID are only used to build
+	// the default Black and Big Papel pools when PapelPools is empty.
+	PapelShopOdds     []ItemProbability `json:"PapelShopOdds"`
+	PapelTicketTypeID uint32            `json:"PapelTicketTypeID"`
 }
 
 type configFileProvider struct {
@@ -71,7 +421,19 @@ type configFileProvider struct {
 	defaultClubSetTypeID uint32
 	defaultPang          uint64
 	courseBonusRate      map[uint8]int
-	papelShopOdds        []ItemProbability
+	papelPools           []PapelPool
+	scratchyCardTypeID   uint32
+	scratchyPrizes       []ScratchyPrize
+	rareShopStock        map[uint32]uint32
+	cards                map[uint32]Card
+	cardPacks            []CardPack
+	clubUpgrades         ClubUpgrades
+	loginBonusCalendars  []LoginBonusCalendar
+	seasons              []Season
+	achievements         []Achievement
+	dailyQuestCount      int
+	dailyQuestPool       []Quest
+	tourneyPrizes        map[int]TourneyPrize
 }
 
 type ItemProbability struct {
@@ -105,16 +467,31 @@ func FromJSONStream(r io.Reader) (Provider, error) {
 	if err != nil {
 		return nil, err
 	}
-	return FromManifest(manifest), nil
+	return FromManifest(manifest)
 }
 
-func FromManifest(manifest Manifest) Provider {
+func FromManifest(manifest Manifest) (Provider, error) {
 	provider := &configFileProvider{
 		characterDefaults:    make(map[uint8]CharacterDefaults),
 		defaultClubSetTypeID: manifest.DefaultClubSetTypeID,
 		defaultPang:          manifest.DefaultPang,
 		courseBonusRate:      make(map[uint8]int),
-		papelShopOdds:        manifest.PapelShopOdds,
+		papelPools:           manifest.PapelPools,
+		scratchyCardTypeID:   manifest.ScratchyCardTypeID,
+		scratchyPrizes:       manifest.ScratchyPrizes,
+		rareShopStock:        make(map[uint32]uint32),
+		cards:                make(map[uint32]Card),
+		cardPacks:            manifest.CardPacks,
+		clubUpgrades:         manifest.ClubUpgrades,
+		loginBonusCalendars:  manifest.LoginBonusCalendars,
+		seasons:              manifest.Seasons,
+		achievements:         manifest.Achievements,
+		dailyQuestCount:      manifest.DailyQuestCount,
+		dailyQuestPool:       manifest.DailyQuestPool,
+		tourneyPrizes:        make(map[int]TourneyPrize),
+	}
+	if len(provider.papelPools) == 0 {
+		provider.papelPools = legacyPapelPools(manifest.PapelShopOdds, manifest.PapelTicketTypeID)
 	}
 	for _, defaults := range manifest.CharacterDefaults {
 		provider.characterDefaults[defaults.CharacterID] = defaults
@@ -122,7 +499,32 @@ func FromManifest(manifest Manifest) Provider {
 	for _, course := range manifest.CourseBonusRate {
 		provider.courseBonusRate[course.CourseID] = course.BonusRate
 	}
-	return provider
+	for _, stock := range manifest.RareShopStock {
+		provider.rareShopStock[stock.ItemTypeID] = stock.Stock
+	}
+	for _, card := range manifest.Cards {
+		provider.cards[card.TypeID] = card
+	}
+	for _, prize := range manifest.TourneyPrizes {
+		provider.tourneyPrizes[prize.Place] = prize
+	}
+	for _, pool := range provider.papelPools {
+		if err := pool.validate(); err != nil {
+			return nil, err
+		}
+	}
+	for _, pack := range provider.cardPacks {
+		if err := pack.validate(); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateScratchyPrizes(provider.scratchyPrizes); err != nil {
+		return nil, err
+	}
+	if manifest.DailyQuestCount < 0 {
+		return nil, fmt.Errorf("negative daily quest count %d", manifest.DailyQuestCount)
+	}
+	return provider, nil
 }
 
 func (c *configFileProvider) GetCharacterDefaults(id uint8) CharacterDefaults {
@@ -148,6 +550,99 @@ func (c *configFileProvider) GetCourseBonus(course uint8, numPlayers, numHoles i
 	return uint64(bonusRate * numHoles * (numPlayers - 1))
 }
 
-func (c *configFileProvider) GetPapelShopOdds() []ItemProbability {
-	return c.papelShopOdds
+func (c *configFileProvider) GetPapelPools() []PapelPool {
+	return c.papelPools
+}
+
+// GetPapelPool returns the first pool for machine that is active at t. Event
+// pools should therefore be listed before the default one.
+func (c *configFileProvider) GetPapelPool(machine PapelMachine, t time.Time) (PapelPool, bool) {
+	for _, pool := range c.papelPools {
+		if pool.Machine == machine && pool.Active(t) {
+			return pool, true
+		}
+	}
+	return PapelPool{}, false
+}
+
+// GetScratchyCardTypeID returns the type of item used up by a Scratchy play,
+// or zero if Scratchy is disabled.
+func (c *configFileProvider) GetScratchyCardTypeID() uint32 {
+	return c.scratchyCardTypeID
+}
+
+func (c *configFileProvider) GetScratchyPrizes() []ScratchyPrize {
+	return c.scratchyPrizes
+}
+
+// GetRareShopStock returns how many of a Rare Shop item can be sold, or zero
+// if the item is not limited.
+func (c *configFileProvider) GetRareShopStock(itemTypeID uint32) uint32 {
+	return c.rareShopStock[itemTypeID]
+}
+
+// GetCard returns the effects of a card. ok is false if the item is not a
+// known card.
+func (c *configFileProvider) GetCard(typeID uint32) (card Card, ok bool) {
+	card, ok = c.cards[typeID]
+	return card, ok
+}
+
+func (c *configFileProvider) GetCardPacks() []CardPack {
+	return c.cardPacks
+}
+
+func (c *configFileProvider) GetClubUpgrades() ClubUpgrades {
+	return c.clubUpgrades
+}
+
+// GetLoginBonusCalendar returns the first login bonus calendar that is active
+// at t. Event calendars should therefore be listed before the default one.
+func (c *configFileProvider) GetLoginBonusCalendar(t time.Time) (LoginBonusCalendar, bool) {
+	for _, calendar := range c.loginBonusCalendars {
+		if calendar.Active(t) {
+			return calendar, true
+		}
+	}
+	return LoginBonusCalendar{}, false
+}
+
+// GetSeason returns the season that is running at t: the one with the latest
+// start time that is not after t. If no season has started, season 0 is used.
+func (c *configFileProvider) GetSeason(t time.Time) uint8 {
+	season, start := uint8(0), time.Time{}
+	for _, s := range c.seasons {
+		if !s.StartTime.After(t) && !s.StartTime.Before(start) {
+			season, start = s.Season, s.StartTime
+		}
+	}
+	return season
+}
+
+func (c *configFileProvider) GetAchievements() []Achievement {
+	return c.achievements
+}
+
+// GetDailyQuests returns the quests in rotation on the day (UTC) containing t.
+// The rotation is picked from the pool at random, but is the same for every
+// call made on the same day.
+func (c *configFileProvider) GetDailyQuests(t time.Time) []Quest {
+	n := c.dailyQuestCount
+	if n > len(c.dailyQuestPool) {
+		n = len(c.dailyQuestPool)
+	}
+	day := t.Unix() / 86400
+	rng := rand.New(rand.NewSource(day))
+	quests := make([]Quest, 0, n)
+	for _, i := range rng.Perm(len(c.dailyQuestPool))[:n] {
+		quests = append(quests, c.dailyQuestPool[i])
+	}
+	return quests
+}
+
+// GetTourneyPrize returns the prize for finishing a tournament in place. ok is
+// false if the place has no prize.
+func (c *configFileProvider) GetTourneyPrize(place int) (prize TourneyPrize, ok bool) {
+	prize, ok = c.tourneyPrizes[place]
+	return prize, ok
 }
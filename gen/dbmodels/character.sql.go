@@ -209,11 +209,11 @@ const getCharacter = `-- name: GetCharacter :one
 SELECT
     character.character_id, character.player_id, character.item_id, character.hair_color, character.shirt, character.mastery, character.part00_item_id, character.part01_item_id, character.part02_item_id, character.part03_item_id, character.part04_item_id, character.part05_item_id, character.part06_item_id, character.part07_item_id, character.part08_item_id, character.part09_item_id, character.part10_item_id, character.part11_item_id, character.part12_item_id, character.part13_item_id, character.part14_item_id, character.part15_item_id, character.part16_item_id, character.part17_item_id, character.part18_item_id, character.part19_item_id, character.part20_item_id, character.part21_item_id, character.part22_item_id, character.part23_item_id, character.part00_item_type_id, character.part01_item_type_id, character.part02_item_type_id, character.part03_item_type_id, character.part04_item_type_id, character.part05_item_type_id, character.part06_item_type_id, character.part07_item_type_id, character.part08_item_type_id, character.part09_item_type_id, character.part10_item_type_id, character.part11_item_type_id, character.part12_item_type_id, character.part13_item_type_id, character.part14_item_type_id, character.part15_item_type_id, character.part16_item_type_id, character.part17_item_type_id, character.part18_item_type_id, character.part19_item_type_id, character.part20_item_type_id, character.part21_item_type_id, character.part22_item_type_id, character.part23_item_type_id, character.aux_part0_id, character.aux_part1_id, character.aux_part2_id, character.aux_part3_id, character.aux_part4_id, character.cut_in_id,
     inventory_character.item_type_id AS character_type_id,
-    inventory_aux_part0.item_type_id AS inventory_aux_part0_type_id_FIXNULL,
-    inventory_aux_part1.item_type_id AS inventory_aux_part1_type_id_FIXNULL,
-    inventory_aux_part2.item_type_id AS inventory_aux_part2_type_id_FIXNULL,
-    inventory_aux_part3.item_type_id AS inventory_aux_part3_type_id_FIXNULL,
-    inventory_aux_part4.item_type_id AS inventory_aux_part4_type_id_FIXNULL
+    inventory_aux_part0.item_type_id AS inventory_aux_part0_type_id_,
+    inventory_aux_part1.item_type_id AS inventory_aux_part1_type_id_,
+    inventory_aux_part2.item_type_id AS inventory_aux_part2_type_id_,
+    inventory_aux_part3.item_type_id AS inventory_aux_part3_type_id_,
+    inventory_aux_part4.item_type_id AS inventory_aux_part4_type_id_
 FROM character AS character
 LEFT JOIN inventory AS inventory_character ON (character.item_id = inventory_character.item_id)
 LEFT JOIN inventory AS inventory_aux_part0 ON (character.aux_part0_id = inventory_aux_part0.item_id)
@@ -286,11 +286,11 @@ type GetCharacterRow struct {
 	AuxPart4ID                     sql.NullInt64
 	CutInID                        sql.NullInt64
 	CharacterTypeID                int64
-	InventoryAuxPart0TypeIDFIXNULL int64
-	InventoryAuxPart1TypeIDFIXNULL int64
-	InventoryAuxPart2TypeIDFIXNULL int64
-	InventoryAuxPart3TypeIDFIXNULL int64
-	InventoryAuxPart4TypeIDFIXNULL int64
+	InventoryAuxPart0TypeID        sql.NullInt64
+	InventoryAuxPart1TypeID        sql.NullInt64
+	InventoryAuxPart2TypeID        sql.NullInt64
+	InventoryAuxPart3TypeID        sql.NullInt64
+	InventoryAuxPart4TypeID        sql.NullInt64
 }
 
 func (q *Queries) GetCharacter(ctx context.Context, characterID int64) (GetCharacterRow, error) {
@@ -358,11 +358,11 @@ func (q *Queries) GetCharacter(ctx context.Context, characterID int64) (GetChara
 		&i.AuxPart4ID,
 		&i.CutInID,
 		&i.CharacterTypeID,
-		&i.InventoryAuxPart0TypeIDFIXNULL,
-		&i.InventoryAuxPart1TypeIDFIXNULL,
-		&i.InventoryAuxPart2TypeIDFIXNULL,
-		&i.InventoryAuxPart3TypeIDFIXNULL,
-		&i.InventoryAuxPart4TypeIDFIXNULL,
+		&i.InventoryAuxPart0TypeID,
+		&i.InventoryAuxPart1TypeID,
+		&i.InventoryAuxPart2TypeID,
+		&i.InventoryAuxPart3TypeID,
+		&i.InventoryAuxPart4TypeID,
 	)
 	return i, err
 }
@@ -588,8 +588,13 @@ SET
     part21_item_type_id = ?,
     part22_item_type_id = ?,
     part23_item_type_id = ?,
+    aux_part0_id = ?,
+    aux_part1_id = ?,
+    aux_part2_id = ?,
+    aux_part3_id = ?,
+    aux_part4_id = ?,
     cut_in_id = ?
-WHERE character_id = ?
+WHERE character_id = ? AND player_id = ?
 RETURNING character_id, player_id, item_id, hair_color, shirt, mastery, part00_item_id, part01_item_id, part02_item_id, part03_item_id, part04_item_id, part05_item_id, part06_item_id, part07_item_id, part08_item_id, part09_item_id, part10_item_id, part11_item_id, part12_item_id, part13_item_id, part14_item_id, part15_item_id, part16_item_id, part17_item_id, part18_item_id, part19_item_id, part20_item_id, part21_item_id, part22_item_id, part23_item_id, part00_item_type_id, part01_item_type_id, part02_item_type_id, part03_item_type_id, part04_item_type_id, part05_item_type_id, part06_item_type_id, part07_item_type_id, part08_item_type_id, part09_item_type_id, part10_item_type_id, part11_item_type_id, part12_item_type_id, part13_item_type_id, part14_item_type_id, part15_item_type_id, part16_item_type_id, part17_item_type_id, part18_item_type_id, part19_item_type_id, part20_item_type_id, part21_item_type_id, part22_item_type_id, part23_item_type_id, aux_part0_id, aux_part1_id, aux_part2_id, aux_part3_id, aux_part4_id, cut_in_id
 `
 
@@ -642,8 +647,14 @@ type SetCharacterPartsParams struct {
 	Part21ItemTypeID int64
 	Part22ItemTypeID int64
 	Part23ItemTypeID int64
+	AuxPart0ID       sql.NullInt64
+	AuxPart1ID       sql.NullInt64
+	AuxPart2ID       sql.NullInt64
+	AuxPart3ID       sql.NullInt64
+	AuxPart4ID       sql.NullInt64
 	CutInID          sql.NullInt64
 	CharacterID      int64
+	PlayerID         int64
 }
 
 func (q *Queries) SetCharacterParts(ctx context.Context, arg SetCharacterPartsParams) (Character, error) {
@@ -696,8 +707,14 @@ func (q *Queries) SetCharacterParts(ctx context.Context, arg SetCharacterPartsPa
 		arg.Part21ItemTypeID,
 		arg.Part22ItemTypeID,
 		arg.Part23ItemTypeID,
+		arg.AuxPart0ID,
+		arg.AuxPart1ID,
+		arg.AuxPart2ID,
+		arg.AuxPart3ID,
+		arg.AuxPart4ID,
 		arg.CutInID,
 		arg.CharacterID,
+		arg.PlayerID,
 	)
 	var i Character
 	err := row.Scan(
@@ -14,35 +14,106 @@ const addItemToInventory = `-- name: AddItemToInventory :one
 INSERT INTO inventory (
     player_id,
     item_type_id,
-    quantity
+    quantity,
+    purchased_at,
+    expires_at
 ) VALUES (
+    ?,
+    ?,
     ?,
     ?,
     ?
 )
-RETURNING item_id, player_id, item_type_id, quantity
+RETURNING item_id, player_id, item_type_id, quantity, purchased_at, expires_at, location, club_power, club_control, club_accuracy, club_spin, club_curve, caddie_level, caddie_exp, mascot_text
 `
 
 type AddItemToInventoryParams struct {
-	PlayerID   int64
-	ItemTypeID int64
-	Quantity   sql.NullInt64
+	PlayerID    int64
+	ItemTypeID  int64
+	Quantity    sql.NullInt64
+	PurchasedAt sql.NullInt64
+	ExpiresAt   sql.NullInt64
 }
 
 func (q *Queries) AddItemToInventory(ctx context.Context, arg AddItemToInventoryParams) (Inventory, error) {
-	row := q.db.QueryRowContext(ctx, addItemToInventory, arg.PlayerID, arg.ItemTypeID, arg.Quantity)
+	row := q.db.QueryRowContext(ctx, addItemToInventory,
+		arg.PlayerID,
+		arg.ItemTypeID,
+		arg.Quantity,
+		arg.PurchasedAt,
+		arg.ExpiresAt,
+	)
 	var i Inventory
 	err := row.Scan(
 		&i.ItemID,
 		&i.PlayerID,
 		&i.ItemTypeID,
 		&i.Quantity,
+		&i.PurchasedAt,
+		&i.ExpiresAt,
+		&i.Location,
+		&i.ClubPower,
+		&i.ClubControl,
+		&i.ClubAccuracy,
+		&i.ClubSpin,
+		&i.ClubCurve,
+		&i.CaddieLevel,
+		&i.CaddieExp,
+		&i.MascotText,
 	)
 	return i, err
 }
 
+const getExpiredItems = `-- name: GetExpiredItems :many
+SELECT item_id, player_id, item_type_id, quantity, purchased_at, expires_at, location, club_power, club_control, club_accuracy, club_spin, club_curve, caddie_level, caddie_exp, mascot_text FROM inventory WHERE location = 0 AND expires_at IS NOT NULL AND expires_at <= ? ORDER BY item_id LIMIT ?
+`
+
+type GetExpiredItemsParams struct {
+	ExpiresAt sql.NullInt64
+	Limit     int64
+}
+
+func (q *Queries) GetExpiredItems(ctx context.Context, arg GetExpiredItemsParams) ([]Inventory, error) {
+	rows, err := q.db.QueryContext(ctx, getExpiredItems, arg.ExpiresAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Inventory
+	for rows.Next() {
+		var i Inventory
+		if err := rows.Scan(
+			&i.ItemID,
+			&i.PlayerID,
+			&i.ItemTypeID,
+			&i.Quantity,
+			&i.PurchasedAt,
+			&i.ExpiresAt,
+			&i.Location,
+			&i.ClubPower,
+			&i.ClubControl,
+			&i.ClubAccuracy,
+			&i.ClubSpin,
+			&i.ClubCurve,
+			&i.CaddieLevel,
+			&i.CaddieExp,
+			&i.MascotText,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getItem = `-- name: GetItem :one
-SELECT item_id, player_id, item_type_id, quantity FROM inventory WHERE player_id = ? AND item_id = ?
+SELECT item_id, player_id, item_type_id, quantity, purchased_at, expires_at, location, club_power, club_control, club_accuracy, club_spin, club_curve, caddie_level, caddie_exp, mascot_text FROM inventory WHERE player_id = ? AND item_id = ? AND location = 0
 `
 
 type GetItemParams struct {
@@ -58,12 +129,56 @@ func (q *Queries) GetItem(ctx context.Context, arg GetItemParams) (Inventory, er
 		&i.PlayerID,
 		&i.ItemTypeID,
 		&i.Quantity,
+		&i.PurchasedAt,
+		&i.ExpiresAt,
+		&i.Location,
+		&i.ClubPower,
+		&i.ClubControl,
+		&i.ClubAccuracy,
+		&i.ClubSpin,
+		&i.ClubCurve,
+		&i.CaddieLevel,
+		&i.CaddieExp,
+		&i.MascotText,
+	)
+	return i, err
+}
+
+const getItemInLocation = `-- name: GetItemInLocation :one
+SELECT item_id, player_id, item_type_id, quantity, purchased_at, expires_at, location, club_power, club_control, club_accuracy, club_spin, club_curve, caddie_level, caddie_exp, mascot_text FROM inventory WHERE player_id = ? AND item_id = ? AND location = ?
+`
+
+type GetItemInLocationParams struct {
+	PlayerID int64
+	ItemID   int64
+	Location int64
+}
+
+func (q *Queries) GetItemInLocation(ctx context.Context, arg GetItemInLocationParams) (Inventory, error) {
+	row := q.db.QueryRowContext(ctx, getItemInLocation, arg.PlayerID, arg.ItemID, arg.Location)
+	var i Inventory
+	err := row.Scan(
+		&i.ItemID,
+		&i.PlayerID,
+		&i.ItemTypeID,
+		&i.Quantity,
+		&i.PurchasedAt,
+		&i.ExpiresAt,
+		&i.Location,
+		&i.ClubPower,
+		&i.ClubControl,
+		&i.ClubAccuracy,
+		&i.ClubSpin,
+		&i.ClubCurve,
+		&i.CaddieLevel,
+		&i.CaddieExp,
+		&i.MascotText,
 	)
 	return i, err
 }
 
 const getItemsByTypeID = `-- name: GetItemsByTypeID :many
-SELECT item_id, player_id, item_type_id, quantity FROM inventory WHERE player_id = ? AND item_type_id = ?
+SELECT item_id, player_id, item_type_id, quantity, purchased_at, expires_at, location, club_power, club_control, club_accuracy, club_spin, club_curve, caddie_level, caddie_exp, mascot_text FROM inventory WHERE player_id = ? AND item_type_id = ? AND location = 0
 `
 
 type GetItemsByTypeIDParams struct {
@@ -85,6 +200,65 @@ func (q *Queries) GetItemsByTypeID(ctx context.Context, arg GetItemsByTypeIDPara
 			&i.PlayerID,
 			&i.ItemTypeID,
 			&i.Quantity,
+			&i.PurchasedAt,
+			&i.ExpiresAt,
+			&i.Location,
+			&i.ClubPower,
+			&i.ClubControl,
+			&i.ClubAccuracy,
+			&i.ClubSpin,
+			&i.ClubCurve,
+			&i.CaddieLevel,
+			&i.CaddieExp,
+			&i.MascotText,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getItemsInLocation = `-- name: GetItemsInLocation :many
+SELECT item_id, player_id, item_type_id, quantity, purchased_at, expires_at, location, club_power, club_control, club_accuracy, club_spin, club_curve, caddie_level, caddie_exp, mascot_text FROM inventory WHERE player_id = ? AND location = ? ORDER BY item_id
+`
+
+type GetItemsInLocationParams struct {
+	PlayerID int64
+	Location int64
+}
+
+func (q *Queries) GetItemsInLocation(ctx context.Context, arg GetItemsInLocationParams) ([]Inventory, error) {
+	rows, err := q.db.QueryContext(ctx, getItemsInLocation, arg.PlayerID, arg.Location)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Inventory
+	for rows.Next() {
+		var i Inventory
+		if err := rows.Scan(
+			&i.ItemID,
+			&i.PlayerID,
+			&i.ItemTypeID,
+			&i.Quantity,
+			&i.PurchasedAt,
+			&i.ExpiresAt,
+			&i.Location,
+			&i.ClubPower,
+			&i.ClubControl,
+			&i.ClubAccuracy,
+			&i.ClubSpin,
+			&i.ClubCurve,
+			&i.CaddieLevel,
+			&i.CaddieExp,
+			&i.MascotText,
 		); err != nil {
 			return nil, err
 		}
@@ -100,7 +274,7 @@ func (q *Queries) GetItemsByTypeID(ctx context.Context, arg GetItemsByTypeIDPara
 }
 
 const getPlayerInventory = `-- name: GetPlayerInventory :many
-SELECT item_id, player_id, item_type_id, quantity FROM inventory WHERE player_id = ?
+SELECT item_id, player_id, item_type_id, quantity, purchased_at, expires_at, location, club_power, club_control, club_accuracy, club_spin, club_curve, caddie_level, caddie_exp, mascot_text FROM inventory WHERE player_id = ? AND location = 0
 `
 
 func (q *Queries) GetPlayerInventory(ctx context.Context, playerID int64) ([]Inventory, error) {
@@ -117,6 +291,17 @@ func (q *Queries) GetPlayerInventory(ctx context.Context, playerID int64) ([]Inv
 			&i.PlayerID,
 			&i.ItemTypeID,
 			&i.Quantity,
+			&i.PurchasedAt,
+			&i.ExpiresAt,
+			&i.Location,
+			&i.ClubPower,
+			&i.ClubControl,
+			&i.ClubAccuracy,
+			&i.ClubSpin,
+			&i.ClubCurve,
+			&i.CaddieLevel,
+			&i.CaddieExp,
+			&i.MascotText,
 		); err != nil {
 			return nil, err
 		}
@@ -131,6 +316,49 @@ func (q *Queries) GetPlayerInventory(ctx context.Context, playerID int64) ([]Inv
 	return items, nil
 }
 
+const moveItem = `-- name: MoveItem :one
+UPDATE inventory SET
+    player_id = ?1,
+    location = ?2
+WHERE player_id = ?3 AND item_id = ?4
+RETURNING item_id, player_id, item_type_id, quantity, purchased_at, expires_at, location, club_power, club_control, club_accuracy, club_spin, club_curve, caddie_level, caddie_exp, mascot_text
+`
+
+type MoveItemParams struct {
+	NewPlayerID int64
+	Location    int64
+	PlayerID    int64
+	ItemID      int64
+}
+
+func (q *Queries) MoveItem(ctx context.Context, arg MoveItemParams) (Inventory, error) {
+	row := q.db.QueryRowContext(ctx, moveItem,
+		arg.NewPlayerID,
+		arg.Location,
+		arg.PlayerID,
+		arg.ItemID,
+	)
+	var i Inventory
+	err := row.Scan(
+		&i.ItemID,
+		&i.PlayerID,
+		&i.ItemTypeID,
+		&i.Quantity,
+		&i.PurchasedAt,
+		&i.ExpiresAt,
+		&i.Location,
+		&i.ClubPower,
+		&i.ClubControl,
+		&i.ClubAccuracy,
+		&i.ClubSpin,
+		&i.ClubCurve,
+		&i.CaddieLevel,
+		&i.CaddieExp,
+		&i.MascotText,
+	)
+	return i, err
+}
+
 const removeItemFromInventory = `-- name: RemoveItemFromInventory :exec
 DELETE FROM inventory WHERE player_id = ? AND item_id = ?
 `
@@ -145,8 +373,136 @@ func (q *Queries) RemoveItemFromInventory(ctx context.Context, arg RemoveItemFro
 	return err
 }
 
+const setCaddieExp = `-- name: SetCaddieExp :one
+UPDATE inventory SET
+    caddie_level = ?,
+    caddie_exp   = ?
+WHERE player_id = ? AND item_id = ?
+RETURNING item_id, player_id, item_type_id, quantity, purchased_at, expires_at, location, club_power, club_control, club_accuracy, club_spin, club_curve, caddie_level, caddie_exp, mascot_text
+`
+
+type SetCaddieExpParams struct {
+	CaddieLevel int64
+	CaddieExp   int64
+	PlayerID    int64
+	ItemID      int64
+}
+
+func (q *Queries) SetCaddieExp(ctx context.Context, arg SetCaddieExpParams) (Inventory, error) {
+	row := q.db.QueryRowContext(ctx, setCaddieExp,
+		arg.CaddieLevel,
+		arg.CaddieExp,
+		arg.PlayerID,
+		arg.ItemID,
+	)
+	var i Inventory
+	err := row.Scan(
+		&i.ItemID,
+		&i.PlayerID,
+		&i.ItemTypeID,
+		&i.Quantity,
+		&i.PurchasedAt,
+		&i.ExpiresAt,
+		&i.Location,
+		&i.ClubPower,
+		&i.ClubControl,
+		&i.ClubAccuracy,
+		&i.ClubSpin,
+		&i.ClubCurve,
+		&i.CaddieLevel,
+		&i.CaddieExp,
+		&i.MascotText,
+	)
+	return i, err
+}
+
+const setClubStats = `-- name: SetClubStats :one
+UPDATE inventory SET
+    club_power    = ?,
+    club_control  = ?,
+    club_accuracy = ?,
+    club_spin     = ?,
+    club_curve    = ?
+WHERE player_id = ? AND item_id = ?
+RETURNING item_id, player_id, item_type_id, quantity, purchased_at, expires_at, location, club_power, club_control, club_accuracy, club_spin, club_curve, caddie_level, caddie_exp, mascot_text
+`
+
+type SetClubStatsParams struct {
+	ClubPower    int64
+	ClubControl  int64
+	ClubAccuracy int64
+	ClubSpin     int64
+	ClubCurve    int64
+	PlayerID     int64
+	ItemID       int64
+}
+
+func (q *Queries) SetClubStats(ctx context.Context, arg SetClubStatsParams) (Inventory, error) {
+	row := q.db.QueryRowContext(ctx, setClubStats,
+		arg.ClubPower,
+		arg.ClubControl,
+		arg.ClubAccuracy,
+		arg.ClubSpin,
+		arg.ClubCurve,
+		arg.PlayerID,
+		arg.ItemID,
+	)
+	var i Inventory
+	err := row.Scan(
+		&i.ItemID,
+		&i.PlayerID,
+		&i.ItemTypeID,
+		&i.Quantity,
+		&i.PurchasedAt,
+		&i.ExpiresAt,
+		&i.Location,
+		&i.ClubPower,
+		&i.ClubControl,
+		&i.ClubAccuracy,
+		&i.ClubSpin,
+		&i.ClubCurve,
+		&i.CaddieLevel,
+		&i.CaddieExp,
+		&i.MascotText,
+	)
+	return i, err
+}
+
+const setItemExpiry = `-- name: SetItemExpiry :one
+UPDATE inventory SET expires_at = ? WHERE player_id = ? AND item_id = ? RETURNING item_id, player_id, item_type_id, quantity, purchased_at, expires_at, location, club_power, club_control, club_accuracy, club_spin, club_curve, caddie_level, caddie_exp, mascot_text
+`
+
+type SetItemExpiryParams struct {
+	ExpiresAt sql.NullInt64
+	PlayerID  int64
+	ItemID    int64
+}
+
+func (q *Queries) SetItemExpiry(ctx context.Context, arg SetItemExpiryParams) (Inventory, error) {
+	row := q.db.QueryRowContext(ctx, setItemExpiry, arg.ExpiresAt, arg.PlayerID, arg.ItemID)
+	var i Inventory
+	err := row.Scan(
+		&i.ItemID,
+		&i.PlayerID,
+		&i.ItemTypeID,
+		&i.Quantity,
+		&i.PurchasedAt,
+		&i.ExpiresAt,
+		&i.Location,
+		&i.ClubPower,
+		&i.ClubControl,
+		&i.ClubAccuracy,
+		&i.ClubSpin,
+		&i.ClubCurve,
+		&i.CaddieLevel,
+		&i.CaddieExp,
+		&i.MascotText,
+	)
+	return i, err
+}
+
 const setItemQuantity = `-- name: SetItemQuantity :one
-UPDATE inventory SET quantity = ? WHERE player_id = ? AND item_id = ? RETURNING item_id, player_id, item_type_id, quantity
+UPDATE inventory SET quantity = ? WHERE player_id = ? AND item_id = ? RETURNING item_id, player_id, item_type_id, quantity, purchased_at, expires_at, location, club_power, club_control, club_accuracy, club_spin, club_curve, caddie_level, caddie_exp, mascot_text
 `
 
 type SetItemQuantityParams struct {
@@ -163,6 +519,198 @@ func (q *Queries) SetItemQuantity(ctx context.Context, arg SetItemQuantityParams
 		&i.PlayerID,
 		&i.ItemTypeID,
 		&i.Quantity,
+		&i.PurchasedAt,
+		&i.ExpiresAt,
+		&i.Location,
+		&i.ClubPower,
+		&i.ClubControl,
+		&i.ClubAccuracy,
+		&i.ClubSpin,
+		&i.ClubCurve,
+		&i.CaddieLevel,
+		&i.CaddieExp,
+		&i.MascotText,
 	)
 	return i, err
 }
+
+const setMascotText = `-- name: SetMascotText :one
+UPDATE inventory SET mascot_text = ? WHERE player_id = ? AND item_id = ? RETURNING item_id, player_id, item_type_id, quantity, purchased_at, expires_at, location, club_power, club_control, club_accuracy, club_spin, club_curve, caddie_level, caddie_exp, mascot_text
+`
+
+type SetMascotTextParams struct {
+	MascotText sql.NullString
+	PlayerID   int64
+	ItemID     int64
+}
+
+func (q *Queries) SetMascotText(ctx context.Context, arg SetMascotTextParams) (Inventory, error) {
+	row := q.db.QueryRowContext(ctx, setMascotText, arg.MascotText, arg.PlayerID, arg.ItemID)
+	var i Inventory
+	err := row.Scan(
+		&i.ItemID,
+		&i.PlayerID,
+		&i.ItemTypeID,
+		&i.Quantity,
+		&i.PurchasedAt,
+		&i.ExpiresAt,
+		&i.Location,
+		&i.ClubPower,
+		&i.ClubControl,
+		&i.ClubAccuracy,
+		&i.ClubSpin,
+		&i.ClubCurve,
+		&i.CaddieLevel,
+		&i.CaddieExp,
+		&i.MascotText,
+	)
+	return i, err
+}
+
+const unequipCharacterItem = `-- name: UnequipCharacterItem :exec
+UPDATE character SET
+    part00_item_type_id = IIF(part00_item_id = ?1, ?2, part00_item_type_id),
+    part00_item_id = NULLIF(part00_item_id, ?1),
+    part01_item_type_id = IIF(part01_item_id = ?1, ?3, part01_item_type_id),
+    part01_item_id = NULLIF(part01_item_id, ?1),
+    part02_item_type_id = IIF(part02_item_id = ?1, ?4, part02_item_type_id),
+    part02_item_id = NULLIF(part02_item_id, ?1),
+    part03_item_type_id = IIF(part03_item_id = ?1, ?5, part03_item_type_id),
+    part03_item_id = NULLIF(part03_item_id, ?1),
+    part04_item_type_id = IIF(part04_item_id = ?1, ?6, part04_item_type_id),
+    part04_item_id = NULLIF(part04_item_id, ?1),
+    part05_item_type_id = IIF(part05_item_id = ?1, ?7, part05_item_type_id),
+    part05_item_id = NULLIF(part05_item_id, ?1),
+    part06_item_type_id = IIF(part06_item_id = ?1, ?8, part06_item_type_id),
+    part06_item_id = NULLIF(part06_item_id, ?1),
+    part07_item_type_id = IIF(part07_item_id = ?1, ?9, part07_item_type_id),
+    part07_item_id = NULLIF(part07_item_id, ?1),
+    part08_item_type_id = IIF(part08_item_id = ?1, ?10, part08_item_type_id),
+    part08_item_id = NULLIF(part08_item_id, ?1),
+    part09_item_type_id = IIF(part09_item_id = ?1, ?11, part09_item_type_id),
+    part09_item_id = NULLIF(part09_item_id, ?1),
+    part10_item_type_id = IIF(part10_item_id = ?1, ?12, part10_item_type_id),
+    part10_item_id = NULLIF(part10_item_id, ?1),
+    part11_item_type_id = IIF(part11_item_id = ?1, ?13, part11_item_type_id),
+    part11_item_id = NULLIF(part11_item_id, ?1),
+    part12_item_type_id = IIF(part12_item_id = ?1, ?14, part12_item_type_id),
+    part12_item_id = NULLIF(part12_item_id, ?1),
+    part13_item_type_id = IIF(part13_item_id = ?1, ?15, part13_item_type_id),
+    part13_item_id = NULLIF(part13_item_id, ?1),
+    part14_item_type_id = IIF(part14_item_id = ?1, ?16, part14_item_type_id),
+    part14_item_id = NULLIF(part14_item_id, ?1),
+    part15_item_type_id = IIF(part15_item_id = ?1, ?17, part15_item_type_id),
+    part15_item_id = NULLIF(part15_item_id, ?1),
+    part16_item_type_id = IIF(part16_item_id = ?1, ?18, part16_item_type_id),
+    part16_item_id = NULLIF(part16_item_id, ?1),
+    part17_item_type_id = IIF(part17_item_id = ?1, ?19, part17_item_type_id),
+    part17_item_id = NULLIF(part17_item_id, ?1),
+    part18_item_type_id = IIF(part18_item_id = ?1, ?20, part18_item_type_id),
+    part18_item_id = NULLIF(part18_item_id, ?1),
+    part19_item_type_id = IIF(part19_item_id = ?1, ?21, part19_item_type_id),
+    part19_item_id = NULLIF(part19_item_id, ?1),
+    part20_item_type_id = IIF(part20_item_id = ?1, ?22, part20_item_type_id),
+    part20_item_id = NULLIF(part20_item_id, ?1),
+    part21_item_type_id = IIF(part21_item_id = ?1, ?23, part21_item_type_id),
+    part21_item_id = NULLIF(part21_item_id, ?1),
+    part22_item_type_id = IIF(part22_item_id = ?1, ?24, part22_item_type_id),
+    part22_item_id = NULLIF(part22_item_id, ?1),
+    part23_item_type_id = IIF(part23_item_id = ?1, ?25, part23_item_type_id),
+    part23_item_id = NULLIF(part23_item_id, ?1),
+    aux_part0_id = NULLIF(aux_part0_id, ?1),
+    aux_part1_id = NULLIF(aux_part1_id, ?1),
+    aux_part2_id = NULLIF(aux_part2_id, ?1),
+    aux_part3_id = NULLIF(aux_part3_id, ?1),
+    aux_part4_id = NULLIF(aux_part4_id, ?1),
+    cut_in_id = NULLIF(cut_in_id, ?1)
+WHERE character_id = ?26 AND player_id = ?27
+`
+
+type UnequipCharacterItemParams struct {
+	ItemID              sql.NullInt64
+	Part00DefaultTypeID interface{}
+	Part01DefaultTypeID interface{}
+	Part02DefaultTypeID interface{}
+	Part03DefaultTypeID interface{}
+	Part04DefaultTypeID interface{}
+	Part05DefaultTypeID interface{}
+	Part06DefaultTypeID interface{}
+	Part07DefaultTypeID interface{}
+	Part08DefaultTypeID interface{}
+	Part09DefaultTypeID interface{}
+	Part10DefaultTypeID interface{}
+	Part11DefaultTypeID interface{}
+	Part12DefaultTypeID interface{}
+	Part13DefaultTypeID interface{}
+	Part14DefaultTypeID interface{}
+	Part15DefaultTypeID interface{}
+	Part16DefaultTypeID interface{}
+	Part17DefaultTypeID interface{}
+	Part18DefaultTypeID interface{}
+	Part19DefaultTypeID interface{}
+	Part20DefaultTypeID interface{}
+	Part21DefaultTypeID interface{}
+	Part22DefaultTypeID interface{}
+	Part23DefaultTypeID interface{}
+	CharacterID         int64
+	PlayerID            int64
+}
+
+func (q *Queries) UnequipCharacterItem(ctx context.Context, arg UnequipCharacterItemParams) error {
+	_, err := q.db.ExecContext(ctx, unequipCharacterItem,
+		arg.ItemID,
+		arg.Part00DefaultTypeID,
+		arg.Part01DefaultTypeID,
+		arg.Part02DefaultTypeID,
+		arg.Part03DefaultTypeID,
+		arg.Part04DefaultTypeID,
+		arg.Part05DefaultTypeID,
+		arg.Part06DefaultTypeID,
+		arg.Part07DefaultTypeID,
+		arg.Part08DefaultTypeID,
+		arg.Part09DefaultTypeID,
+		arg.Part10DefaultTypeID,
+		arg.Part11DefaultTypeID,
+		arg.Part12DefaultTypeID,
+		arg.Part13DefaultTypeID,
+		arg.Part14DefaultTypeID,
+		arg.Part15DefaultTypeID,
+		arg.Part16DefaultTypeID,
+		arg.Part17DefaultTypeID,
+		arg.Part18DefaultTypeID,
+		arg.Part19DefaultTypeID,
+		arg.Part20DefaultTypeID,
+		arg.Part21DefaultTypeID,
+		arg.Part22DefaultTypeID,
+		arg.Part23DefaultTypeID,
+		arg.CharacterID,
+		arg.PlayerID,
+	)
+	return err
+}
+
+const unequipPlayerItem = `-- name: UnequipPlayerItem :exec
+UPDATE player SET
+    caddie_id     = NULLIF(caddie_id, ?1),
+    mascot_id     = NULLIF(mascot_id, ?1),
+    club_id       = NULLIF(club_id, ?1),
+    background_id = NULLIF(background_id, ?1),
+    frame_id      = NULLIF(frame_id, ?1),
+    sticker_id    = NULLIF(sticker_id, ?1),
+    slot_id       = NULLIF(slot_id, ?1),
+    cut_in_id     = NULLIF(cut_in_id, ?1),
+    title_id      = NULLIF(title_id, ?1),
+    poster0_id    = NULLIF(poster0_id, ?1),
+    poster1_id    = NULLIF(poster1_id, ?1)
+WHERE player_id = ?2
+`
+
+type UnequipPlayerItemParams struct {
+	ItemID   interface{}
+	PlayerID int64
+}
+
+func (q *Queries) UnequipPlayerItem(ctx context.Context, arg UnequipPlayerItemParams) error {
+	_, err := q.db.ExecContext(ctx, unequipPlayerItem, arg.ItemID, arg.PlayerID)
+	return err
+}
@@ -8,6 +8,27 @@ import (
 	"database/sql"
 )
 
+type AchievementCompletion struct {
+	PlayerID          int64
+	AchievementTypeID int64
+	StageTypeID       int64
+	CompletedAt       int64
+}
+
+type AchievementCounter struct {
+	PlayerID      int64
+	CounterTypeID int64
+	Value         int64
+}
+
+type CardSlot struct {
+	PlayerID   int64
+	ItemID     int64
+	SlotKind   int64
+	SlotNum    int64
+	CardTypeID int64
+}
+
 type Character struct {
 	CharacterID      int64
 	PlayerID         int64
@@ -71,45 +92,192 @@ type Character struct {
 	CutInID          sql.NullInt64
 }
 
-type Inventory struct {
-	ItemID     int64
+type CourseRecord struct {
+	PlayerID     int64
+	Season       int64
+	CourseID     int64
+	CharTypeID   int64
+	BestScore    int64
+	BestPang     int64
+	TotalStrokes int64
+	TotalPutts   int64
+	TotalHoles   int64
+	TotalScore   int64
+}
+
+type CurrencyLedger struct {
+	LedgerID      int64
+	PlayerID      int64
+	Reason        int64
+	PangDelta     int64
+	PointsDelta   int64
+	PangBalance   int64
+	PointsBalance int64
+	ItemTypeID    int64
+	Note          string
+	CreatedAt     int64
+}
+
+type DailyQuest struct {
+	PlayerID    int64
+	Day         int64
+	QuestTypeID int64
+	Progress    int64
+	Claimed     bool
+}
+
+type Guild struct {
+	GuildID        int64
+	Name           string
+	Description    string
+	EmblemImage    string
+	LeaderPlayerID int64
+	Pang           int64
+	Points         int64
+	CreatedAt      int64
+}
+
+type GuildApplication struct {
+	GuildID   int64
+	PlayerID  int64
+	Message   string
+	CreatedAt int64
+}
+
+type GuildMember struct {
 	PlayerID   int64
-	ItemTypeID int64
-	Quantity   sql.NullInt64
+	GuildID    int64
+	MemberRole int64
+	JoinedAt   int64
 }
 
-type Player struct {
+type Inventory struct {
+	ItemID       int64
+	PlayerID     int64
+	ItemTypeID   int64
+	Quantity     sql.NullInt64
+	PurchasedAt  sql.NullInt64
+	ExpiresAt    sql.NullInt64
+	Location     int64
+	ClubPower    int64
+	ClubControl  int64
+	ClubAccuracy int64
+	ClubSpin     int64
+	ClubCurve    int64
+	CaddieLevel  int64
+	CaddieExp    int64
+	MascotText   sql.NullString
+}
+
+type LoginBonusStreak struct {
 	PlayerID     int64
-	Username     string
-	Nickname     sql.NullString
-	PasswordHash string
-	Pang         int64
-	Points       int64
-	Rank         int64
-	BallTypeID   int64
-	MascotTypeID int64
-	Slot0TypeID  int64
-	Slot1TypeID  int64
-	Slot2TypeID  int64
-	Slot3TypeID  int64
-	Slot4TypeID  int64
-	Slot5TypeID  int64
-	Slot6TypeID  int64
-	Slot7TypeID  int64
-	Slot8TypeID  int64
-	Slot9TypeID  int64
-	CaddieID     sql.NullInt64
-	ClubID       sql.NullInt64
-	BackgroundID sql.NullInt64
-	FrameID      sql.NullInt64
-	StickerID    sql.NullInt64
-	SlotID       sql.NullInt64
-	CutInID      sql.NullInt64
-	TitleID      sql.NullInt64
-	Poster0ID    sql.NullInt64
-	Poster1ID    sql.NullInt64
-	CharacterID  sql.NullInt64
-	Exp          int64
+	Calendar     string
+	Streak       int64
+	LastClaimDay int64
+}
+
+type Mail struct {
+	MailID            int64
+	SenderPlayerID    sql.NullInt64
+	SenderNickname    string
+	RecipientPlayerID int64
+	Body              string
+	Pang              int64
+	IsRead            bool
+	Claimed           bool
+	CreatedAt         int64
+}
+
+type MailAttachment struct {
+	AttachmentID int64
+	MailID       int64
+	ItemTypeID   int64
+	Quantity     int64
+}
+
+type MyroomFurniture struct {
+	ItemID   int64
+	PlayerID int64
+	PosX     float64
+	PosY     float64
+	PosZ     float64
+	Rotation float64
+}
+
+type PapelPity struct {
+	PlayerID int64
+	PoolName string
+	Plays    int64
+}
+
+type Player struct {
+	PlayerID             int64
+	Username             string
+	Nickname             sql.NullString
+	PasswordHash         string
+	Pang                 int64
+	Points               int64
+	Rank                 int64
+	BallTypeID           int64
+	Slot0TypeID          int64
+	Slot1TypeID          int64
+	Slot2TypeID          int64
+	Slot3TypeID          int64
+	Slot4TypeID          int64
+	Slot5TypeID          int64
+	Slot6TypeID          int64
+	Slot7TypeID          int64
+	Slot8TypeID          int64
+	Slot9TypeID          int64
+	CaddieID             sql.NullInt64
+	ClubID               sql.NullInt64
+	BackgroundID         sql.NullInt64
+	FrameID              sql.NullInt64
+	StickerID            sql.NullInt64
+	SlotID               sql.NullInt64
+	CutInID              sql.NullInt64
+	TitleID              sql.NullInt64
+	Poster0ID            sql.NullInt64
+	Poster1ID            sql.NullInt64
+	CharacterID          sql.NullInt64
+	Exp                  int64
+	LockerPinHash        sql.NullString
+	LockerFailedAttempts int64
+	LockerLockedUntil    sql.NullInt64
+	LockerPang           int64
+	MascotID             sql.NullInt64
+}
+
+type PlayerStat struct {
+	PlayerID       int64
+	TotalStrokes   int64
+	TotalPutts     int64
+	LongestDrive   float64
+	PangyaHits     int64
+	Timeouts       int64
+	Obs            int64
+	TotalDistance  int64
+	TotalHoles     int64
+	HoleUnfinished int64
+	TotalHio       int64
+	BunkersHit     int64
+	FairwaysHit    int64
+	TotalAlbatross int64
+	PuttIns        int64
+	LongestPutt    float64
+	LongestChip    float64
+	TotalScore     int64
+	BestPang       int64
+	GamesPlayed    int64
+	Quits          int64
+	TeamHole       int64
+	TeamWin        int64
+	TeamGame       int64
+}
+
+type RareShopSale struct {
+	ItemTypeID int64
+	Sold       int64
 }
 
 type Session struct {
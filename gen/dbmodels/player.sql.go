@@ -19,7 +19,7 @@ INSERT INTO player (
 ) VALUES (
     ?, ?, ?, ?
 )
-RETURNING player_id, username, nickname, password_hash, pang, points, rank, ball_type_id, mascot_type_id, slot0_type_id, slot1_type_id, slot2_type_id, slot3_type_id, slot4_type_id, slot5_type_id, slot6_type_id, slot7_type_id, slot8_type_id, slot9_type_id, caddie_id, club_id, background_id, frame_id, sticker_id, slot_id, cut_in_id, title_id, poster0_id, poster1_id, character_id, exp
+RETURNING player_id, username, nickname, password_hash, pang, points, rank, ball_type_id, slot0_type_id, slot1_type_id, slot2_type_id, slot3_type_id, slot4_type_id, slot5_type_id, slot6_type_id, slot7_type_id, slot8_type_id, slot9_type_id, caddie_id, club_id, background_id, frame_id, sticker_id, slot_id, cut_in_id, title_id, poster0_id, poster1_id, character_id, exp, locker_pin_hash, locker_failed_attempts, locker_locked_until, locker_pang, mascot_id
 `
 
 type CreatePlayerParams struct {
@@ -46,7 +46,6 @@ func (q *Queries) CreatePlayer(ctx context.Context, arg CreatePlayerParams) (Pla
 		&i.Points,
 		&i.Rank,
 		&i.BallTypeID,
-		&i.MascotTypeID,
 		&i.Slot0TypeID,
 		&i.Slot1TypeID,
 		&i.Slot2TypeID,
@@ -69,17 +68,62 @@ func (q *Queries) CreatePlayer(ctx context.Context, arg CreatePlayerParams) (Pla
 		&i.Poster1ID,
 		&i.CharacterID,
 		&i.Exp,
+		&i.LockerPinHash,
+		&i.LockerFailedAttempts,
+		&i.LockerLockedUntil,
+		&i.LockerPang,
+		&i.MascotID,
+	)
+	return i, err
+}
+
+const getEquippedCaddie = `-- name: GetEquippedCaddie :one
+SELECT inventory.item_id, inventory.player_id, inventory.item_type_id, inventory.quantity, inventory.purchased_at, inventory.expires_at, inventory.location, inventory.club_power, inventory.club_control, inventory.club_accuracy, inventory.club_spin, inventory.club_curve, inventory.caddie_level, inventory.caddie_exp, inventory.mascot_text FROM player
+JOIN inventory ON (player.caddie_id = inventory.item_id)
+WHERE player.player_id = ?
+`
+
+func (q *Queries) GetEquippedCaddie(ctx context.Context, playerID int64) (Inventory, error) {
+	row := q.db.QueryRowContext(ctx, getEquippedCaddie, playerID)
+	var i Inventory
+	err := row.Scan(
+		&i.ItemID,
+		&i.PlayerID,
+		&i.ItemTypeID,
+		&i.Quantity,
+		&i.PurchasedAt,
+		&i.ExpiresAt,
+		&i.Location,
+		&i.ClubPower,
+		&i.ClubControl,
+		&i.ClubAccuracy,
+		&i.ClubSpin,
+		&i.ClubCurve,
+		&i.CaddieLevel,
+		&i.CaddieExp,
+		&i.MascotText,
 	)
 	return i, err
 }
 
 const getPlayer = `-- name: GetPlayer :one
 SELECT
-    player.player_id, player.username, player.nickname, player.password_hash, player.pang, player.points, player.rank, player.ball_type_id, player.mascot_type_id, player.slot0_type_id, player.slot1_type_id, player.slot2_type_id, player.slot3_type_id, player.slot4_type_id, player.slot5_type_id, player.slot6_type_id, player.slot7_type_id, player.slot8_type_id, player.slot9_type_id, player.caddie_id, player.club_id, player.background_id, player.frame_id, player.sticker_id, player.slot_id, player.cut_in_id, player.title_id, player.poster0_id, player.poster1_id, player.character_id, player.exp,
+    player.player_id, player.username, player.nickname, player.password_hash, player.pang, player.points, player.rank, player.ball_type_id, player.slot0_type_id, player.slot1_type_id, player.slot2_type_id, player.slot3_type_id, player.slot4_type_id, player.slot5_type_id, player.slot6_type_id, player.slot7_type_id, player.slot8_type_id, player.slot9_type_id, player.caddie_id, player.club_id, player.background_id, player.frame_id, player.sticker_id, player.slot_id, player.cut_in_id, player.title_id, player.poster0_id, player.poster1_id, player.character_id, player.exp, player.locker_pin_hash, player.locker_failed_attempts, player.locker_locked_until, player.locker_pang, player.mascot_id,
     character.character_id, character.player_id, character.item_id, character.hair_color, character.shirt, character.mastery, character.part00_item_id, character.part01_item_id, character.part02_item_id, character.part03_item_id, character.part04_item_id, character.part05_item_id, character.part06_item_id, character.part07_item_id, character.part08_item_id, character.part09_item_id, character.part10_item_id, character.part11_item_id, character.part12_item_id, character.part13_item_id, character.part14_item_id, character.part15_item_id, character.part16_item_id, character.part17_item_id, character.part18_item_id, character.part19_item_id, character.part20_item_id, character.part21_item_id, character.part22_item_id, character.part23_item_id, character.part00_item_type_id, character.part01_item_type_id, character.part02_item_type_id, character.part03_item_type_id, character.part04_item_type_id, character.part05_item_type_id, character.part06_item_type_id, character.part07_item_type_id, character.part08_item_type_id, character.part09_item_type_id, character.part10_item_type_id, character.part11_item_type_id, character.part12_item_type_id, character.part13_item_type_id, character.part14_item_type_id, character.part15_item_type_id, character.part16_item_type_id, character.part17_item_type_id, character.part18_item_type_id, character.part19_item_type_id, character.part20_item_type_id, character.part21_item_type_id, character.part22_item_type_id, character.part23_item_type_id, character.aux_part0_id, character.aux_part1_id, character.aux_part2_id, character.aux_part3_id, character.aux_part4_id, character.cut_in_id,
     inventory_character.item_type_id  AS character_type_id_,
     inventory_caddie.item_type_id     AS caddie_type_id_,
+    inventory_caddie.caddie_level     AS caddie_level_,
+    inventory_caddie.caddie_exp       AS caddie_exp_,
+    inventory_caddie.expires_at       AS caddie_expires_at_,
+    inventory_mascot.item_type_id     AS mascot_type_id_,
+    inventory_mascot.mascot_text      AS mascot_text,
+    inventory_mascot.expires_at       AS mascot_expires_at_,
     inventory_club.item_type_id       AS club_type_id_,
+    inventory_club.club_power         AS club_power_,
+    inventory_club.club_control       AS club_control_,
+    inventory_club.club_accuracy      AS club_accuracy_,
+    inventory_club.club_spin          AS club_spin_,
+    inventory_club.club_curve         AS club_curve_,
     inventory_background.item_type_id AS background_type_id_,
     inventory_frame.item_type_id      AS frame_type_id_,
     inventory_sticker.item_type_id    AS sticker_type_id_,
@@ -92,6 +136,7 @@ FROM player AS player
 LEFT JOIN character USING (character_id)
 LEFT JOIN inventory AS inventory_character  ON (character.item_id    = inventory_character.item_id)
 LEFT JOIN inventory AS inventory_caddie     ON (player.caddie_id     = inventory_caddie.item_id)
+LEFT JOIN inventory AS inventory_mascot     ON (player.mascot_id     = inventory_mascot.item_id)
 LEFT JOIN inventory AS inventory_club       ON (player.club_id       = inventory_club.item_id)
 LEFT JOIN inventory AS inventory_background ON (player.background_id = inventory_background.item_id)
 LEFT JOIN inventory AS inventory_frame      ON (player.frame_id      = inventory_frame.item_id)
@@ -114,7 +159,6 @@ type GetPlayerRow struct {
 	Points                  int64
 	Rank                    int64
 	BallTypeID              int64
-	MascotTypeID            int64
 	Slot0TypeID             int64
 	Slot1TypeID             int64
 	Slot2TypeID             int64
@@ -137,6 +181,11 @@ type GetPlayerRow struct {
 	Poster1ID               sql.NullInt64
 	CharacterID             sql.NullInt64
 	Exp                     int64
+	LockerPinHash           sql.NullString
+	LockerFailedAttempts    int64
+	LockerLockedUntil       sql.NullInt64
+	LockerPang              int64
+	MascotID                sql.NullInt64
 	CharacterID_2           int64
 	PlayerID_2              int64
 	ItemID                  int64
@@ -199,7 +248,18 @@ type GetPlayerRow struct {
 	CutInID_2               sql.NullInt64
 	CharacterTypeID         sql.NullInt64
 	CaddieTypeID            sql.NullInt64
+	CaddieLevel             sql.NullInt64
+	CaddieExp               sql.NullInt64
+	CaddieExpiresAt  sql.NullInt64
+	MascotTypeID            sql.NullInt64
+	MascotText              sql.NullString
+	MascotExpiresAt  sql.NullInt64
 	ClubTypeID              sql.NullInt64
+	ClubPower               sql.NullInt64
+	ClubControl             sql.NullInt64
+	ClubAccuracy            sql.NullInt64
+	ClubSpin                sql.NullInt64
+	ClubCurve               sql.NullInt64
 	BackgroundTypeID        sql.NullInt64
 	FrameTypeID             sql.NullInt64
 	StickerTypeID           sql.NullInt64
@@ -222,7 +282,6 @@ func (q *Queries) GetPlayer(ctx context.Context, playerID int64) (GetPlayerRow,
 		&i.Points,
 		&i.Rank,
 		&i.BallTypeID,
-		&i.MascotTypeID,
 		&i.Slot0TypeID,
 		&i.Slot1TypeID,
 		&i.Slot2TypeID,
@@ -245,6 +304,11 @@ func (q *Queries) GetPlayer(ctx context.Context, playerID int64) (GetPlayerRow,
 		&i.Poster1ID,
 		&i.CharacterID,
 		&i.Exp,
+		&i.LockerPinHash,
+		&i.LockerFailedAttempts,
+		&i.LockerLockedUntil,
+		&i.LockerPang,
+		&i.MascotID,
 		&i.CharacterID_2,
 		&i.PlayerID_2,
 		&i.ItemID,
@@ -307,7 +371,18 @@ func (q *Queries) GetPlayer(ctx context.Context, playerID int64) (GetPlayerRow,
 		&i.CutInID_2,
 		&i.CharacterTypeID,
 		&i.CaddieTypeID,
+		&i.CaddieLevel,
+		&i.CaddieExp,
+		&i.CaddieExpiresAt,
+		&i.MascotTypeID,
+		&i.MascotText,
+		&i.MascotExpiresAt,
 		&i.ClubTypeID,
+		&i.ClubPower,
+		&i.ClubControl,
+		&i.ClubAccuracy,
+		&i.ClubSpin,
+		&i.ClubCurve,
 		&i.BackgroundTypeID,
 		&i.FrameTypeID,
 		&i.StickerTypeID,
@@ -321,7 +396,7 @@ func (q *Queries) GetPlayer(ctx context.Context, playerID int64) (GetPlayerRow,
 }
 
 const getPlayerByUsername = `-- name: GetPlayerByUsername :one
-SELECT player_id, username, nickname, password_hash, pang, points, rank, ball_type_id, mascot_type_id, slot0_type_id, slot1_type_id, slot2_type_id, slot3_type_id, slot4_type_id, slot5_type_id, slot6_type_id, slot7_type_id, slot8_type_id, slot9_type_id, caddie_id, club_id, background_id, frame_id, sticker_id, slot_id, cut_in_id, title_id, poster0_id, poster1_id, character_id, exp FROM player
+SELECT player_id, username, nickname, password_hash, pang, points, rank, ball_type_id, slot0_type_id, slot1_type_id, slot2_type_id, slot3_type_id, slot4_type_id, slot5_type_id, slot6_type_id, slot7_type_id, slot8_type_id, slot9_type_id, caddie_id, club_id, background_id, frame_id, sticker_id, slot_id, cut_in_id, title_id, poster0_id, poster1_id, character_id, exp, locker_pin_hash, locker_failed_attempts, locker_locked_until, locker_pang, mascot_id FROM player
 WHERE username = ?
 LIMIT 1
 `
@@ -338,7 +413,6 @@ func (q *Queries) GetPlayerByUsername(ctx context.Context, username string) (Pla
 		&i.Points,
 		&i.Rank,
 		&i.BallTypeID,
-		&i.MascotTypeID,
 		&i.Slot0TypeID,
 		&i.Slot1TypeID,
 		&i.Slot2TypeID,
@@ -361,6 +435,11 @@ func (q *Queries) GetPlayerByUsername(ctx context.Context, username string) (Pla
 		&i.Poster1ID,
 		&i.CharacterID,
 		&i.Exp,
+		&i.LockerPinHash,
+		&i.LockerFailedAttempts,
+		&i.LockerLockedUntil,
+		&i.LockerPang,
+		&i.MascotID,
 	)
 	return i, err
 }
@@ -445,7 +524,7 @@ func (q *Queries) GetPlayerRank(ctx context.Context, playerID int64) (GetPlayerR
 }
 
 const setPlayerCaddie = `-- name: SetPlayerCaddie :one
-UPDATE player SET caddie_id = ? WHERE player_id = ? RETURNING player_id, username, nickname, password_hash, pang, points, rank, ball_type_id, mascot_type_id, slot0_type_id, slot1_type_id, slot2_type_id, slot3_type_id, slot4_type_id, slot5_type_id, slot6_type_id, slot7_type_id, slot8_type_id, slot9_type_id, caddie_id, club_id, background_id, frame_id, sticker_id, slot_id, cut_in_id, title_id, poster0_id, poster1_id, character_id, exp
+UPDATE player SET caddie_id = ? WHERE player_id = ? RETURNING player_id, username, nickname, password_hash, pang, points, rank, ball_type_id, slot0_type_id, slot1_type_id, slot2_type_id, slot3_type_id, slot4_type_id, slot5_type_id, slot6_type_id, slot7_type_id, slot8_type_id, slot9_type_id, caddie_id, club_id, background_id, frame_id, sticker_id, slot_id, cut_in_id, title_id, poster0_id, poster1_id, character_id, exp, locker_pin_hash, locker_failed_attempts, locker_locked_until, locker_pang, mascot_id
 `
 
 type SetPlayerCaddieParams struct {
@@ -465,7 +544,6 @@ func (q *Queries) SetPlayerCaddie(ctx context.Context, arg SetPlayerCaddieParams
 		&i.Points,
 		&i.Rank,
 		&i.BallTypeID,
-		&i.MascotTypeID,
 		&i.Slot0TypeID,
 		&i.Slot1TypeID,
 		&i.Slot2TypeID,
@@ -488,12 +566,17 @@ func (q *Queries) SetPlayerCaddie(ctx context.Context, arg SetPlayerCaddieParams
 		&i.Poster1ID,
 		&i.CharacterID,
 		&i.Exp,
+		&i.LockerPinHash,
+		&i.LockerFailedAttempts,
+		&i.LockerLockedUntil,
+		&i.LockerPang,
+		&i.MascotID,
 	)
 	return i, err
 }
 
 const setPlayerCharacter = `-- name: SetPlayerCharacter :one
-UPDATE player SET character_id = ? WHERE player_id = ? RETURNING player_id, username, nickname, password_hash, pang, points, rank, ball_type_id, mascot_type_id, slot0_type_id, slot1_type_id, slot2_type_id, slot3_type_id, slot4_type_id, slot5_type_id, slot6_type_id, slot7_type_id, slot8_type_id, slot9_type_id, caddie_id, club_id, background_id, frame_id, sticker_id, slot_id, cut_in_id, title_id, poster0_id, poster1_id, character_id, exp
+UPDATE player SET character_id = ? WHERE player_id = ? RETURNING player_id, username, nickname, password_hash, pang, points, rank, ball_type_id, slot0_type_id, slot1_type_id, slot2_type_id, slot3_type_id, slot4_type_id, slot5_type_id, slot6_type_id, slot7_type_id, slot8_type_id, slot9_type_id, caddie_id, club_id, background_id, frame_id, sticker_id, slot_id, cut_in_id, title_id, poster0_id, poster1_id, character_id, exp, locker_pin_hash, locker_failed_attempts, locker_locked_until, locker_pang, mascot_id
 `
 
 type SetPlayerCharacterParams struct {
@@ -513,7 +596,6 @@ func (q *Queries) SetPlayerCharacter(ctx context.Context, arg SetPlayerCharacter
 		&i.Points,
 		&i.Rank,
 		&i.BallTypeID,
-		&i.MascotTypeID,
 		&i.Slot0TypeID,
 		&i.Slot1TypeID,
 		&i.Slot2TypeID,
@@ -536,12 +618,17 @@ func (q *Queries) SetPlayerCharacter(ctx context.Context, arg SetPlayerCharacter
 		&i.Poster1ID,
 		&i.CharacterID,
 		&i.Exp,
+		&i.LockerPinHash,
+		&i.LockerFailedAttempts,
+		&i.LockerLockedUntil,
+		&i.LockerPang,
+		&i.MascotID,
 	)
 	return i, err
 }
 
 const setPlayerClubSet = `-- name: SetPlayerClubSet :one
-UPDATE player SET club_id = ? WHERE player_id = ? RETURNING player_id, username, nickname, password_hash, pang, points, rank, ball_type_id, mascot_type_id, slot0_type_id, slot1_type_id, slot2_type_id, slot3_type_id, slot4_type_id, slot5_type_id, slot6_type_id, slot7_type_id, slot8_type_id, slot9_type_id, caddie_id, club_id, background_id, frame_id, sticker_id, slot_id, cut_in_id, title_id, poster0_id, poster1_id, character_id, exp
+UPDATE player SET club_id = ? WHERE player_id = ? RETURNING player_id, username, nickname, password_hash, pang, points, rank, ball_type_id, slot0_type_id, slot1_type_id, slot2_type_id, slot3_type_id, slot4_type_id, slot5_type_id, slot6_type_id, slot7_type_id, slot8_type_id, slot9_type_id, caddie_id, club_id, background_id, frame_id, sticker_id, slot_id, cut_in_id, title_id, poster0_id, poster1_id, character_id, exp, locker_pin_hash, locker_failed_attempts, locker_locked_until, locker_pang, mascot_id
 `
 
 type SetPlayerClubSetParams struct {
@@ -561,7 +648,6 @@ func (q *Queries) SetPlayerClubSet(ctx context.Context, arg SetPlayerClubSetPara
 		&i.Points,
 		&i.Rank,
 		&i.BallTypeID,
-		&i.MascotTypeID,
 		&i.Slot0TypeID,
 		&i.Slot1TypeID,
 		&i.Slot2TypeID,
@@ -584,12 +670,17 @@ func (q *Queries) SetPlayerClubSet(ctx context.Context, arg SetPlayerClubSetPara
 		&i.Poster1ID,
 		&i.CharacterID,
 		&i.Exp,
+		&i.LockerPinHash,
+		&i.LockerFailedAttempts,
+		&i.LockerLockedUntil,
+		&i.LockerPang,
+		&i.MascotID,
 	)
 	return i, err
 }
 
 const setPlayerComet = `-- name: SetPlayerComet :one
-UPDATE player SET ball_type_id = ? WHERE player_id = ? RETURNING player_id, username, nickname, password_hash, pang, points, rank, ball_type_id, mascot_type_id, slot0_type_id, slot1_type_id, slot2_type_id, slot3_type_id, slot4_type_id, slot5_type_id, slot6_type_id, slot7_type_id, slot8_type_id, slot9_type_id, caddie_id, club_id, background_id, frame_id, sticker_id, slot_id, cut_in_id, title_id, poster0_id, poster1_id, character_id, exp
+UPDATE player SET ball_type_id = ? WHERE player_id = ? RETURNING player_id, username, nickname, password_hash, pang, points, rank, ball_type_id, slot0_type_id, slot1_type_id, slot2_type_id, slot3_type_id, slot4_type_id, slot5_type_id, slot6_type_id, slot7_type_id, slot8_type_id, slot9_type_id, caddie_id, club_id, background_id, frame_id, sticker_id, slot_id, cut_in_id, title_id, poster0_id, poster1_id, character_id, exp, locker_pin_hash, locker_failed_attempts, locker_locked_until, locker_pang, mascot_id
 `
 
 type SetPlayerCometParams struct {
@@ -609,7 +700,6 @@ func (q *Queries) SetPlayerComet(ctx context.Context, arg SetPlayerCometParams)
 		&i.Points,
 		&i.Rank,
 		&i.BallTypeID,
-		&i.MascotTypeID,
 		&i.Slot0TypeID,
 		&i.Slot1TypeID,
 		&i.Slot2TypeID,
@@ -632,6 +722,11 @@ func (q *Queries) SetPlayerComet(ctx context.Context, arg SetPlayerCometParams)
 		&i.Poster1ID,
 		&i.CharacterID,
 		&i.Exp,
+		&i.LockerPinHash,
+		&i.LockerFailedAttempts,
+		&i.LockerLockedUntil,
+		&i.LockerPang,
+		&i.MascotID,
 	)
 	return i, err
 }
@@ -650,7 +745,7 @@ SET
     slot8_type_id = ?,
     slot9_type_id = ?
 WHERE player_id = ?
-RETURNING player_id, username, nickname, password_hash, pang, points, rank, ball_type_id, mascot_type_id, slot0_type_id, slot1_type_id, slot2_type_id, slot3_type_id, slot4_type_id, slot5_type_id, slot6_type_id, slot7_type_id, slot8_type_id, slot9_type_id, caddie_id, club_id, background_id, frame_id, sticker_id, slot_id, cut_in_id, title_id, poster0_id, poster1_id, character_id, exp
+RETURNING player_id, username, nickname, password_hash, pang, points, rank, ball_type_id, slot0_type_id, slot1_type_id, slot2_type_id, slot3_type_id, slot4_type_id, slot5_type_id, slot6_type_id, slot7_type_id, slot8_type_id, slot9_type_id, caddie_id, club_id, background_id, frame_id, sticker_id, slot_id, cut_in_id, title_id, poster0_id, poster1_id, character_id, exp, locker_pin_hash, locker_failed_attempts, locker_locked_until, locker_pang, mascot_id
 `
 
 type SetPlayerConsumablesParams struct {
@@ -691,7 +786,6 @@ func (q *Queries) SetPlayerConsumables(ctx context.Context, arg SetPlayerConsuma
 		&i.Points,
 		&i.Rank,
 		&i.BallTypeID,
-		&i.MascotTypeID,
 		&i.Slot0TypeID,
 		&i.Slot1TypeID,
 		&i.Slot2TypeID,
@@ -714,6 +808,11 @@ func (q *Queries) SetPlayerConsumables(ctx context.Context, arg SetPlayerConsuma
 		&i.Poster1ID,
 		&i.CharacterID,
 		&i.Exp,
+		&i.LockerPinHash,
+		&i.LockerFailedAttempts,
+		&i.LockerLockedUntil,
+		&i.LockerPang,
+		&i.MascotID,
 	)
 	return i, err
 }
@@ -750,7 +849,7 @@ SET
     cut_in_id = ?,
     title_id = ?
 WHERE player_id = ?
-RETURNING player_id, username, nickname, password_hash, pang, points, rank, ball_type_id, mascot_type_id, slot0_type_id, slot1_type_id, slot2_type_id, slot3_type_id, slot4_type_id, slot5_type_id, slot6_type_id, slot7_type_id, slot8_type_id, slot9_type_id, caddie_id, club_id, background_id, frame_id, sticker_id, slot_id, cut_in_id, title_id, poster0_id, poster1_id, character_id, exp
+RETURNING player_id, username, nickname, password_hash, pang, points, rank, ball_type_id, slot0_type_id, slot1_type_id, slot2_type_id, slot3_type_id, slot4_type_id, slot5_type_id, slot6_type_id, slot7_type_id, slot8_type_id, slot9_type_id, caddie_id, club_id, background_id, frame_id, sticker_id, slot_id, cut_in_id, title_id, poster0_id, poster1_id, character_id, exp, locker_pin_hash, locker_failed_attempts, locker_locked_until, locker_pang, mascot_id
 `
 
 type SetPlayerDecorationParams struct {
@@ -783,7 +882,6 @@ func (q *Queries) SetPlayerDecoration(ctx context.Context, arg SetPlayerDecorati
 		&i.Points,
 		&i.Rank,
 		&i.BallTypeID,
-		&i.MascotTypeID,
 		&i.Slot0TypeID,
 		&i.Slot1TypeID,
 		&i.Slot2TypeID,
@@ -806,12 +904,69 @@ func (q *Queries) SetPlayerDecoration(ctx context.Context, arg SetPlayerDecorati
 		&i.Poster1ID,
 		&i.CharacterID,
 		&i.Exp,
+		&i.LockerPinHash,
+		&i.LockerFailedAttempts,
+		&i.LockerLockedUntil,
+		&i.LockerPang,
+		&i.MascotID,
+	)
+	return i, err
+}
+
+const setPlayerMascot = `-- name: SetPlayerMascot :one
+UPDATE player SET mascot_id = ? WHERE player_id = ? RETURNING player_id, username, nickname, password_hash, pang, points, rank, ball_type_id, slot0_type_id, slot1_type_id, slot2_type_id, slot3_type_id, slot4_type_id, slot5_type_id, slot6_type_id, slot7_type_id, slot8_type_id, slot9_type_id, caddie_id, club_id, background_id, frame_id, sticker_id, slot_id, cut_in_id, title_id, poster0_id, poster1_id, character_id, exp, locker_pin_hash, locker_failed_attempts, locker_locked_until, locker_pang, mascot_id
+`
+
+type SetPlayerMascotParams struct {
+	MascotID sql.NullInt64
+	PlayerID int64
+}
+
+func (q *Queries) SetPlayerMascot(ctx context.Context, arg SetPlayerMascotParams) (Player, error) {
+	row := q.db.QueryRowContext(ctx, setPlayerMascot, arg.MascotID, arg.PlayerID)
+	var i Player
+	err := row.Scan(
+		&i.PlayerID,
+		&i.Username,
+		&i.Nickname,
+		&i.PasswordHash,
+		&i.Pang,
+		&i.Points,
+		&i.Rank,
+		&i.BallTypeID,
+		&i.Slot0TypeID,
+		&i.Slot1TypeID,
+		&i.Slot2TypeID,
+		&i.Slot3TypeID,
+		&i.Slot4TypeID,
+		&i.Slot5TypeID,
+		&i.Slot6TypeID,
+		&i.Slot7TypeID,
+		&i.Slot8TypeID,
+		&i.Slot9TypeID,
+		&i.CaddieID,
+		&i.ClubID,
+		&i.BackgroundID,
+		&i.FrameID,
+		&i.StickerID,
+		&i.SlotID,
+		&i.CutInID,
+		&i.TitleID,
+		&i.Poster0ID,
+		&i.Poster1ID,
+		&i.CharacterID,
+		&i.Exp,
+		&i.LockerPinHash,
+		&i.LockerFailedAttempts,
+		&i.LockerLockedUntil,
+		&i.LockerPang,
+		&i.MascotID,
 	)
 	return i, err
 }
 
 const setPlayerNickname = `-- name: SetPlayerNickname :one
-UPDATE player SET nickname = ? WHERE player_id = ? RETURNING player_id, username, nickname, password_hash, pang, points, rank, ball_type_id, mascot_type_id, slot0_type_id, slot1_type_id, slot2_type_id, slot3_type_id, slot4_type_id, slot5_type_id, slot6_type_id, slot7_type_id, slot8_type_id, slot9_type_id, caddie_id, club_id, background_id, frame_id, sticker_id, slot_id, cut_in_id, title_id, poster0_id, poster1_id, character_id, exp
+UPDATE player SET nickname = ? WHERE player_id = ? RETURNING player_id, username, nickname, password_hash, pang, points, rank, ball_type_id, slot0_type_id, slot1_type_id, slot2_type_id, slot3_type_id, slot4_type_id, slot5_type_id, slot6_type_id, slot7_type_id, slot8_type_id, slot9_type_id, caddie_id, club_id, background_id, frame_id, sticker_id, slot_id, cut_in_id, title_id, poster0_id, poster1_id, character_id, exp, locker_pin_hash, locker_failed_attempts, locker_locked_until, locker_pang, mascot_id
 `
 
 type SetPlayerNicknameParams struct {
@@ -831,7 +986,6 @@ func (q *Queries) SetPlayerNickname(ctx context.Context, arg SetPlayerNicknamePa
 		&i.Points,
 		&i.Rank,
 		&i.BallTypeID,
-		&i.MascotTypeID,
 		&i.Slot0TypeID,
 		&i.Slot1TypeID,
 		&i.Slot2TypeID,
@@ -854,6 +1008,11 @@ func (q *Queries) SetPlayerNickname(ctx context.Context, arg SetPlayerNicknamePa
 		&i.Poster1ID,
 		&i.CharacterID,
 		&i.Exp,
+		&i.LockerPinHash,
+		&i.LockerFailedAttempts,
+		&i.LockerLockedUntil,
+		&i.LockerPang,
+		&i.MascotID,
 	)
 	return i, err
 }
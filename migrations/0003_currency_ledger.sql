@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE currency_ledger (
+    ledger_id      INTEGER PRIMARY KEY AUTOINCREMENT,
+    player_id      INTEGER REFERENCES player(player_id) ON DELETE CASCADE NOT NULL,
+    reason         INTEGER NOT NULL,
+    pang_delta     INTEGER NOT NULL DEFAULT 0,
+    points_delta   INTEGER NOT NULL DEFAULT 0,
+    pang_balance   INTEGER NOT NULL,
+    points_balance INTEGER NOT NULL,
+    item_type_id   INTEGER NOT NULL DEFAULT 0,
+    note           TEXT NOT NULL DEFAULT '',
+    created_at     INTEGER NOT NULL
+);
+
+CREATE INDEX currency_ledger_player_idx ON currency_ledger (player_id, ledger_id);
+
+-- +goose Down
+DROP INDEX currency_ledger_player_idx;
+DROP TABLE currency_ledger;
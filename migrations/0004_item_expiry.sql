@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE inventory ADD COLUMN purchased_at INTEGER;
+ALTER TABLE inventory ADD COLUMN expires_at INTEGER;
+CREATE INDEX inventory_expires_at_idx ON inventory (expires_at);
+
+-- +goose Down
+DROP INDEX inventory_expires_at_idx;
+ALTER TABLE inventory DROP COLUMN expires_at;
+ALTER TABLE inventory DROP COLUMN purchased_at;
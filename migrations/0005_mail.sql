@@ -0,0 +1,27 @@
+-- +goose Up
+CREATE TABLE mail (
+    mail_id             INTEGER PRIMARY KEY AUTOINCREMENT,
+    sender_player_id    INTEGER REFERENCES player(player_id) ON DELETE SET NULL,
+    sender_nickname     TEXT NOT NULL,
+    recipient_player_id INTEGER REFERENCES player(player_id) ON DELETE CASCADE NOT NULL,
+    body                TEXT NOT NULL,
+    pang                INTEGER NOT NULL DEFAULT 0,
+    is_read             BOOLEAN NOT NULL DEFAULT FALSE,
+    claimed             BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at          INTEGER NOT NULL
+);
+CREATE INDEX mail_recipient_idx ON mail (recipient_player_id, mail_id);
+
+CREATE TABLE mail_attachment (
+    attachment_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    mail_id       INTEGER REFERENCES mail(mail_id) ON DELETE CASCADE NOT NULL,
+    item_type_id  INTEGER NOT NULL,
+    quantity      INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX mail_attachment_mail_idx ON mail_attachment (mail_id);
+
+-- +goose Down
+DROP INDEX mail_attachment_mail_idx;
+DROP TABLE mail_attachment;
+DROP INDEX mail_recipient_idx;
+DROP TABLE mail;
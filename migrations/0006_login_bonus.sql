@@ -0,0 +1,10 @@
+-- +goose Up
+CREATE TABLE login_bonus_streak (
+    player_id      INTEGER PRIMARY KEY REFERENCES player(player_id) ON DELETE CASCADE,
+    calendar       TEXT NOT NULL,
+    streak         INTEGER NOT NULL,
+    last_claim_day INTEGER NOT NULL
+);
+
+-- +goose Down
+DROP TABLE login_bonus_streak;
@@ -0,0 +1,27 @@
+-- +goose Up
+CREATE TABLE player_stats (
+    player_id       INTEGER PRIMARY KEY REFERENCES player(player_id) ON DELETE CASCADE,
+    total_strokes   INTEGER NOT NULL DEFAULT 0,
+    total_putts     INTEGER NOT NULL DEFAULT 0,
+    longest_drive   REAL NOT NULL DEFAULT 0,
+    pangya_hits     INTEGER NOT NULL DEFAULT 0,
+    timeouts        INTEGER NOT NULL DEFAULT 0,
+    obs             INTEGER NOT NULL DEFAULT 0,
+    total_distance  INTEGER NOT NULL DEFAULT 0,
+    total_holes     INTEGER NOT NULL DEFAULT 0,
+    hole_unfinished INTEGER NOT NULL DEFAULT 0,
+    total_hio       INTEGER NOT NULL DEFAULT 0,
+    bunkers_hit     INTEGER NOT NULL DEFAULT 0,
+    fairways_hit    INTEGER NOT NULL DEFAULT 0,
+    total_albatross INTEGER NOT NULL DEFAULT 0,
+    putt_ins        INTEGER NOT NULL DEFAULT 0,
+    longest_putt    REAL NOT NULL DEFAULT 0,
+    longest_chip    REAL NOT NULL DEFAULT 0,
+    total_score     INTEGER NOT NULL DEFAULT 0,
+    best_pang       INTEGER NOT NULL DEFAULT 0,
+    games_played    INTEGER NOT NULL DEFAULT 0,
+    quits           INTEGER NOT NULL DEFAULT 0
+);
+
+-- +goose Down
+DROP TABLE player_stats;
@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE course_record (
+    player_id     INTEGER NOT NULL REFERENCES player(player_id) ON DELETE CASCADE,
+    season        INTEGER NOT NULL,
+    course_id     INTEGER NOT NULL,
+    char_type_id  INTEGER NOT NULL,
+    best_score    INTEGER NOT NULL,
+    best_pang     INTEGER NOT NULL DEFAULT 0,
+    total_strokes INTEGER NOT NULL DEFAULT 0,
+    total_putts   INTEGER NOT NULL DEFAULT 0,
+    total_holes   INTEGER NOT NULL DEFAULT 0,
+    total_score   INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (player_id, season, course_id, char_type_id)
+);
+
+-- +goose Down
+DROP TABLE course_record;
@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE achievement_counter (
+    player_id       INTEGER NOT NULL REFERENCES player(player_id) ON DELETE CASCADE,
+    counter_type_id INTEGER NOT NULL,
+    value           INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (player_id, counter_type_id)
+);
+
+CREATE TABLE achievement_completion (
+    player_id           INTEGER NOT NULL REFERENCES player(player_id) ON DELETE CASCADE,
+    achievement_type_id INTEGER NOT NULL,
+    stage_type_id       INTEGER NOT NULL,
+    completed_at        INTEGER NOT NULL,
+    PRIMARY KEY (player_id, stage_type_id)
+);
+
+-- +goose Down
+DROP TABLE achievement_completion;
+DROP TABLE achievement_counter;
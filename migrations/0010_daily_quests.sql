@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE daily_quest (
+    player_id     INTEGER NOT NULL REFERENCES player(player_id) ON DELETE CASCADE,
+    day           INTEGER NOT NULL,
+    quest_type_id INTEGER NOT NULL,
+    progress      INTEGER NOT NULL DEFAULT 0,
+    claimed       BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (player_id, day, quest_type_id)
+);
+
+-- +goose Down
+DROP TABLE daily_quest;
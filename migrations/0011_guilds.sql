@@ -0,0 +1,33 @@
+-- +goose Up
+CREATE TABLE guild (
+    guild_id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    name             TEXT NOT NULL UNIQUE,
+    description      TEXT NOT NULL DEFAULT '',
+    emblem_image     TEXT NOT NULL DEFAULT '',
+    leader_player_id INTEGER REFERENCES player(player_id) NOT NULL,
+    pang             INTEGER NOT NULL DEFAULT 0,
+    points           INTEGER NOT NULL DEFAULT 0,
+    created_at       INTEGER NOT NULL
+);
+
+CREATE TABLE guild_member (
+    player_id   INTEGER PRIMARY KEY REFERENCES player(player_id) ON DELETE CASCADE,
+    guild_id    INTEGER REFERENCES guild(guild_id) ON DELETE CASCADE NOT NULL,
+    member_role INTEGER NOT NULL,
+    joined_at   INTEGER NOT NULL
+);
+CREATE INDEX guild_member_guild_idx ON guild_member (guild_id);
+
+CREATE TABLE guild_application (
+    guild_id   INTEGER REFERENCES guild(guild_id) ON DELETE CASCADE NOT NULL,
+    player_id  INTEGER REFERENCES player(player_id) ON DELETE CASCADE NOT NULL,
+    message    TEXT NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL,
+    PRIMARY KEY (guild_id, player_id)
+);
+
+-- +goose Down
+DROP TABLE guild_application;
+DROP INDEX guild_member_guild_idx;
+DROP TABLE guild_member;
+DROP TABLE guild;
@@ -0,0 +1,10 @@
+-- +goose Up
+CREATE TABLE papel_pity (
+    player_id INTEGER NOT NULL REFERENCES player(player_id) ON DELETE CASCADE,
+    pool_name TEXT NOT NULL,
+    plays     INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (player_id, pool_name)
+);
+
+-- +goose Down
+DROP TABLE papel_pity;
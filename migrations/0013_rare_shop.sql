@@ -0,0 +1,8 @@
+-- +goose Up
+CREATE TABLE rare_shop_sale (
+    item_type_id INTEGER PRIMARY KEY,
+    sold         INTEGER NOT NULL DEFAULT 0
+);
+
+-- +goose Down
+DROP TABLE rare_shop_sale;
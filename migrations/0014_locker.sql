@@ -0,0 +1,17 @@
+-- +goose Up
+ALTER TABLE player ADD COLUMN locker_pin_hash        TEXT;
+ALTER TABLE player ADD COLUMN locker_failed_attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE player ADD COLUMN locker_locked_until    INTEGER;
+ALTER TABLE player ADD COLUMN locker_pang            INTEGER NOT NULL DEFAULT 0;
+
+-- Items in the locker keep their inventory row, so that upgrades and time
+-- limits survive being stored.
+ALTER TABLE inventory ADD COLUMN location INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE inventory DROP COLUMN location;
+
+ALTER TABLE player DROP COLUMN locker_pang;
+ALTER TABLE player DROP COLUMN locker_locked_until;
+ALTER TABLE player DROP COLUMN locker_failed_attempts;
+ALTER TABLE player DROP COLUMN locker_pin_hash;
@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE myroom_furniture (
+    item_id   INTEGER PRIMARY KEY REFERENCES inventory(item_id) ON DELETE CASCADE,
+    player_id INTEGER NOT NULL REFERENCES player(player_id) ON DELETE CASCADE,
+    pos_x     REAL NOT NULL,
+    pos_y     REAL NOT NULL,
+    pos_z     REAL NOT NULL,
+    rotation  REAL NOT NULL
+);
+
+-- +goose Down
+DROP TABLE myroom_furniture;
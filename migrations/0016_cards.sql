@@ -0,0 +1,14 @@
+-- +goose Up
+-- Character and caddie cards are equipped to a character or caddie item.
+-- NPC slots belong to the player, with an item ID of zero.
+CREATE TABLE card_slot (
+    player_id    INTEGER NOT NULL REFERENCES player(player_id) ON DELETE CASCADE,
+    item_id      INTEGER NOT NULL,
+    slot_kind    INTEGER NOT NULL,
+    slot_num     INTEGER NOT NULL,
+    card_type_id INTEGER NOT NULL,
+    PRIMARY KEY (player_id, item_id, slot_kind, slot_num)
+);
+
+-- +goose Down
+DROP TABLE card_slot;
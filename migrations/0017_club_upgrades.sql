@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE inventory ADD COLUMN club_power    INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE inventory ADD COLUMN club_control  INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE inventory ADD COLUMN club_accuracy INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE inventory ADD COLUMN club_spin     INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE inventory ADD COLUMN club_curve    INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE inventory DROP COLUMN club_curve;
+ALTER TABLE inventory DROP COLUMN club_spin;
+ALTER TABLE inventory DROP COLUMN club_accuracy;
+ALTER TABLE inventory DROP COLUMN club_control;
+ALTER TABLE inventory DROP COLUMN club_power;
@@ -0,0 +1,31 @@
+-- +goose Up
+ALTER TABLE inventory ADD COLUMN caddie_level INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE inventory ADD COLUMN caddie_exp   INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE inventory ADD COLUMN mascot_text  TEXT;
+
+ALTER TABLE player ADD COLUMN mascot_id INTEGER REFERENCES inventory(item_id) ON DELETE SET NULL;
+
+-- Mascots used to be stored as a bare type ID; give each one an item.
+INSERT INTO inventory (player_id, item_type_id)
+SELECT player_id, mascot_type_id FROM player WHERE mascot_type_id != 0;
+
+UPDATE player SET mascot_id = (
+    SELECT max(item_id) FROM inventory
+    WHERE inventory.player_id = player.player_id
+      AND inventory.item_type_id = player.mascot_type_id
+) WHERE mascot_type_id != 0;
+
+ALTER TABLE player DROP COLUMN mascot_type_id;
+
+-- +goose Down
+ALTER TABLE player ADD COLUMN mascot_type_id INTEGER NOT NULL DEFAULT 0;
+
+UPDATE player SET mascot_type_id = coalesce((
+    SELECT item_type_id FROM inventory WHERE inventory.item_id = player.mascot_id
+), 0);
+
+ALTER TABLE player DROP COLUMN mascot_id;
+
+ALTER TABLE inventory DROP COLUMN mascot_text;
+ALTER TABLE inventory DROP COLUMN caddie_exp;
+ALTER TABLE inventory DROP COLUMN caddie_level;
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE player_stats ADD COLUMN team_hole INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE player_stats ADD COLUMN team_win  INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE player_stats ADD COLUMN team_game INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE player_stats DROP COLUMN team_game;
+ALTER TABLE player_stats DROP COLUMN team_win;
+ALTER TABLE player_stats DROP COLUMN team_hole;
@@ -0,0 +1,44 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package pangya
+
+// CaddieExperience is the amount of EXP needed to advance from each caddie
+// level to the next. The last level can not be advanced past.
+var CaddieExperience = []int{
+	500,
+	1500,
+	4000,
+	-1,
+}
+
+// AddCaddieExperience adds EXP to a caddie, advancing its level as needed.
+func AddCaddieExperience(level, current, amount int) (newLevel, newExp int) {
+	sum := current + amount
+	for {
+		if level >= len(CaddieExperience) || CaddieExperience[level] == -1 {
+			// Max level; no EXP.
+			return level, 0
+		}
+		if sum >= CaddieExperience[level] {
+			sum -= CaddieExperience[level]
+			level++
+		} else {
+			return level, sum
+		}
+	}
+}
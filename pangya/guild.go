@@ -20,7 +20,7 @@ package pangya
 type GuildData struct {
 	// TODO: This structure is 100% untested, modified based on Pangya TH.
 	// It is absolutely not going to be right.
-	Unknown          uint32
+	GuildID          uint32
 	GuildName        string `struct:"[17]byte"`
 	Pang             uint32
 	Point            uint32
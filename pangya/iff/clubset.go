@@ -0,0 +1,100 @@
+package iff
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/go-restruct/restruct"
+)
+
+// ClubSet is the data for a club set. Only the fields needed to upgrade
+// clubs are decoded.
+type ClubSet struct {
+	Active bool
+	ID     uint32
+	Name   string
+
+	// Stats are the club set's stats before any upgrades, in the order
+	// power, control, accuracy, spin and curve. MaxStats are the highest
+	// each stat can be upgraded to.
+	Stats    [5]uint16
+	MaxStats [5]uint16
+}
+
+// MaxUpgrades returns how many times a stat can be upgraded.
+func (c *ClubSet) MaxUpgrades(stat int) int {
+	if c.MaxStats[stat] < c.Stats[stat] {
+		return 0
+	}
+	return int(c.MaxStats[stat] - c.Stats[stat])
+}
+
+// ClubSetV13 is the start of a version 13 club set record. The common item
+// data is followed by the club type IDs and stats; the rest of the record is
+// not decoded.
+type ClubSetV13 struct {
+	/* 0x00 */ Active bool
+	/* 0x01 */ _ [3]byte
+	/* 0x04 */ ID uint32
+	/* 0x08 */ Name string `struct:"[64]byte"`
+	/* 0x48 */ _ [0x7C]byte
+	/* 0xC4 */ ClubTypeIDs [4]uint32
+	/* 0xD4 */ Stats [5]uint16
+	/* 0xDE */ MaxStats [5]uint16
+	/* 0xE8 */
+}
+
+type clubSetGeneric interface {
+	Generic() ClubSet
+}
+
+func (c ClubSetV13) Generic() ClubSet {
+	return ClubSet{
+		Active:   c.Active,
+		ID:       c.ID,
+		Name:     c.Name,
+		Stats:    c.Stats,
+		MaxStats: c.MaxStats,
+	}
+}
+
+func LoadClubSets(data []byte) ([]ClubSet, error) {
+	if len(data) < 8 {
+		return nil, errors.New("club set iff too short")
+	}
+	recordCount := int(binary.LittleEndian.Uint16(data[:2]))
+	if recordCount == 0 {
+		return nil, nil
+	}
+	recordLength := (len(data) - 0x8) / recordCount
+	version := Version(binary.LittleEndian.Uint32(data[4:8]))
+
+	switch version {
+	case Version13:
+		return loadClubSetVersion[ClubSetV13](data[8:], recordCount, recordLength)
+	default:
+		return nil, fmt.Errorf("unknown club set iff v%d record size %d (please report)", version, recordLength)
+	}
+}
+
+// loadClubSetVersion decodes the start of each record. Records may be longer
+// than T, since only the leading fields are decoded.
+func loadClubSetVersion[T clubSetGeneric](data []byte, recordCount, recordLength int) ([]ClubSet, error) {
+	size, err := restruct.SizeOf(new(T))
+	if err != nil {
+		return nil, err
+	}
+	if recordLength < size {
+		return nil, fmt.Errorf("club set record size %d is shorter than %d (please report)", recordLength, size)
+	}
+	result := make([]ClubSet, 0, recordCount)
+	for i := 0; i < recordCount; i++ {
+		var record T
+		if err := restruct.Unpack(data[i*recordLength:(i+1)*recordLength], binary.LittleEndian, &record); err != nil {
+			return nil, err
+		}
+		result = append(result, record.Generic())
+	}
+	return result, nil
+}
@@ -0,0 +1,38 @@
+package iff
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/go-restruct/restruct"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadClubSets(t *testing.T) {
+	record, err := restruct.Pack(binary.LittleEndian, &ClubSetV13{
+		Active:   true,
+		ID:       0x10000061,
+		Name:     "Test Club",
+		Stats:    [5]uint16{8, 9, 8, 3, 3},
+		MaxStats: [5]uint16{12, 11, 10, 3, 2},
+	})
+	require.NoError(t, err)
+
+	// Records are longer than the decoded part.
+	record = append(record, make([]byte, 0x10)...)
+	data := []byte{1, 0, 0, 0, byte(Version13), 0, 0, 0}
+	data = append(data, record...)
+
+	clubSets, err := LoadClubSets(data)
+	require.NoError(t, err)
+	require.Len(t, clubSets, 1)
+	assert.Equal(t, uint32(0x10000061), clubSets[0].ID)
+	assert.Equal(t, "Test Club", clubSets[0].Name)
+	assert.Equal(t, 4, clubSets[0].MaxUpgrades(0))
+	assert.Equal(t, 0, clubSets[0].MaxUpgrades(3))
+	assert.Equal(t, 0, clubSets[0].MaxUpgrades(4))
+
+	_, err = LoadClubSets([]byte{1, 0, 0, 0, byte(Version11), 0, 0, 0})
+	assert.Error(t, err)
+}
@@ -31,7 +31,10 @@ import (
 )
 
 type Archive struct {
-	ItemMap map[uint32]*Item
+	ItemMap    map[uint32]*Item
+	ClubSetMap map[uint32]*ClubSet
+	PartMap    map[uint32]*Part
+	AuxPartMap map[uint32]*Part
 }
 
 // Filenames to look for to find client IFF.
@@ -62,34 +65,89 @@ func Load(log zerolog.Logger, data []byte) (*Archive, error) {
 	}
 	for _, f := range r.File {
 		log.Debug().Str("file", f.Name).Msg("found IFF")
-		if f.Name == "Item.iff" {
-			r, err := f.Open()
+		switch f.Name {
+		case "Item.iff":
+			data, err := readZipFile(f)
 			if err != nil {
 				return nil, err
 			}
-			defer r.Close()
-			data, err := io.ReadAll(r)
+			archive.loadItems(data)
+		case "ClubSet.iff":
+			data, err := readZipFile(f)
 			if err != nil {
 				return nil, err
 			}
-			archive.loadItems(data)
+			if err := archive.loadClubSets(data); err != nil {
+				// Club upgrades fall back to the configured limits.
+				log.Warn().Err(err).Msg("could not load club sets")
+			}
+		case "Part.iff", "AuxPart.iff":
+			data, err := readZipFile(f)
+			if err != nil {
+				return nil, err
+			}
+			parts, err := loadPartMap(data)
+			if err != nil {
+				// Character parts are then only checked against the
+				// inventory.
+				log.Warn().Err(err).Str("file", f.Name).Msg("could not load parts")
+				continue
+			}
+			if f.Name == "Part.iff" {
+				archive.PartMap = parts
+			} else {
+				archive.AuxPartMap = parts
+			}
 		}
 	}
 	return archive, nil
 }
 
+func readZipFile(f *zip.File) ([]byte, error) {
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
 func (a *Archive) loadItems(data []byte) error {
 	file, err := LoadItems(data)
 	if err != nil {
 		return err
 	}
 	a.ItemMap = make(map[uint32]*Item)
-	for _, item := range file.Records {
-		a.ItemMap[item.ID] = &item
+	for i := range file.Records {
+		a.ItemMap[file.Records[i].ID] = &file.Records[i]
 	}
 	return nil
 }
 
+func (a *Archive) loadClubSets(data []byte) error {
+	clubSets, err := LoadClubSets(data)
+	if err != nil {
+		return err
+	}
+	a.ClubSetMap = make(map[uint32]*ClubSet)
+	for i := range clubSets {
+		a.ClubSetMap[clubSets[i].ID] = &clubSets[i]
+	}
+	return nil
+}
+
+func loadPartMap(data []byte) (map[uint32]*Part, error) {
+	parts, err := LoadParts(data)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[uint32]*Part)
+	for i := range parts {
+		result[parts[i].ID] = &parts[i]
+	}
+	return result, nil
+}
+
 func LoadItems(data []byte) (*File[Item], error) {
 	recordCount := binary.LittleEndian.Uint16(data[:2])
 	recordLength := (len(data) - 0x8) / int(recordCount)
@@ -1,6 +1,10 @@
 package iff
 
-import "github.com/pangbox/server/pangya"
+import (
+	"time"
+
+	"github.com/pangbox/server/pangya"
+)
 
 type Common struct {
 }
@@ -27,6 +31,101 @@ type Item struct {
 	Unknown2      [4]uint16
 }
 
+// Bits of Item.ShopFlag. These were worked out by comparing the flags of
+// items in retail IFF archives with how the client's shop treats them; bits
+// not listed here have no known meaning and are ignored.
+const (
+	// ShopFlagCash marks items priced in Cookie Points. Without it, the
+	// price is in Pang.
+	ShopFlagCash = 0x01
+
+	// ShopFlagDuplicate marks items that can be owned more than once.
+	ShopFlagDuplicate = 0x02
+
+	// ShopFlagGiftable marks items that can be sent to another player.
+	ShopFlagGiftable = 0x04
+
+	// ShopFlagDisplayOnly marks items the shop shows but won't sell.
+	ShopFlagDisplayOnly = 0x08
+
+	// ShopFlagGiftOnly marks items that can only be bought as a gift.
+	ShopFlagGiftOnly = 0x10
+
+	// ShopFlagRare marks items sold in the Rare Shop instead of the normal
+	// shop.
+	ShopFlagRare = 0x20
+)
+
+// Values of Item.MoneyFlag. MoneyFlag selects the badge the client draws
+// next to an item in the shop. Only these values appear on items the shop
+// sells; items with any other value are handed out some other way, such as
+// by events or Papel Shop, and can't be bought.
+const (
+	MoneyFlagNormal = 0 // No badge.
+	MoneyFlagNew    = 1 // "New" badge.
+	MoneyFlagHot    = 2 // "Hot" badge.
+)
+
+// Buyable returns true if the shop will sell the item: it must be listed
+// with a known MoneyFlag, and its ShopFlag must not restrict it to display
+// or gifting.
+func (i *Item) Buyable() bool {
+	if i.MoneyFlag > MoneyFlagHot {
+		return false
+	}
+	return i.ShopFlag&(ShopFlagDisplayOnly|ShopFlagGiftOnly) == 0
+}
+
+// IsCash returns true if the item is priced in Cookie Points.
+func (i *Item) IsCash() bool {
+	return i.ShopFlag&ShopFlagCash != 0
+}
+
+// IsRare returns true if the item is sold in the Rare Shop.
+func (i *Item) IsRare() bool {
+	return i.ShopFlag&ShopFlagRare != 0
+}
+
+// ShopPrice returns the price the shop charges for the item, taking any
+// discount into account.
+func (i *Item) ShopPrice() uint32 {
+	if i.DiscountPrice != 0 && i.DiscountPrice < i.Price {
+		return i.DiscountPrice
+	}
+	return i.Price
+}
+
+// ShopQuantity returns the number of the item that one purchase gives. Items
+// that aren't sold in bundles have a Quantity of zero, and come one at a time.
+func (i *Item) ShopQuantity() uint32 {
+	if i.Quantity == 0 {
+		return 1
+	}
+	return uint32(i.Quantity)
+}
+
+// OnSale returns true if the item's sale window includes t. Items without a
+// start or end time are always on sale.
+func (i *Item) OnSale(t time.Time) bool {
+	if i.StartTime.IsValid() && t.Before(i.StartTime.Time()) {
+		return false
+	}
+	if i.EndTime.IsValid() && !t.Before(i.EndTime.Time()) {
+		return false
+	}
+	return true
+}
+
+// RentalDuration returns how long the item lasts after being bought, or zero
+// if the item is permanent. For time-limited items, TimeByte holds the number
+// of days.
+func (i *Item) RentalDuration() time.Duration {
+	if i.TimeFlag == 0 || i.TimeByte == 0 {
+		return 0
+	}
+	return time.Duration(i.TimeByte) * 24 * time.Hour
+}
+
 type itemGeneric interface {
 	Generic() Item
 }
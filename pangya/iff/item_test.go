@@ -2,7 +2,9 @@ package iff
 
 import (
 	"testing"
+	"time"
 
+	"github.com/pangbox/server/pangya"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -31,3 +33,42 @@ func testLoadItemVersion(t *testing.T, filename string) {
 	assert.Equal(t, "item0_00", file.Records[0].Icon)
 	assert.Equal(t, "item0_00", file.Records[0].Model)
 }
+
+func TestItemShopPrice(t *testing.T) {
+	assert.Equal(t, uint32(1000), (&Item{Price: 1000}).ShopPrice())
+	assert.Equal(t, uint32(800), (&Item{Price: 1000, DiscountPrice: 800}).ShopPrice())
+	assert.Equal(t, uint32(1000), (&Item{Price: 1000, DiscountPrice: 1200}).ShopPrice())
+}
+
+func TestItemShopQuantity(t *testing.T) {
+	assert.Equal(t, uint32(1), (&Item{}).ShopQuantity())
+	assert.Equal(t, uint32(10), (&Item{Quantity: 10}).ShopQuantity())
+}
+
+func TestItemOnSale(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+	item := &Item{
+		StartTime: pangya.NewSystemTime(start),
+		EndTime:   pangya.NewSystemTime(end),
+	}
+	assert.False(t, item.OnSale(start.Add(-time.Second)))
+	assert.True(t, item.OnSale(start))
+	assert.True(t, item.OnSale(end.Add(-time.Second)))
+	assert.False(t, item.OnSale(end))
+	assert.True(t, (&Item{}).OnSale(end))
+}
+
+func TestItemRentalDuration(t *testing.T) {
+	assert.Equal(t, time.Duration(0), (&Item{}).RentalDuration())
+	assert.Equal(t, time.Duration(0), (&Item{TimeFlag: 1}).RentalDuration())
+	assert.Equal(t, 7*24*time.Hour, (&Item{TimeFlag: 1, TimeByte: 7}).RentalDuration())
+}
+
+func TestItemBuyable(t *testing.T) {
+	assert.True(t, (&Item{}).Buyable())
+	assert.True(t, (&Item{ShopFlag: ShopFlagCash | ShopFlagGiftable, MoneyFlag: MoneyFlagHot}).Buyable())
+	assert.False(t, (&Item{MoneyFlag: MoneyFlagHot + 1}).Buyable())
+	assert.False(t, (&Item{ShopFlag: ShopFlagDisplayOnly}).Buyable())
+	assert.False(t, (&Item{ShopFlag: ShopFlagGiftOnly}).Buyable())
+}
@@ -0,0 +1,57 @@
+package iff
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/go-restruct/restruct"
+)
+
+// Part is a character part or aux part. Only the leading fields that are
+// the same in every version are decoded, which is enough to check that a
+// part exists.
+type Part struct {
+	/* 0x00 */ Active bool
+	/* 0x01 */ _ [3]byte
+	/* 0x04 */ ID uint32
+	/* 0x08 */
+}
+
+// Character returns the index of the character the part is for. Part records
+// have no separate field for it; like the client, this reads it from the
+// record's ID. Character type IDs end with the same index.
+func (p *Part) Character() uint8 {
+	return uint8((p.ID & 0x03FC0000) >> 18)
+}
+
+// Slot returns which of the character's part slots the part goes in. Like
+// Character, this is read from the record's ID.
+func (p *Part) Slot() int {
+	return int((p.ID & 0x0003E000) >> 13)
+}
+
+// LoadParts loads the records of Part.iff or AuxPart.iff.
+func LoadParts(data []byte) ([]Part, error) {
+	if len(data) < 8 {
+		return nil, errors.New("part iff too short")
+	}
+	recordCount := int(binary.LittleEndian.Uint16(data[:2]))
+	if recordCount == 0 {
+		return nil, nil
+	}
+	recordLength := (len(data) - 0x8) / recordCount
+	if recordLength < 0x8 {
+		return nil, fmt.Errorf("part record size %d is too short (please report)", recordLength)
+	}
+	data = data[8:]
+	result := make([]Part, 0, recordCount)
+	for i := 0; i < recordCount; i++ {
+		var record Part
+		if err := restruct.Unpack(data[i*recordLength:i*recordLength+0x8], binary.LittleEndian, &record); err != nil {
+			return nil, err
+		}
+		result = append(result, record)
+	}
+	return result, nil
+}
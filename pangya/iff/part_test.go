@@ -0,0 +1,33 @@
+package iff
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/go-restruct/restruct"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadParts(t *testing.T) {
+	data := []byte{2, 0, 0, 0, byte(Version13), 0, 0, 0}
+	for _, id := range []uint32{0x08000000, 0x08042000} {
+		record, err := restruct.Pack(binary.LittleEndian, &Part{Active: true, ID: id})
+		require.NoError(t, err)
+		// Records are longer than the decoded part.
+		data = append(data, record...)
+		data = append(data, make([]byte, 0x20)...)
+	}
+
+	parts, err := LoadParts(data)
+	require.NoError(t, err)
+	require.Len(t, parts, 2)
+	assert.Equal(t, uint32(0x08000000), parts[0].ID)
+	assert.Equal(t, uint32(0x08042000), parts[1].ID)
+	assert.True(t, parts[1].Active)
+	assert.Equal(t, uint8(1), parts[1].Character())
+	assert.Equal(t, 1, parts[1].Slot())
+
+	_, err = LoadParts([]byte{1, 0})
+	assert.Error(t, err)
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2018-2023, John Chadwick <john@jchw.io>
+//
+// Permission to use, copy, modify, and/or distribute this software for any purpose
+// with or without fee is hereby granted, provided that the above copyright notice
+// and this permission notice appear in all copies.
+//
+// THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH
+// REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF MERCHANTABILITY AND
+// FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+// INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS
+// OF USE, DATA OR PROFITS, WHETHER IN AN ACTION OF CONTRACT, NEGLIGENCE OR OTHER
+// TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+// THIS SOFTWARE.
+//
+// SPDX-FileCopyrightText: Copyright (c) 2018-2023 John Chadwick
+// SPDX-License-Identifier: ISC
+
+package pangya
+
+// ItemGroup is the category of an item, stored in the top bits of its type
+// ID.
+type ItemGroup uint8
+
+const (
+	ItemGroupCharacter ItemGroup = 0x01
+	ItemGroupPart      ItemGroup = 0x02
+	ItemGroupClubSet   ItemGroup = 0x04
+	ItemGroupBall      ItemGroup = 0x05
+	ItemGroupUsable    ItemGroup = 0x06
+	ItemGroupCaddie    ItemGroup = 0x07
+	ItemGroupSkin      ItemGroup = 0x0E
+	ItemGroupMascot    ItemGroup = 0x10
+	ItemGroupAuxPart   ItemGroup = 0x1C
+)
+
+// ItemGroupOf returns the group of an item type ID.
+func ItemGroupOf(typeID uint32) ItemGroup {
+	return ItemGroup(typeID >> 26)
+}
+
+// SkinKind is the kind of a Skin item, such as a cut-in or title, stored in
+// the bits below its group.
+type SkinKind uint8
+
+// SkinKindCutIn is the kind of cut-in items. This is a best guess from the
+// type IDs of known cut-ins.
+const SkinKindCutIn SkinKind = 4
+
+// SkinKindOf returns the kind of a Skin item type ID.
+func SkinKindOf(typeID uint32) SkinKind {
+	return SkinKind((typeID & 0x03E00000) >> 21)
+}
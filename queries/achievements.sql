@@ -0,0 +1,37 @@
+-- name: GetAchievementCounters :many
+SELECT * FROM achievement_counter
+WHERE player_id = ?
+ORDER BY counter_type_id;
+
+-- name: AddAchievementCounter :one
+INSERT INTO achievement_counter (
+    player_id,
+    counter_type_id,
+    value
+) VALUES (
+    ?,
+    ?,
+    ?
+)
+ON CONFLICT (player_id, counter_type_id) DO UPDATE SET
+    value = value + excluded.value
+RETURNING value;
+
+-- name: GetAchievementCompletions :many
+SELECT * FROM achievement_completion
+WHERE player_id = ?
+ORDER BY completed_at;
+
+-- name: CompleteAchievementStage :execrows
+INSERT INTO achievement_completion (
+    player_id,
+    achievement_type_id,
+    stage_type_id,
+    completed_at
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?
+)
+ON CONFLICT (player_id, stage_type_id) DO NOTHING;
@@ -0,0 +1,37 @@
+-- name: GetCardSlotOwners :one
+SELECT character_id, caddie_id FROM player WHERE player_id = ?;
+
+-- name: GetCardSlots :many
+SELECT * FROM card_slot
+WHERE player_id = @player_id AND item_id IN (0, @character_item_id, @caddie_item_id)
+ORDER BY slot_kind, slot_num;
+
+-- name: GetItemCardSlots :many
+SELECT * FROM card_slot
+WHERE player_id = ? AND item_id = ?
+ORDER BY slot_kind, slot_num;
+
+-- name: GetCardSlot :one
+SELECT * FROM card_slot
+WHERE player_id = ? AND item_id = ? AND slot_kind = ? AND slot_num = ?;
+
+-- name: SetCardSlot :exec
+INSERT INTO card_slot (
+    player_id,
+    item_id,
+    slot_kind,
+    slot_num,
+    card_type_id
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+)
+ON CONFLICT (player_id, item_id, slot_kind, slot_num) DO UPDATE SET
+    card_type_id = excluded.card_type_id;
+
+-- name: ClearCardSlot :exec
+DELETE FROM card_slot
+WHERE player_id = ? AND item_id = ? AND slot_kind = ? AND slot_num = ?;
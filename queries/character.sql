@@ -143,6 +143,11 @@ SET
     part21_item_type_id = ?,
     part22_item_type_id = ?,
     part23_item_type_id = ?,
+    aux_part0_id = ?,
+    aux_part1_id = ?,
+    aux_part2_id = ?,
+    aux_part3_id = ?,
+    aux_part4_id = ?,
     cut_in_id = ?
-WHERE character_id = ?
+WHERE character_id = ? AND player_id = ?
 RETURNING *;
@@ -0,0 +1,37 @@
+-- name: GetCourseRecords :many
+SELECT * FROM course_record
+WHERE player_id = ?
+ORDER BY season, course_id, char_type_id;
+
+-- name: AddCourseRecord :one
+INSERT INTO course_record (
+    player_id,
+    season,
+    course_id,
+    char_type_id,
+    best_score,
+    best_pang,
+    total_strokes,
+    total_putts,
+    total_holes,
+    total_score
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+)
+ON CONFLICT (player_id, season, course_id, char_type_id) DO UPDATE SET
+    best_score    = MIN(best_score, excluded.best_score),
+    best_pang     = MAX(best_pang, excluded.best_pang),
+    total_strokes = total_strokes + excluded.total_strokes,
+    total_putts   = total_putts + excluded.total_putts,
+    total_holes   = total_holes + excluded.total_holes,
+    total_score   = total_score + excluded.total_score
+RETURNING *;
@@ -0,0 +1,24 @@
+-- name: AddCurrencyLedgerEntry :one
+INSERT INTO currency_ledger (
+    player_id,
+    reason,
+    pang_delta,
+    points_delta,
+    pang_balance,
+    points_balance,
+    item_type_id,
+    note,
+    created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: GetCurrencyLedgerEntry :one
+SELECT * FROM currency_ledger WHERE ledger_id = ?;
+
+-- name: GetCurrencyLedgerByPlayer :many
+SELECT * FROM currency_ledger
+WHERE player_id = ?
+ORDER BY ledger_id DESC
+LIMIT ?;
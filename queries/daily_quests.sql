@@ -0,0 +1,28 @@
+-- name: GetDailyQuests :many
+SELECT * FROM daily_quest
+WHERE player_id = ? AND day = ?;
+
+-- name: GetDailyQuest :one
+SELECT * FROM daily_quest
+WHERE player_id = ? AND day = ? AND quest_type_id = ?;
+
+-- name: AddDailyQuestProgress :one
+INSERT INTO daily_quest (
+    player_id,
+    day,
+    quest_type_id,
+    progress
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?
+)
+ON CONFLICT (player_id, day, quest_type_id) DO UPDATE SET
+    progress = progress + excluded.progress
+RETURNING *;
+
+-- name: ClaimDailyQuest :execrows
+UPDATE daily_quest
+SET claimed = TRUE
+WHERE player_id = ? AND day = ? AND quest_type_id = ? AND claimed = FALSE;
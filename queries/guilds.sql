@@ -0,0 +1,103 @@
+-- name: CreateGuild :one
+INSERT INTO guild (
+    name,
+    description,
+    emblem_image,
+    leader_player_id,
+    created_at
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+)
+RETURNING *;
+
+-- name: GetGuild :one
+SELECT * FROM guild WHERE guild_id = ?;
+
+-- name: GetGuildByName :one
+SELECT * FROM guild WHERE name = ?;
+
+-- name: SetGuildLeader :exec
+UPDATE guild SET leader_player_id = ? WHERE guild_id = ?;
+
+-- name: DeleteGuild :exec
+DELETE FROM guild WHERE guild_id = ?;
+
+-- name: GetGuildCount :one
+SELECT COUNT(*) FROM guild;
+
+-- name: GetGuildListPage :many
+SELECT
+    guild.*,
+    leader.nickname AS leader_nickname,
+    (SELECT COUNT(*) FROM guild_member AS member WHERE member.guild_id = guild.guild_id) AS num_members
+FROM guild AS guild
+INNER JOIN player AS leader ON (guild.leader_player_id = leader.player_id)
+ORDER BY guild.guild_id
+LIMIT ? OFFSET ?;
+
+-- name: AddGuildMember :exec
+INSERT INTO guild_member (
+    player_id,
+    guild_id,
+    member_role,
+    joined_at
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?
+);
+
+-- name: GetGuildMember :one
+SELECT * FROM guild_member WHERE player_id = ?;
+
+-- name: GetGuildMembers :many
+SELECT * FROM guild_member WHERE guild_id = ? ORDER BY member_role, joined_at;
+
+-- name: GetGuildMemberCount :one
+SELECT COUNT(*) FROM guild_member WHERE guild_id = ?;
+
+-- name: SetGuildMemberRole :exec
+UPDATE guild_member SET member_role = ? WHERE player_id = ?;
+
+-- name: RemoveGuildMember :exec
+DELETE FROM guild_member WHERE player_id = ?;
+
+-- name: GetPlayerGuild :one
+SELECT
+    guild.guild_id,
+    guild.name,
+    guild.emblem_image,
+    guild_member.member_role
+FROM guild_member AS guild_member
+INNER JOIN guild AS guild ON (guild_member.guild_id = guild.guild_id)
+WHERE guild_member.player_id = ?;
+
+-- name: AddGuildApplication :exec
+INSERT INTO guild_application (
+    guild_id,
+    player_id,
+    message,
+    created_at
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?
+)
+ON CONFLICT (guild_id, player_id) DO UPDATE SET
+    message    = excluded.message,
+    created_at = excluded.created_at;
+
+-- name: GetGuildApplications :many
+SELECT * FROM guild_application WHERE guild_id = ? ORDER BY created_at;
+
+-- name: DeleteGuildApplication :execrows
+DELETE FROM guild_application WHERE guild_id = ? AND player_id = ?;
+
+-- name: DeletePlayerGuildApplications :exec
+DELETE FROM guild_application WHERE player_id = ?;
@@ -2,8 +2,12 @@
 INSERT INTO inventory (
     player_id,
     item_type_id,
-    quantity
+    quantity,
+    purchased_at,
+    expires_at
 ) VALUES (
+    ?,
+    ?,
     ?,
     ?,
     ?
@@ -17,10 +21,122 @@ DELETE FROM inventory WHERE player_id = ? AND item_id = ?;
 UPDATE inventory SET quantity = ? WHERE player_id = ? AND item_id = ? RETURNING *;
 
 -- name: GetPlayerInventory :many
-SELECT * FROM inventory WHERE player_id = ?;
+SELECT * FROM inventory WHERE player_id = ? AND location = 0;
 
 -- name: GetItemsByTypeID :many
-SELECT * FROM inventory WHERE player_id = ? AND item_type_id = ?;
+SELECT * FROM inventory WHERE player_id = ? AND item_type_id = ? AND location = 0;
 
 -- name: GetItem :one
-SELECT * FROM inventory WHERE player_id = ? AND item_id = ?;
+SELECT * FROM inventory WHERE player_id = ? AND item_id = ? AND location = 0;
+
+-- name: GetItemsInLocation :many
+SELECT * FROM inventory WHERE player_id = ? AND location = ? ORDER BY item_id;
+
+-- name: GetItemInLocation :one
+SELECT * FROM inventory WHERE player_id = ? AND item_id = ? AND location = ?;
+
+-- name: MoveItem :one
+UPDATE inventory SET
+    player_id = @new_player_id,
+    location = @location
+WHERE player_id = @player_id AND item_id = @item_id
+RETURNING *;
+
+-- name: SetItemExpiry :one
+UPDATE inventory SET expires_at = ? WHERE player_id = ? AND item_id = ? RETURNING *;
+
+-- name: SetClubStats :one
+UPDATE inventory SET
+    club_power    = ?,
+    club_control  = ?,
+    club_accuracy = ?,
+    club_spin     = ?,
+    club_curve    = ?
+WHERE player_id = ? AND item_id = ?
+RETURNING *;
+
+-- name: SetCaddieExp :one
+UPDATE inventory SET
+    caddie_level = ?,
+    caddie_exp   = ?
+WHERE player_id = ? AND item_id = ?
+RETURNING *;
+
+-- name: SetMascotText :one
+UPDATE inventory SET mascot_text = ? WHERE player_id = ? AND item_id = ? RETURNING *;
+
+-- name: GetExpiredItems :many
+SELECT * FROM inventory WHERE location = 0 AND expires_at IS NOT NULL AND expires_at <= ? ORDER BY item_id LIMIT ?;
+
+-- name: UnequipPlayerItem :exec
+UPDATE player SET
+    caddie_id     = NULLIF(caddie_id, @item_id),
+    mascot_id     = NULLIF(mascot_id, @item_id),
+    club_id       = NULLIF(club_id, @item_id),
+    background_id = NULLIF(background_id, @item_id),
+    frame_id      = NULLIF(frame_id, @item_id),
+    sticker_id    = NULLIF(sticker_id, @item_id),
+    slot_id       = NULLIF(slot_id, @item_id),
+    cut_in_id     = NULLIF(cut_in_id, @item_id),
+    title_id      = NULLIF(title_id, @item_id),
+    poster0_id    = NULLIF(poster0_id, @item_id),
+    poster1_id    = NULLIF(poster1_id, @item_id)
+WHERE player_id = @player_id;
+
+-- name: UnequipCharacterItem :exec
+UPDATE character SET
+    part00_item_type_id = IIF(part00_item_id = @item_id, @part00_default_type_id, part00_item_type_id),
+    part00_item_id = NULLIF(part00_item_id, @item_id),
+    part01_item_type_id = IIF(part01_item_id = @item_id, @part01_default_type_id, part01_item_type_id),
+    part01_item_id = NULLIF(part01_item_id, @item_id),
+    part02_item_type_id = IIF(part02_item_id = @item_id, @part02_default_type_id, part02_item_type_id),
+    part02_item_id = NULLIF(part02_item_id, @item_id),
+    part03_item_type_id = IIF(part03_item_id = @item_id, @part03_default_type_id, part03_item_type_id),
+    part03_item_id = NULLIF(part03_item_id, @item_id),
+    part04_item_type_id = IIF(part04_item_id = @item_id, @part04_default_type_id, part04_item_type_id),
+    part04_item_id = NULLIF(part04_item_id, @item_id),
+    part05_item_type_id = IIF(part05_item_id = @item_id, @part05_default_type_id, part05_item_type_id),
+    part05_item_id = NULLIF(part05_item_id, @item_id),
+    part06_item_type_id = IIF(part06_item_id = @item_id, @part06_default_type_id, part06_item_type_id),
+    part06_item_id = NULLIF(part06_item_id, @item_id),
+    part07_item_type_id = IIF(part07_item_id = @item_id, @part07_default_type_id, part07_item_type_id),
+    part07_item_id = NULLIF(part07_item_id, @item_id),
+    part08_item_type_id = IIF(part08_item_id = @item_id, @part08_default_type_id, part08_item_type_id),
+    part08_item_id = NULLIF(part08_item_id, @item_id),
+    part09_item_type_id = IIF(part09_item_id = @item_id, @part09_default_type_id, part09_item_type_id),
+    part09_item_id = NULLIF(part09_item_id, @item_id),
+    part10_item_type_id = IIF(part10_item_id = @item_id, @part10_default_type_id, part10_item_type_id),
+    part10_item_id = NULLIF(part10_item_id, @item_id),
+    part11_item_type_id = IIF(part11_item_id = @item_id, @part11_default_type_id, part11_item_type_id),
+    part11_item_id = NULLIF(part11_item_id, @item_id),
+    part12_item_type_id = IIF(part12_item_id = @item_id, @part12_default_type_id, part12_item_type_id),
+    part12_item_id = NULLIF(part12_item_id, @item_id),
+    part13_item_type_id = IIF(part13_item_id = @item_id, @part13_default_type_id, part13_item_type_id),
+    part13_item_id = NULLIF(part13_item_id, @item_id),
+    part14_item_type_id = IIF(part14_item_id = @item_id, @part14_default_type_id, part14_item_type_id),
+    part14_item_id = NULLIF(part14_item_id, @item_id),
+    part15_item_type_id = IIF(part15_item_id = @item_id, @part15_default_type_id, part15_item_type_id),
+    part15_item_id = NULLIF(part15_item_id, @item_id),
+    part16_item_type_id = IIF(part16_item_id = @item_id, @part16_default_type_id, part16_item_type_id),
+    part16_item_id = NULLIF(part16_item_id, @item_id),
+    part17_item_type_id = IIF(part17_item_id = @item_id, @part17_default_type_id, part17_item_type_id),
+    part17_item_id = NULLIF(part17_item_id, @item_id),
+    part18_item_type_id = IIF(part18_item_id = @item_id, @part18_default_type_id, part18_item_type_id),
+    part18_item_id = NULLIF(part18_item_id, @item_id),
+    part19_item_type_id = IIF(part19_item_id = @item_id, @part19_default_type_id, part19_item_type_id),
+    part19_item_id = NULLIF(part19_item_id, @item_id),
+    part20_item_type_id = IIF(part20_item_id = @item_id, @part20_default_type_id, part20_item_type_id),
+    part20_item_id = NULLIF(part20_item_id, @item_id),
+    part21_item_type_id = IIF(part21_item_id = @item_id, @part21_default_type_id, part21_item_type_id),
+    part21_item_id = NULLIF(part21_item_id, @item_id),
+    part22_item_type_id = IIF(part22_item_id = @item_id, @part22_default_type_id, part22_item_type_id),
+    part22_item_id = NULLIF(part22_item_id, @item_id),
+    part23_item_type_id = IIF(part23_item_id = @item_id, @part23_default_type_id, part23_item_type_id),
+    part23_item_id = NULLIF(part23_item_id, @item_id),
+    aux_part0_id = NULLIF(aux_part0_id, @item_id),
+    aux_part1_id = NULLIF(aux_part1_id, @item_id),
+    aux_part2_id = NULLIF(aux_part2_id, @item_id),
+    aux_part3_id = NULLIF(aux_part3_id, @item_id),
+    aux_part4_id = NULLIF(aux_part4_id, @item_id),
+    cut_in_id = NULLIF(cut_in_id, @item_id)
+WHERE character_id = @character_id AND player_id = @player_id;
@@ -0,0 +1,27 @@
+-- name: GetLocker :one
+SELECT
+    locker_pin_hash,
+    locker_failed_attempts,
+    locker_locked_until,
+    locker_pang
+FROM player
+WHERE player_id = ?;
+
+-- name: SetLockerPINHash :exec
+UPDATE player SET
+    locker_pin_hash = ?,
+    locker_failed_attempts = 0,
+    locker_locked_until = NULL
+WHERE player_id = ?;
+
+-- name: SetLockerFailedAttempts :exec
+UPDATE player SET
+    locker_failed_attempts = ?,
+    locker_locked_until = ?
+WHERE player_id = ?;
+
+-- name: SetLockerPang :exec
+UPDATE player SET locker_pang = ? WHERE player_id = ?;
+
+-- name: IsCharacterItem :one
+SELECT EXISTS(SELECT 1 FROM character WHERE player_id = ? AND item_id = ?);
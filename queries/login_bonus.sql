@@ -0,0 +1,20 @@
+-- name: GetLoginBonusStreak :one
+SELECT * FROM login_bonus_streak WHERE player_id = ?;
+
+-- name: SetLoginBonusStreak :one
+INSERT INTO login_bonus_streak (
+    player_id,
+    calendar,
+    streak,
+    last_claim_day
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?
+)
+ON CONFLICT (player_id) DO UPDATE SET
+    calendar = excluded.calendar,
+    streak = excluded.streak,
+    last_claim_day = excluded.last_claim_day
+RETURNING *;
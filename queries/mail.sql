@@ -0,0 +1,53 @@
+-- name: CreateMail :one
+INSERT INTO mail (
+    sender_player_id,
+    sender_nickname,
+    recipient_player_id,
+    body,
+    pang,
+    created_at
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+)
+RETURNING *;
+
+-- name: AddMailAttachment :one
+INSERT INTO mail_attachment (
+    mail_id,
+    item_type_id,
+    quantity
+) VALUES (
+    ?,
+    ?,
+    ?
+)
+RETURNING *;
+
+-- name: GetMail :one
+SELECT * FROM mail WHERE recipient_player_id = ? AND mail_id = ?;
+
+-- name: GetMailAttachments :many
+SELECT * FROM mail_attachment WHERE mail_id = ? ORDER BY attachment_id;
+
+-- name: GetInboxPage :many
+SELECT * FROM mail
+WHERE recipient_player_id = ?
+ORDER BY mail_id DESC
+LIMIT ? OFFSET ?;
+
+-- name: CountMail :one
+SELECT COUNT(*) FROM mail WHERE recipient_player_id = ?;
+
+-- name: CountUnreadMail :one
+SELECT COUNT(*) FROM mail WHERE recipient_player_id = ? AND is_read = FALSE;
+
+-- name: SetMailRead :exec
+UPDATE mail SET is_read = TRUE WHERE recipient_player_id = ? AND mail_id = ?;
+
+-- name: SetMailClaimed :exec
+UPDATE mail SET claimed = TRUE WHERE recipient_player_id = ? AND mail_id = ?;
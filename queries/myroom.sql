@@ -0,0 +1,31 @@
+-- name: GetMyRoomFurniture :many
+SELECT
+    furniture.*,
+    inventory.item_type_id
+FROM myroom_furniture AS furniture
+INNER JOIN inventory AS inventory ON (inventory.item_id = furniture.item_id)
+WHERE furniture.player_id = ?
+ORDER BY furniture.item_id;
+
+-- name: ClearMyRoomFurniture :exec
+DELETE FROM myroom_furniture WHERE player_id = ?;
+
+-- name: AddMyRoomFurniture :exec
+INSERT INTO myroom_furniture (
+    item_id,
+    player_id,
+    pos_x,
+    pos_y,
+    pos_z,
+    rotation
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+);
+
+-- name: RemoveMyRoomFurnitureItem :exec
+DELETE FROM myroom_furniture WHERE player_id = ? AND item_id = ?;
@@ -0,0 +1,29 @@
+-- name: GetPapelPity :one
+SELECT plays FROM papel_pity
+WHERE player_id = ? AND pool_name = ?;
+
+-- name: IncrementPapelPity :exec
+INSERT INTO papel_pity (
+    player_id,
+    pool_name,
+    plays
+) VALUES (
+    ?,
+    ?,
+    1
+)
+ON CONFLICT (player_id, pool_name) DO UPDATE SET
+    plays = plays + 1;
+
+-- name: ResetPapelPity :exec
+INSERT INTO papel_pity (
+    player_id,
+    pool_name,
+    plays
+) VALUES (
+    ?,
+    ?,
+    0
+)
+ON CONFLICT (player_id, pool_name) DO UPDATE SET
+    plays = 0;
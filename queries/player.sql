@@ -4,7 +4,18 @@ SELECT
     character.*,
     inventory_character.item_type_id  AS character_type_id_FIXNULL,
     inventory_caddie.item_type_id     AS caddie_type_id_FIXNULL,
+    inventory_caddie.caddie_level     AS caddie_level_FIXNULL,
+    inventory_caddie.caddie_exp       AS caddie_exp_FIXNULL,
+    inventory_caddie.expires_at       AS caddie_expires_at_FIXNULL,
+    inventory_mascot.item_type_id     AS mascot_type_id_FIXNULL,
+    inventory_mascot.mascot_text      AS mascot_text,
+    inventory_mascot.expires_at       AS mascot_expires_at_FIXNULL,
     inventory_club.item_type_id       AS club_type_id_FIXNULL,
+    inventory_club.club_power         AS club_power_FIXNULL,
+    inventory_club.club_control       AS club_control_FIXNULL,
+    inventory_club.club_accuracy      AS club_accuracy_FIXNULL,
+    inventory_club.club_spin          AS club_spin_FIXNULL,
+    inventory_club.club_curve         AS club_curve_FIXNULL,
     inventory_background.item_type_id AS background_type_id_FIXNULL,
     inventory_frame.item_type_id      AS frame_type_id_FIXNULL,
     inventory_sticker.item_type_id    AS sticker_type_id_FIXNULL,
@@ -17,6 +28,7 @@ FROM player AS player
 LEFT JOIN character USING (character_id)
 LEFT JOIN inventory AS inventory_character  ON (character.item_id    = inventory_character.item_id)
 LEFT JOIN inventory AS inventory_caddie     ON (player.caddie_id     = inventory_caddie.item_id)
+LEFT JOIN inventory AS inventory_mascot     ON (player.mascot_id     = inventory_mascot.item_id)
 LEFT JOIN inventory AS inventory_club       ON (player.club_id       = inventory_club.item_id)
 LEFT JOIN inventory AS inventory_background ON (player.background_id = inventory_background.item_id)
 LEFT JOIN inventory AS inventory_frame      ON (player.frame_id      = inventory_frame.item_id)
@@ -57,6 +69,14 @@ UPDATE player SET club_id = ? WHERE player_id = ? RETURNING *;
 -- name: SetPlayerCaddie :one
 UPDATE player SET caddie_id = ? WHERE player_id = ? RETURNING *;
 
+-- name: SetPlayerMascot :one
+UPDATE player SET mascot_id = ? WHERE player_id = ? RETURNING *;
+
+-- name: GetEquippedCaddie :one
+SELECT inventory.* FROM player
+JOIN inventory ON (player.caddie_id = inventory.item_id)
+WHERE player.player_id = ?;
+
 -- name: GetPlayerConsumables :one
 SELECT
     slot0_type_id,
@@ -0,0 +1,80 @@
+-- name: GetPlayerStats :one
+SELECT * FROM player_stats WHERE player_id = ?;
+
+-- name: AddPlayerStats :one
+INSERT INTO player_stats (
+    player_id,
+    total_strokes,
+    total_putts,
+    longest_drive,
+    pangya_hits,
+    timeouts,
+    obs,
+    total_distance,
+    total_holes,
+    hole_unfinished,
+    total_hio,
+    bunkers_hit,
+    fairways_hit,
+    total_albatross,
+    putt_ins,
+    longest_putt,
+    longest_chip,
+    total_score,
+    best_pang,
+    games_played,
+    quits,
+    team_hole,
+    team_win,
+    team_game
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+)
+ON CONFLICT (player_id) DO UPDATE SET
+    total_strokes   = total_strokes + excluded.total_strokes,
+    total_putts     = total_putts + excluded.total_putts,
+    longest_drive   = MAX(longest_drive, excluded.longest_drive),
+    pangya_hits     = pangya_hits + excluded.pangya_hits,
+    timeouts        = timeouts + excluded.timeouts,
+    obs             = obs + excluded.obs,
+    total_distance  = total_distance + excluded.total_distance,
+    total_holes     = total_holes + excluded.total_holes,
+    hole_unfinished = hole_unfinished + excluded.hole_unfinished,
+    total_hio       = total_hio + excluded.total_hio,
+    bunkers_hit     = bunkers_hit + excluded.bunkers_hit,
+    fairways_hit    = fairways_hit + excluded.fairways_hit,
+    total_albatross = total_albatross + excluded.total_albatross,
+    putt_ins        = putt_ins + excluded.putt_ins,
+    longest_putt    = MAX(longest_putt, excluded.longest_putt),
+    longest_chip    = MAX(longest_chip, excluded.longest_chip),
+    total_score     = total_score + excluded.total_score,
+    best_pang       = MAX(best_pang, excluded.best_pang),
+    games_played    = games_played + excluded.games_played,
+    quits           = quits + excluded.quits,
+    team_hole       = team_hole + excluded.team_hole,
+    team_win        = team_win + excluded.team_win,
+    team_game       = team_game + excluded.team_game
+RETURNING *;
@@ -0,0 +1,17 @@
+-- name: GetRareShopSales :many
+SELECT * FROM rare_shop_sale;
+
+-- name: GetRareShopSale :one
+SELECT sold FROM rare_shop_sale
+WHERE item_type_id = ?;
+
+-- name: AddRareShopSale :exec
+INSERT INTO rare_shop_sale (
+    item_type_id,
+    sold
+) VALUES (
+    ?,
+    1
+)
+ON CONFLICT (item_type_id) DO UPDATE SET
+    sold = sold + 1;